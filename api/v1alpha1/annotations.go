@@ -0,0 +1,158 @@
+package v1alpha1
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// PausedAnnotation can be set to "true" on a DNSRecord to stop it being
+	// reconciled without deleting it. While paused no changes are made in
+	// the provider zone, including deletion time cleanup, so records can be
+	// frozen during emergency change freezes.
+	PausedAnnotation = "kuadrant.io/paused"
+
+	// AdoptAnnotation can be set to "true" on a DNSRecord with no endpoints
+	// to import the existing records under its rootHost from the provider
+	// zone into the spec, claiming ownership of them via the registry. It
+	// eases migration from manually managed zones or raw external-dns.
+	AdoptAnnotation = "kuadrant.io/adopt"
+
+	// DNSProviderAnnotation can be set on a Gateway to the name of a
+	// provider secret in its namespace. The gateway controller, when
+	// enabled, creates a DNSRecord per listener hostname publishing through
+	// that provider.
+	DNSProviderAnnotation = "kuadrant.io/dns-provider"
+
+	// ConfirmMassDeletionAnnotation can be set to "true" on a DNSRecord to
+	// confirm an apply the deletion safety policy aborted because it would
+	// delete more owned records at once than the operator allows.
+	ConfirmMassDeletionAnnotation = "kuadrant.io/confirm-mass-deletion"
+
+	// DNSSECAnnotation can be set to "true" on a DNSRecord to request
+	// DNSSEC signing of the provider zone holding it, on providers that
+	// support managed signing. The signing state and the DS records to
+	// place in the parent zone are surfaced in the record status.
+	DNSSECAnnotation = "kuadrant.io/dnssec"
+
+	// MigratingAnnotation can be set to "true" on a DNSRecord being moved
+	// to another namespace. Deleting a migrating record skips provider
+	// cleanup, leaving the published endpoints and registry entries in
+	// place for the replacement record to adopt via the migrate-from
+	// annotation, without a visibility gap.
+	MigratingAnnotation = "kuadrant.io/migrating"
+
+	// MigrateFromAnnotation is set on the replacement record of a
+	// migration to the "namespace/name" of the record being replaced. The
+	// registry entries of the source record are claimed for this record in
+	// one atomic registry update while the source still exists, after
+	// which the source (marked migrating) can be deleted without provider
+	// cleanup. The annotation is removed once the adoption has completed.
+	MigrateFromAnnotation = "kuadrant.io/migrate-from"
+
+	// RestoreAnnotation triggers a restore of a ZoneBackup snapshot into
+	// the provider zone. The annotation is removed once the restore has
+	// completed.
+	RestoreAnnotation = "kuadrant.io/restore"
+
+	// RollbackAnnotation can be set on a DNSRecord to re-apply a known-good
+	// endpoint set from the applied-change history in status instead of the
+	// current spec. "true" or "previous" selects the newest set applied for
+	// an earlier generation, a number selects the set applied for that
+	// generation. The RolledBack condition stays set until the spec is
+	// corrected and the annotation removed.
+	RollbackAnnotation = "kuadrant.io/rollback"
+
+	// TakeOverAnnotation can be set on a DNSRecord to the owner ID currently
+	// holding its records in the registry, claiming them for this record in
+	// one atomic registry update. It recovers records orphaned when the
+	// owner ID changed, e.g. after a cluster rebuild changed the UID hash.
+	// The annotation is removed once the takeover has completed.
+	TakeOverAnnotation = "kuadrant.io/take-over-from"
+
+	// MaintenanceWindowsAnnotation holds recurring change-freeze windows for
+	// a single DNSRecord, semicolon separated in the same "[Day] HH:MM-HH:MM"
+	// UTC format as the operator level --maintenance-window flag. While a
+	// window is active no provider mutations are performed for the record;
+	// reads and status updates continue.
+	MaintenanceWindowsAnnotation = "kuadrant.io/maintenance-windows"
+)
+
+// IsPaused returns true if the record has the paused annotation set to a
+// truthy value.
+func (s *DNSRecord) IsPaused() bool {
+	return hasBoolAnnotation(s, PausedAnnotation)
+}
+
+// IsAdopting returns true if the record has the adopt annotation set to a
+// truthy value.
+func (s *DNSRecord) IsAdopting() bool {
+	return hasBoolAnnotation(s, AdoptAnnotation)
+}
+
+// IsDNSSECRequested returns true if the record requests DNSSEC signing of
+// its provider zone.
+func (s *DNSRecord) IsDNSSECRequested() bool {
+	return hasBoolAnnotation(s, DNSSECAnnotation)
+}
+
+// IsMigrating returns true if the record is being migrated to another
+// namespace and must not clean up the provider zone on deletion.
+func (s *DNSRecord) IsMigrating() bool {
+	return hasBoolAnnotation(s, MigratingAnnotation)
+}
+
+// GetMigrationSource returns the "namespace/name" of the record this record
+// replaces and whether an adoption is requested.
+func (s *DNSRecord) GetMigrationSource() (string, bool) {
+	value, ok := s.GetAnnotations()[MigrateFromAnnotation]
+	return value, ok && value != ""
+}
+
+// GetMaintenanceWindows returns the record level maintenance window entries,
+// or nil when none are set.
+func (s *DNSRecord) GetMaintenanceWindows() []string {
+	value, ok := s.GetAnnotations()[MaintenanceWindowsAnnotation]
+	if !ok || value == "" {
+		return nil
+	}
+	var entries []string
+	for _, entry := range strings.Split(value, ";") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// GetRollbackTarget returns the rollback annotation value and whether a
+// rollback is requested.
+func (s *DNSRecord) GetRollbackTarget() (string, bool) {
+	value, ok := s.GetAnnotations()[RollbackAnnotation]
+	return value, ok && value != ""
+}
+
+// GetTakeOverSource returns the owner id a takeover is requested from and
+// whether one is requested.
+func (s *DNSRecord) GetTakeOverSource() (string, bool) {
+	value, ok := s.GetAnnotations()[TakeOverAnnotation]
+	return value, ok && value != ""
+}
+
+// IsMassDeletionConfirmed returns true if an apply exceeding the deletion
+// safety policy has been confirmed on the record.
+func (s *DNSRecord) IsMassDeletionConfirmed() bool {
+	return hasBoolAnnotation(s, ConfirmMassDeletionAnnotation)
+}
+
+func hasBoolAnnotation(record *DNSRecord, annotation string) bool {
+	value, ok := record.GetAnnotations()[annotation]
+	if !ok {
+		return false
+	}
+	val, err := strconv.ParseBool(value)
+	if err != nil {
+		return false
+	}
+	return val
+}
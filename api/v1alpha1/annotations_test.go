@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDNSRecord_IsMigrating(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:        "migrating",
+			annotations: map[string]string{MigratingAnnotation: "true"},
+			want:        true,
+		},
+		{
+			name:        "explicitly not migrating",
+			annotations: map[string]string{MigratingAnnotation: "false"},
+		},
+		{
+			name:        "a non boolean value is ignored",
+			annotations: map[string]string{MigratingAnnotation: "yes please"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &DNSRecord{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			if got := record.IsMigrating(); got != tt.want {
+				t.Errorf("IsMigrating() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDNSRecord_GetMigrationSource(t *testing.T) {
+	record := &DNSRecord{}
+	if _, ok := record.GetMigrationSource(); ok {
+		t.Error("GetMigrationSource() = requested without the annotation")
+	}
+
+	record.Annotations = map[string]string{MigrateFromAnnotation: ""}
+	if _, ok := record.GetMigrationSource(); ok {
+		t.Error("GetMigrationSource() = requested with an empty annotation")
+	}
+
+	record.Annotations[MigrateFromAnnotation] = "old-ns/app-record"
+	source, ok := record.GetMigrationSource()
+	if !ok || source != "old-ns/app-record" {
+		t.Errorf("GetMigrationSource() = %q, %t, want old-ns/app-record requested", source, ok)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSDomainClaimSpec defines the desired state of DNSDomainClaim
+type DNSDomainClaimSpec struct {
+	// domain is the domain suffix being claimed, e.g. "team-a.example.com".
+	// The claim covers the domain itself and everything under it, except
+	// domains covered by a more specific claim.
+	// +kubebuilder:validation:MinLength=1
+	Domain string `json:"domain"`
+
+	// namespaces allowed to publish DNSRecords under the claimed domain.
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Domain",type="string",JSONPath=".spec.domain",description="Claimed domain suffix."
+
+// DNSDomainClaim is the Schema for the dnsdomainclaims API. Cluster admins
+// use claims to declare which namespaces own which domain suffixes;
+// DNSRecords whose rootHost falls under a claimed domain are rejected unless
+// their namespace is listed. Domains without a claim are unrestricted.
+type DNSDomainClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DNSDomainClaimSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DNSDomainClaimList contains a list of DNSDomainClaim
+type DNSDomainClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSDomainClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSDomainClaim{}, &DNSDomainClaimList{})
+}
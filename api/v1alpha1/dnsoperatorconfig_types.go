@@ -0,0 +1,143 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSOperatorConfig conditions. The configuration is read at startup, so a
+// spec change is surfaced as RestartRequired until an instance restarts
+// with it.
+const (
+	ConditionTypeApplied string = "Applied"
+
+	ConditionReasonApplied         string = "Applied"
+	ConditionReasonRestartRequired string = "RestartRequired"
+)
+
+// DNSOperatorConfigSpec defines the desired operator configuration. Every
+// field is optional; unset fields leave the corresponding flag or built-in
+// default in effect, and flags passed explicitly on the command line always
+// win over the configuration resource.
+type DNSOperatorConfigSpec struct {
+	// providers to enable, equivalent to the --provider flag.
+	// +optional
+	Providers []string `json:"providers,omitempty"`
+
+	// maxConcurrentReconciles is the number of DNSRecords reconciled in
+	// parallel by an instance.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+
+	// reconcileInterval is the default re-validation interval of ready
+	// records with no pending changes, overridable per record.
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// providerAPITimeout bounds each provider API call, equivalent to the
+	// --provider-api-timeout flag.
+	// +optional
+	ProviderAPITimeout *metav1.Duration `json:"providerAPITimeout,omitempty"`
+
+	// providerAPIRetries is the number of times a failed provider API call
+	// is retried, equivalent to the --provider-api-retries flag.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ProviderAPIRetries *int `json:"providerAPIRetries,omitempty"`
+
+	// maxDeletesPerApply aborts plans deleting more than this many owned
+	// records at once, equivalent to the --max-deletes-per-apply flag.
+	// +optional
+	MaxDeletesPerApply *int `json:"maxDeletesPerApply,omitempty"`
+
+	// zoneRecordLimit is the per zone record limit used for the
+	// ZoneCapacity condition, equivalent to the --zone-record-limit flag.
+	// +optional
+	ZoneRecordLimit *int `json:"zoneRecordLimit,omitempty"`
+
+	// stalledThreshold is how long a record may stay not Ready with the
+	// same error before it is marked Stalled, equivalent to the
+	// --stalled-threshold flag.
+	// +optional
+	StalledThreshold *metav1.Duration `json:"stalledThreshold,omitempty"`
+
+	// applyDebounce is the quiet period a changed spec must hold before it
+	// is applied, equivalent to the --apply-debounce flag.
+	// +optional
+	ApplyDebounce *metav1.Duration `json:"applyDebounce,omitempty"`
+
+	// flapDampingWindow keeps vanished targets published for this long,
+	// equivalent to the --flap-damping-window flag.
+	// +optional
+	FlapDampingWindow *metav1.Duration `json:"flapDampingWindow,omitempty"`
+
+	// stableOwnerIDs derives default owner ids from the cluster id,
+	// namespace and name instead of the record UID, so a recreated record
+	// re-adopts its provider entries. Equivalent to the --stable-owner-ids
+	// flag.
+	// +optional
+	StableOwnerIDs *bool `json:"stableOwnerIDs,omitempty"`
+
+	// maxRecordsPerNamespace limits the number of DNSRecords a namespace
+	// may hold, equivalent to the --max-records-per-namespace flag.
+	// +optional
+	MaxRecordsPerNamespace *int `json:"maxRecordsPerNamespace,omitempty"`
+
+	// maxEndpointsPerNamespace limits the total endpoints published by a
+	// namespace, equivalent to the --max-endpoints-per-namespace flag.
+	// +optional
+	MaxEndpointsPerNamespace *int `json:"maxEndpointsPerNamespace,omitempty"`
+}
+
+// DNSOperatorConfigStatus defines the observed state of DNSOperatorConfig
+type DNSOperatorConfigStatus struct {
+	// conditions for the configuration.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Applied",type="string",JSONPath=".status.conditions[?(@.type==\"Applied\")].status"
+
+// DNSOperatorConfig is the Schema for the dnsoperatorconfigs API. It is a
+// singleton configuration resource, named by the --operator-config flag and
+// read at startup, so operator configuration can be managed through GitOps
+// instead of deployment flag edits.
+type DNSOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSOperatorConfigSpec   `json:"spec,omitempty"`
+	Status DNSOperatorConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DNSOperatorConfigList contains a list of DNSOperatorConfig
+type DNSOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSOperatorConfig{}, &DNSOperatorConfigList{})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSOwnershipSpec defines the desired state of DNSOwnership
+type DNSOwnershipSpec struct {
+	// entries maps record keys (dnsName/setIdentifier/recordType) to the
+	// registry labels (owner, resource) of that record.
+	// +optional
+	Entries map[string]RegistryLabels `json:"entries,omitempty"`
+}
+
+// RegistryLabels are the registry labels stored for a single owned record.
+type RegistryLabels map[string]string
+
+//+kubebuilder:object:root=true
+
+// DNSOwnership is the Schema for the dnsownerships API. It is used by the
+// crd registry backend to track ownership of provider zone records in the
+// cluster instead of TXT records, for providers or zones where writing a TXT
+// record per owned name is not acceptable or doubles record counts against
+// zone limits.
+//
+// A DNSOwnership is created per provider secret in the secrets namespace and
+// is entirely managed by the operator.
+type DNSOwnership struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DNSOwnershipSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DNSOwnershipList contains a list of DNSOwnership
+type DNSOwnershipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSOwnership `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSOwnership{}, &DNSOwnershipList{})
+}
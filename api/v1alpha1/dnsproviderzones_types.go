@@ -0,0 +1,81 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSProviderZonesSpec defines the desired state of DNSProviderZones
+type DNSProviderZonesSpec struct {
+	// providerRef references the provider secret whose discoverable zones
+	// are surfaced in the status.
+	ProviderRef ProviderRef `json:"providerRef"`
+}
+
+// DNSProviderZonesStatus defines the observed state of DNSProviderZones
+type DNSProviderZonesStatus struct {
+	// zones discoverable through the provider secret.
+	// +optional
+	Zones []ProviderZone `json:"zones,omitempty"`
+
+	// observedAt is when the zones were last discovered.
+	// +optional
+	ObservedAt metav1.Time `json:"observedAt,omitempty"`
+
+	// error carries the discovery error when the provider could not be
+	// reached.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// ProviderZone is a zone discoverable through a provider secret.
+type ProviderZone struct {
+	// id of the zone with the provider.
+	ID string `json:"id"`
+	// dnsName is the domain the zone manages.
+	DNSName string `json:"dnsName"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".spec.providerRef.name",description="Provider secret the zones were discovered through."
+
+// DNSProviderZones is the Schema for the dnsproviderzones API. The operator
+// maintains one per provider secret, listing the zones discoverable through
+// it, so users can see what the operator can target before creating
+// DNSRecords.
+type DNSProviderZones struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSProviderZonesSpec   `json:"spec,omitempty"`
+	Status DNSProviderZonesStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DNSProviderZonesList contains a list of DNSProviderZones
+type DNSProviderZonesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSProviderZones `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSProviderZones{}, &DNSProviderZonesList{})
+}
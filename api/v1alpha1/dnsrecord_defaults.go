@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// DefaultEndpointTTL is applied to any endpoint that does not set an
+// explicit TTL. Keeping this low allows dns based load balancing changes to
+// propagate quickly.
+const DefaultEndpointTTL = externaldnsendpoint.TTL(300)
+
+// stableOwnerIDs mirrors the operators owner id mode, set via
+// SetStableOwnerIDs. While enabled the defaulting webhook must not persist
+// the UID hash into the spec, or the stable id derived at publish time could
+// never apply.
+var stableOwnerIDs bool
+
+// SetStableOwnerIDs configures whether the operator derives stable owner ids
+// at publish time, in which case defaulting leaves an empty spec ownerID in
+// place.
+func SetStableOwnerIDs(enabled bool) {
+	stableOwnerIDs = enabled
+}
+
+// Default applies defaults to a DNSRecord so the stored spec reflects what
+// will actually be applied to the provider zone:
+//
+//   - OwnerID is set to the UID hash used when publishing if not explicitly
+//     set, unless the operator runs with stable owner ids, which are derived
+//     at publish time from an empty spec ownerID.
+//   - Hostnames (rootHost, endpoint dnsNames and in-tree targets) are
+//     normalized to lowercase with any trailing dot removed.
+//   - Endpoints with no TTL get the default TTL.
+func (s *DNSRecord) Default() {
+	// delegating records share a rootHost derived owner id, never a UID one
+	if s.Spec.OwnerID == "" && !s.Spec.Delegate && !stableOwnerIDs && s.GetUID() != "" {
+		s.Spec.OwnerID = s.GetUIDHash()
+	}
+
+	s.Spec.RootHost = normalizeHost(s.Spec.RootHost)
+	for i, host := range s.Spec.AdditionalHosts {
+		s.Spec.AdditionalHosts[i] = normalizeHost(host)
+	}
+
+	for _, ep := range s.Spec.Endpoints {
+		ep.DNSName = normalizeHost(ep.DNSName)
+		for i, target := range ep.Targets {
+			if ep.RecordType == string(CNAMERecordType) || ep.RecordType == string(NSRecordType) {
+				ep.Targets[i] = normalizeHost(target)
+			}
+		}
+		if ep.RecordTTL == 0 {
+			ep.RecordTTL = DefaultEndpointTTL
+		}
+	}
+}
+
+// normalizeHost converts a hostname to its canonical form, lowercase with no
+// trailing dot.
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
@@ -0,0 +1,103 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestDNSRecord_Default(t *testing.T) {
+	record := &DNSRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+			UID:       types.UID("8b0a1dd4-35ea-4840-9a87-9a429c0fcf2e"),
+		},
+		Spec: DNSRecordSpec{
+			RootHost:    "App.Example.Com.",
+			ProviderRef: ProviderRef{Name: "test"},
+			Endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "App.example.com",
+					RecordType: "CNAME",
+					Targets:    []string{"klb.App.Example.com."},
+				},
+				{
+					DNSName:    "klb.app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+					RecordTTL:  60,
+				},
+			},
+		},
+	}
+
+	record.Default()
+
+	if record.Spec.OwnerID != record.GetUIDHash() {
+		t.Errorf("Default() expected ownerID %q, got %q", record.GetUIDHash(), record.Spec.OwnerID)
+	}
+	if record.Spec.RootHost != "app.example.com" {
+		t.Errorf("Default() expected normalized rootHost, got %q", record.Spec.RootHost)
+	}
+	if record.Spec.Endpoints[0].DNSName != "app.example.com" {
+		t.Errorf("Default() expected normalized endpoint dnsName, got %q", record.Spec.Endpoints[0].DNSName)
+	}
+	if record.Spec.Endpoints[0].Targets[0] != "klb.app.example.com" {
+		t.Errorf("Default() expected normalized CNAME target, got %q", record.Spec.Endpoints[0].Targets[0])
+	}
+	if record.Spec.Endpoints[0].RecordTTL != DefaultEndpointTTL {
+		t.Errorf("Default() expected default TTL %d, got %d", DefaultEndpointTTL, record.Spec.Endpoints[0].RecordTTL)
+	}
+	if record.Spec.Endpoints[1].RecordTTL != 60 {
+		t.Errorf("Default() expected explicit TTL to be kept, got %d", record.Spec.Endpoints[1].RecordTTL)
+	}
+
+	// A targets must not be normalized and an empty uid must not produce an owner id
+	record = &DNSRecord{
+		Spec: DNSRecordSpec{
+			RootHost: "app.example.com",
+			Endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+		},
+	}
+	record.Default()
+	if record.Spec.OwnerID != "" {
+		t.Errorf("Default() expected no ownerID for record with no UID, got %q", record.Spec.OwnerID)
+	}
+	if record.Spec.Endpoints[0].Targets[0] != "172.32.200.1" {
+		t.Errorf("Default() expected A target to be unchanged, got %q", record.Spec.Endpoints[0].Targets[0])
+	}
+}
+
+func TestDNSRecord_Default_StableOwnerIDs(t *testing.T) {
+	SetStableOwnerIDs(true)
+	t.Cleanup(func() { SetStableOwnerIDs(false) })
+
+	record := &DNSRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+			UID:       types.UID("8b0a1dd4-35ea-4840-9a87-9a429c0fcf2e"),
+		},
+		Spec: DNSRecordSpec{RootHost: "app.example.com"},
+	}
+	record.Default()
+	if record.Spec.OwnerID != "" {
+		t.Errorf("Default() expected no ownerID in stable owner id mode, got %q", record.Spec.OwnerID)
+	}
+
+	// an explicit ownerID is always kept
+	record.Spec.OwnerID = "explicit"
+	record.Default()
+	if record.Spec.OwnerID != "explicit" {
+		t.Errorf("Default() expected explicit ownerID to be kept, got %q", record.Spec.OwnerID)
+	}
+}
@@ -0,0 +1,690 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/common/hash"
+)
+
+// ProviderRef is a reference to a provider secret in the same namespace as
+// the resource referencing it.
+type ProviderRef struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// DNSRecordSpec defines the desired state of DNSRecord
+type DNSRecordSpec struct {
+	// ownerID is a unique string used to identify the owner of this record.
+	// If unset an owner id will be generated from the record UID and applied
+	// to the status.
+	// +kubebuilder:validation:MinLength=6
+	// +kubebuilder:validation:MaxLength=36
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OwnerID is immutable"
+	// +optional
+	OwnerID string `json:"ownerID,omitempty"`
+
+	// rootHost is the single root for all endpoints in a DNSRecord.
+	// It is expected all defined endpoints are children of or equal to this rootHost.
+	// +kubebuilder:validation:MinLength=1
+	RootHost string `json:"rootHost"`
+
+	// providerRef is a reference to a provider secret used to publish endpoints.
+	ProviderRef ProviderRef `json:"providerRef"`
+
+	// endpoints is a list of endpoints that will be published into the dns provider.
+	// +optional
+	Endpoints []*externaldnsendpoint.Endpoint `json:"endpoints,omitempty"`
+
+	// endpointsFrom references a ConfigMap holding the endpoints instead of
+	// inlining them, for record sets too large for the spec (many gateway
+	// listeners approach the etcd object size limit). Mutually exclusive
+	// with endpoints.
+	// +optional
+	EndpointsFrom *EndpointsFromSource `json:"endpointsFrom,omitempty"`
+
+	// targetsFrom keeps the targets of the rootHost address endpoint in
+	// sync with a cluster source, instead of an external component
+	// rewriting endpoints on every address change.
+	// +optional
+	TargetsFrom *TargetsFromSource `json:"targetsFrom,omitempty"`
+
+	// degradationPolicy selects what happens when the provider can not
+	// satisfy the geo or weighted routing of the endpoints: "None" (the
+	// default) rejects the record, "RoundRobin" strips the routing
+	// properties and publishes plain round-robin RRsets with all targets.
+	// +optional
+	DegradationPolicy DegradationPolicy `json:"degradationPolicy,omitempty"`
+
+	// apexRedirect requests a provider-native redirect from the rootHost
+	// apex to another host (typically apex to www), on providers that
+	// support redirect constructs. A plain CNAME at a zone apex is not
+	// possible.
+	// +optional
+	ApexRedirect *ApexRedirectSpec `json:"apexRedirect,omitempty"`
+
+	// additionalHosts are extra hostnames covered by this record in addition
+	// to rootHost. Endpoints may belong to any listed host, allowing multiple
+	// listener hosts to alias into the rootHost load balancer hierarchy and
+	// share a single CNAME tree instead of each host carrying its own.
+	// +optional
+	AdditionalHosts []string `json:"additionalHosts,omitempty"`
+
+	// reconcileInterval overrides how frequently this record is re-validated
+	// against the provider zone when no changes are pending. Critical
+	// records can use a tighter interval for faster drift detection, bulk
+	// records a relaxed one to save provider API quota.
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// healthCheck configures health checking of the record endpoints by the
+	// provider. Unhealthy endpoints are withdrawn from responses where the
+	// provider supports it.
+	// +optional
+	HealthCheck *HealthCheckSpec `json:"healthCheck,omitempty"`
+
+	// syntheticCheck, when set, performs an HTTP GET against the rootHost
+	// after the record is published, resolving the host through the zones
+	// authoritative nameservers so the check does not depend on local
+	// resolver caches. The outcome is surfaced as the Reachable condition,
+	// closing the loop between the record being applied and users actually
+	// reaching the application.
+	// +optional
+	SyntheticCheck *SyntheticCheckSpec `json:"syntheticCheck,omitempty"`
+
+	// coOwnershipPolicy decides what happens when another tool (e.g. a raw
+	// external-dns instance with a different registry TXT format) is found
+	// managing names under the rootHost. "Refuse" (the default) holds all
+	// provider writes until the conflict is resolved, "TakeOver" keeps
+	// publishing and lets the registries fight it out. Either way the
+	// CoOwnershipDetected condition names the foreign owners.
+	// +optional
+	CoOwnershipPolicy CoOwnershipPolicy `json:"coOwnershipPolicy,omitempty"`
+
+	// dependsOn lists DNSRecords in the same namespace that must be Ready
+	// before this record is published, ordering publication so e.g. the
+	// cluster specific A record set is confirmed live before the top-level
+	// CNAME pointing at it, avoiding transient NXDOMAIN chains during
+	// bootstrap. Deletion is never held back by dependencies.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// delegate marks this record as a member of a delegated rootHost. The
+	// endpoints of all delegating records sharing a rootHost are merged and
+	// published once by the primary operator instance, instead of each
+	// record performing its own provider writes.
+	// +optional
+	Delegate bool `json:"delegate,omitempty"`
+}
+
+// EndpointsFromSource references an external source of record endpoints.
+type EndpointsFromSource struct {
+	// configMapRef names a ConfigMap in the records namespace holding the
+	// endpoints as a JSON array.
+	ConfigMapRef ConfigMapEndpointsRef `json:"configMapRef"`
+}
+
+// ConfigMapEndpointsRef locates the endpoints JSON within a ConfigMap.
+type ConfigMapEndpointsRef struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// key holding the endpoints JSON array. Defaults to "endpoints".
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// DefaultEndpointsKey is the ConfigMap key read when endpointsFrom does not
+// name one.
+const DefaultEndpointsKey = "endpoints"
+
+// TargetsFromSource selects the cluster source the rootHost targets are
+// derived from. Exactly one source must be set.
+type TargetsFromSource struct {
+	// serviceRef names a Service in the records namespace. Its load
+	// balancer ingress addresses, or externalIPs when there are none,
+	// become the targets.
+	// +optional
+	ServiceRef *ServiceTargetRef `json:"serviceRef,omitempty"`
+
+	// endpointSliceSelector selects EndpointSlices in the records namespace
+	// by label. The ready addresses of the selected slices become the
+	// targets.
+	// +optional
+	EndpointSliceSelector map[string]string `json:"endpointSliceSelector,omitempty"`
+
+	// nodes derives the targets from Node addresses, for NodePort or
+	// hostNetwork gateways where the node IPs are the ingress addresses.
+	// +optional
+	Nodes *NodeTargetSource `json:"nodes,omitempty"`
+}
+
+// NodeAddressType selects which node address becomes a target.
+// +kubebuilder:validation:Enum=ExternalIP;InternalIP
+type NodeAddressType string
+
+const (
+	NodeAddressTypeExternalIP NodeAddressType = "ExternalIP"
+	NodeAddressTypeInternalIP NodeAddressType = "InternalIP"
+)
+
+// NodeTargetSource selects cluster nodes and the address type published for
+// them.
+type NodeTargetSource struct {
+	// selector restricts the nodes by label. An empty selector matches all
+	// nodes.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+
+	// addressType published per node. Defaults to ExternalIP, falling back
+	// to InternalIP on nodes without one.
+	// +optional
+	AddressType NodeAddressType `json:"addressType,omitempty"`
+}
+
+// DegradationPolicy selects the fallback behaviour against providers
+// without native geo or weighted record support.
+// +kubebuilder:validation:Enum=None;RoundRobin
+type DegradationPolicy string
+
+const (
+	// DegradationPolicyNone rejects records the provider can not satisfy.
+	DegradationPolicyNone DegradationPolicy = "None"
+	// DegradationPolicyRoundRobin publishes plain RRsets with all targets,
+	// trading routing behaviour for availability.
+	DegradationPolicyRoundRobin DegradationPolicy = "RoundRobin"
+)
+
+// CoOwnershipPolicy selects the behaviour when a foreign tool is found
+// managing names under the rootHost.
+// +kubebuilder:validation:Enum=Refuse;TakeOver
+type CoOwnershipPolicy string
+
+const (
+	// CoOwnershipPolicyRefuse holds provider writes while a foreign owner
+	// manages names under the rootHost.
+	CoOwnershipPolicyRefuse CoOwnershipPolicy = "Refuse"
+	// CoOwnershipPolicyTakeOver keeps publishing despite the foreign owner.
+	CoOwnershipPolicyTakeOver CoOwnershipPolicy = "TakeOver"
+)
+
+// ApexRedirectSpec describes a redirect from the rootHost apex.
+type ApexRedirectSpec struct {
+	// target host requests for the rootHost are redirected to, e.g.
+	// "www.example.com".
+	// +kubebuilder:validation:MinLength=1
+	Target string `json:"target"`
+
+	// permanent redirects with 301 instead of 302.
+	// +optional
+	Permanent bool `json:"permanent,omitempty"`
+}
+
+// SyntheticCheckSpec configures the post-publish HTTP check of the
+// rootHost.
+type SyntheticCheckSpec struct {
+	// path requested on the rootHost, e.g. "/healthz".
+	// +kubebuilder:default:="/"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// port to connect to. Defaults to the standard port of the protocol.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int `json:"port,omitempty"`
+
+	// protocol to check with.
+	// +kubebuilder:default:=HTTPS
+	// +optional
+	Protocol Protocol `json:"protocol,omitempty"`
+}
+
+// ServiceTargetRef references a Service in the same namespace.
+type ServiceTargetRef struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// Protocol is a scheme used when health checking an endpoint.
+// +kubebuilder:validation:Enum=HTTP;HTTPS
+type Protocol string
+
+const (
+	HttpProtocol  Protocol = "HTTP"
+	HttpsProtocol Protocol = "HTTPS"
+)
+
+// HealthCheckSpec configures health checking of the addresses behind a
+// record.
+type HealthCheckSpec struct {
+	// path probed on each address, e.g. "/healthz".
+	Path string `json:"path"`
+
+	// port to connect to.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int `json:"port"`
+
+	// protocol to probe with.
+	Protocol Protocol `json:"protocol"`
+
+	// failureThreshold is the number of consecutive probe failures after
+	// which an address is considered unhealthy.
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int `json:"failureThreshold"`
+
+	// interval between probes.
+	Interval metav1.Duration `json:"interval"`
+
+	// adaptiveWeights scales the weight of each weighted endpoint by the
+	// recent probe success ratio of its targets, smoothing traffic away
+	// from degraded clusters instead of removing them outright.
+	// +optional
+	AdaptiveWeights bool `json:"adaptiveWeights,omitempty"`
+}
+
+// InFlightChange identifies a submitted change batch through a token derived
+// from the record generation and the batch contents.
+type InFlightChange struct {
+	// token of the submitted batch.
+	Token string `json:"token"`
+	// time the batch was submitted.
+	Time metav1.Time `json:"time"`
+}
+
+// FailedApplySummary records the change batch submitted by a failed provider
+// apply. Entry lists are truncated to keep the status bounded.
+type FailedApplySummary struct {
+	// time the apply failed.
+	Time metav1.Time `json:"time"`
+	// error the provider returned.
+	Error string `json:"error"`
+	// creates, updates and deletes describe the submitted changes as
+	// "<type> <dnsName>" entries, with the set identifier appended when
+	// present.
+	// +optional
+	Creates []string `json:"creates,omitempty"`
+	// +optional
+	Updates []string `json:"updates,omitempty"`
+	// +optional
+	Deletes []string `json:"deletes,omitempty"`
+}
+
+// AppliedChange records one successful apply, for reconstructing what DNS
+// looked like at a point in time without provider console access.
+type AppliedChange struct {
+	// time the apply succeeded.
+	Time metav1.Time `json:"time"`
+	// generation of the spec that produced the change batch.
+	Generation int64 `json:"generation"`
+	// endpoints is the desired endpoint set that was applied, kept so a
+	// known-good set can be re-applied on rollback.
+	// +optional
+	Endpoints []*externaldnsendpoint.Endpoint `json:"endpoints,omitempty"`
+	// creates, updates and deletes describe the applied changes as
+	// "<type> <dnsName>" entries, with the set identifier appended when
+	// present.
+	// +optional
+	Creates []string `json:"creates,omitempty"`
+	// +optional
+	Updates []string `json:"updates,omitempty"`
+	// +optional
+	Deletes []string `json:"deletes,omitempty"`
+}
+
+// DNSRecordStatus defines the observed state of DNSRecord
+type DNSRecordStatus struct {
+	// conditions are any conditions associated with the record in the dns provider.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// observedGeneration is the most recently observed generation of the
+	// DNSRecord. When the DNSRecord is updated, the controller updates the
+	// corresponding record in each managed zone. If an update for a
+	// particular zone fails, that failure is recorded in the status
+	// condition for the zone so that the controller can determine that it
+	// needs to retry the update for that specific zone.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// QueuedAt is a time when DNS record was received for the reconciliation
+	// +optional
+	QueuedAt metav1.Time `json:"queuedAt,omitempty"`
+
+	// ValidFor indicates duration since the last reconciliation we consider data in the record to be valid
+	// +optional
+	ValidFor string `json:"validFor,omitempty"`
+
+	// WriteCounter represent a number of consecutive write attempts on the same generation of the record.
+	// It is being reset to 0 when the generation changes or there are no changes to write.
+	// +optional
+	WriteCounter int64 `json:"writeCounter,omitempty"`
+
+	// nextCheckAt is the time the record will next be checked against the
+	// provider zone.
+	// +optional
+	NextCheckAt metav1.Time `json:"nextCheckAt,omitempty"`
+
+	// DeleteCounter is the number of failed provider cleanup attempts while
+	// the record is being deleted.
+	// +optional
+	DeleteCounter int64 `json:"deleteCounter,omitempty"`
+
+	// endpoints are the last endpoints that were successfully published to the provider zone
+	// +optional
+	Endpoints []*externaldnsendpoint.Endpoint `json:"endpoints,omitempty"`
+
+	// ownerID is the owner id that was used to publish this record, either
+	// taken from the spec or generated from the record UID.
+	// +optional
+	OwnerID string `json:"ownerID,omitempty"`
+
+	// DomainOwners is a list of all the owners working against the root domain of this record
+	// +optional
+	DomainOwners []string `json:"domainOwners,omitempty"`
+
+	// zoneID is the id of the zone the record is published into.
+	// +optional
+	ZoneID string `json:"zoneID,omitempty"`
+
+	// zoneProject is the provider side scope the selected zone lives in
+	// (a GCP project, an Azure "subscription/resourceGroup" pair), when
+	// the provider searches several.
+	// +optional
+	ZoneProject string `json:"zoneProject,omitempty"`
+
+	// providerRecordCount is the number of provider records, including the
+	// registry TXT records, this record materializes into. It drives cost
+	// and quota estimation per application.
+	// +optional
+	ProviderRecordCount int `json:"providerRecordCount,omitempty"`
+
+	// providerOperationID identifies a long-running provider operation the
+	// last apply submitted, polled to completion across reconciles.
+	// +optional
+	ProviderOperationID string `json:"providerOperationID,omitempty"`
+
+	// inFlightChange identifies a change batch submitted to the provider
+	// whose outcome is not yet confirmed, so a reconcile retry after an
+	// ambiguous provider timeout does not double-apply it.
+	// +optional
+	InFlightChange *InFlightChange `json:"inFlightChange,omitempty"`
+
+	// appliedChanges is a bounded history of successful applies, most
+	// recent first.
+	// +optional
+	AppliedChanges []AppliedChange `json:"appliedChanges,omitempty"`
+
+	// lastFailedApply summarizes the change batch of the most recent failed
+	// provider apply, so what may have partially applied can be reconciled
+	// manually. Cleared on the next successful apply.
+	// +optional
+	LastFailedApply *FailedApplySummary `json:"lastFailedApply,omitempty"`
+
+	// dsRecords are the delegation signer records of the provider zone
+	// that must be placed in the parent zone, populated when DNSSEC has
+	// been requested and the provider signs the zone.
+	// +optional
+	DSRecords []string `json:"dsRecords,omitempty"`
+
+	// zoneDomainName is the domain name of the zone the record is published into.
+	// +optional
+	ZoneDomainName string `json:"zoneDomainName,omitempty"`
+
+	// rootHost is the A-label (punycode) wire form of the spec rootHost as
+	// published to the provider. It matches spec.rootHost for plain ASCII
+	// hosts; for internationalized hosts the spec keeps the display form
+	// and this field shows the published one.
+	// +optional
+	RootHost string `json:"rootHost,omitempty"`
+
+	// endpointCount is the number of endpoints in the last applied spec.
+	// +optional
+	EndpointCount int `json:"endpointCount,omitempty"`
+
+	// summary is a single human readable string summarising the current
+	// state of the record, suitable for display in a kubectl column.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="DNSRecord ready."
+//+kubebuilder:printcolumn:name="RootHost",type="string",JSONPath=".spec.rootHost",description="Root host of the record."
+//+kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".status.zoneDomainName",description="Provider zone the record is published into."
+//+kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".spec.providerRef.name",description="Referenced provider secret."
+//+kubebuilder:printcolumn:name="Endpoints",type="integer",JSONPath=".status.endpointCount",description="Number of endpoints in the last applied spec."
+//+kubebuilder:printcolumn:name="Last Applied",type="date",JSONPath=".status.queuedAt",description="Time the record was last written to the provider."
+//+kubebuilder:printcolumn:name="Summary",type="string",JSONPath=".status.summary",priority=1,description="Summary of the current record state."
+
+// DNSRecord is the Schema for the dnsrecords API
+type DNSRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSRecordSpec   `json:"spec,omitempty"`
+	Status DNSRecordStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DNSRecordList contains a list of DNSRecord
+type DNSRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSRecord `json:"items"`
+}
+
+// DNSRecordType is a DNS resource record type.
+// +kubebuilder:validation:Enum=CNAME;A
+type DNSRecordType string
+
+const (
+	// ARecordType is an RFC 1035 A record.
+	ARecordType DNSRecordType = "A"
+
+	// AAAARecordType is an RFC 3596 AAAA record.
+	AAAARecordType DNSRecordType = "AAAA"
+
+	// CNAMERecordType is an RFC 1035 CNAME record.
+	CNAMERecordType DNSRecordType = "CNAME"
+
+	// NSRecordType is a RFC 1035 NS record.
+	NSRecordType DNSRecordType = "NS"
+
+	// TXTRecordType is a RFC 1035 TXT record.
+	TXTRecordType DNSRecordType = "TXT"
+)
+
+const (
+	// ConditionTypeReady indicates the record will be ready when all
+	// endpoints have been successfully published to, and validated in, the
+	// dns provider zone.
+	ConditionTypeReady string = "Ready"
+
+	// ConditionTypeZoneCapacity degrades when the provider zone the record
+	// publishes into approaches the provider record limit.
+	ConditionTypeZoneCapacity string = "ZoneCapacity"
+
+	// ConditionTypeStalled is set when the record has been not Ready with
+	// the same error for longer than the configured threshold, giving
+	// alerting a stable signal that does not require parsing free-form
+	// messages.
+	ConditionTypeStalled string = "Stalled"
+
+	// ConditionTypePreflight reports the outcome of the pre-publish checks
+	// of the public CAA/DNSSEC configuration around the rootHost.
+	ConditionTypePreflight string = "Preflight"
+
+	// ConditionTypeDNSSEC reports the signing state of the provider zone
+	// when DNSSEC has been requested on the record.
+	ConditionTypeDNSSEC string = "DNSSEC"
+
+	// ConditionTypeDeletionBlocked is set when provider cleanup of a record
+	// being deleted keeps failing, so automation can detect and escalate
+	// stuck deletions.
+	ConditionTypeDeletionBlocked string = "DeletionBlocked"
+
+	// ConditionTypeApexRedirect reports whether the requested apex
+	// redirect is in place in the provider.
+	ConditionTypeApexRedirect string = "ApexRedirect"
+
+	// ConditionTypeDegraded is set when the record is published with its
+	// routing degraded per its degradationPolicy, so the fallback is
+	// visible rather than silent.
+	ConditionTypeDegraded string = "Degraded"
+
+	// ConditionTypeReconciling is set while the record is progressing
+	// towards the desired state, per the kstatus conventions GitOps tooling
+	// (Argo CD, Flux) uses to assess health: Reconciling means in progress,
+	// Stalled means failed, neither plus Ready means healthy. Without it a
+	// partially applied record would appear healthy.
+	ConditionTypeReconciling string = "Reconciling"
+
+	// ConditionTypeVerified reports whether live DNS resolution matches the
+	// answer a DNSVerification declares.
+	ConditionTypeVerified string = "Verified"
+
+	// ConditionTypeReachable reports the outcome of the post-publish
+	// synthetic HTTP check of the rootHost.
+	ConditionTypeReachable string = "Reachable"
+
+	// ConditionTypeRolledBack is set while a rollback annotation keeps a
+	// known-good endpoint set from the applied-change history published
+	// instead of the current spec.
+	ConditionTypeRolledBack string = "RolledBack"
+
+	// ConditionTypeCoOwnershipDetected is set when ownership records of a
+	// foreign tool, e.g. a raw external-dns instance, are found for names
+	// under the rootHost.
+	ConditionTypeCoOwnershipDetected string = "CoOwnershipDetected"
+)
+
+const (
+	ConditionReasonProviderSuccess      string = "ProviderSuccess"
+	ConditionReasonAwaitingValidation   string = "AwaitingValidation"
+	ConditionReasonProviderError        string = "DNSProviderError"
+	ConditionReasonValidationError      string = "ValidationError"
+	ConditionReasonUnableToRetrieve     string = "UnableToRetrieveDNSRecord"
+	ConditionReasonPaused               string = "Paused"
+	ConditionReasonDelegated            string = "Delegated"
+	ConditionReasonAwaitingConfirmation string = "AwaitingConfirmation"
+	ConditionReasonApplying             string = "Applying"
+	ConditionReasonFrozen               string = "Frozen"
+	ConditionReasonRetriesExhausted     string = "RetriesExhausted"
+	ConditionReasonDependenciesNotReady string = "DependenciesNotReady"
+
+	ConditionReasonWithinRecordLimit      string = "WithinRecordLimit"
+	ConditionReasonApproachingRecordLimit string = "ApproachingRecordLimit"
+
+	ConditionReasonChecksPassed string = "ChecksPassed"
+	ConditionReasonChecksFailed string = "ChecksFailed"
+
+	ConditionReasonSigningHealthy      string = "SigningHealthy"
+	ConditionReasonSigningUnhealthy    string = "SigningUnhealthy"
+	ConditionReasonSigningNotSupported string = "SigningNotSupported"
+
+	ConditionReasonRoundRobinFallback string = "RoundRobinFallback"
+
+	ConditionReasonRedirectEnsured      string = "RedirectEnsured"
+	ConditionReasonRedirectError        string = "RedirectError"
+	ConditionReasonRedirectNotSupported string = "RedirectNotSupported"
+
+	ConditionReasonVerificationPassed string = "VerificationPassed"
+	ConditionReasonVerificationFailed string = "VerificationFailed"
+	ConditionReasonQueryError         string = "QueryError"
+
+	ConditionReasonHostReachable   string = "HostReachable"
+	ConditionReasonHostUnreachable string = "HostUnreachable"
+
+	ConditionReasonQuotaExceeded string = "QuotaExceeded"
+
+	ConditionReasonRollbackActive string = "RollbackActive"
+	ConditionReasonRollbackFailed string = "RollbackFailed"
+
+	ConditionReasonForeignOwner string = "ForeignOwner"
+)
+
+const WildcardPrefix = "*."
+
+// GetRootHost returns the rootHost of the record with any wildcard prefix
+// removed, in punycode form for internationalized hosts.
+func (s *DNSRecord) GetRootHost() string {
+	return stripWildcard(PunycodeHost(s.Spec.RootHost))
+}
+
+// GetHosts returns all hosts covered by the record, the rootHost and any
+// additionalHosts, with wildcard prefixes removed and internationalized
+// hosts in punycode form.
+func (s *DNSRecord) GetHosts() []string {
+	hosts := []string{s.GetRootHost()}
+	for _, host := range s.Spec.AdditionalHosts {
+		hosts = append(hosts, stripWildcard(PunycodeHost(host)))
+	}
+	return hosts
+}
+
+// stripWildcard removes any wildcard prefix from a host.
+func stripWildcard(host string) string {
+	host, _ = strings.CutPrefix(host, WildcardPrefix)
+	return host
+}
+
+// GetUIDHash returns a hash of the records UID suitable for use as an owner
+// id when none is set in the spec.
+func (s *DNSRecord) GetUIDHash() string {
+	return hash.ToBase36HashLen(string(s.GetUID()), 8)
+}
+
+// GetStableOwnerID returns a deterministic owner id derived from the cluster
+// id and the records namespace and name. Unlike the UID hash it survives the
+// record being deleted and recreated, so a recreated record re-adopts its
+// provider entries instead of orphaning them.
+func (s *DNSRecord) GetStableOwnerID(clusterID string) string {
+	return hash.ToBase36HashLen(clusterID+"/"+s.Namespace+"/"+s.Name, 8)
+}
+
+// GetOwnerID returns the owner id that should be used when publishing the
+// record, preferring an explicit spec value over the UID generated default.
+// Delegating records share an owner id derived from the rootHost so the
+// merged record set has a single owner regardless of which member record
+// triggered the publish.
+func (s *DNSRecord) GetOwnerID() string {
+	if s.Spec.Delegate {
+		return hash.ToBase36HashLen("delegated."+s.GetRootHost(), 8)
+	}
+	if s.Spec.OwnerID != "" {
+		return s.Spec.OwnerID
+	}
+	return s.GetUIDHash()
+}
+
+// GetProviderRef returns the provider secret reference for the record.
+func (s *DNSRecord) GetProviderRef() ProviderRef {
+	return s.Spec.ProviderRef
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSRecord{}, &DNSRecordList{})
+}
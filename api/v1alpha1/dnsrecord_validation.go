@@ -0,0 +1,370 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// Validate performs semantic validation of a DNSRecord spec that can not be
+// expressed in the CRD schema. It is used by the validating webhook to give
+// synchronous feedback on create/update, and by the controller as a guard
+// for records admitted before the webhook was installed.
+func (s *DNSRecord) Validate() error {
+	rootHost := s.Spec.RootHost
+	if strings.HasPrefix(rootHost, WildcardPrefix) || !isASCII(rootHost) {
+		rootHost = s.GetRootHost()
+	}
+	if err := validateWildcard(s.Spec.RootHost); err != nil {
+		return fmt.Errorf("invalid rootHost: %w", err)
+	}
+	if _, err := punycodeHost(s.Spec.RootHost); err != nil {
+		return fmt.Errorf("invalid rootHost: %w", err)
+	}
+	// underscore labels stay allowed on hosts, TXT endpoints may live at them
+	if err := validateDNSName(PunycodeHost(s.Spec.RootHost), true); err != nil {
+		return fmt.Errorf("invalid rootHost: %w", err)
+	}
+	if len(strings.Split(rootHost, ".")) <= 1 {
+		return fmt.Errorf("invalid rootHost %q, must contain at least two groups", s.Spec.RootHost)
+	}
+	if s.Spec.ReconcileInterval != nil && s.Spec.ReconcileInterval.Duration < 5*time.Second {
+		return fmt.Errorf("invalid reconcileInterval %q, must be at least 5s", s.Spec.ReconcileInterval.Duration)
+	}
+	if err := s.Spec.HealthCheck.Validate(); err != nil {
+		return err
+	}
+	if err := s.Spec.SyntheticCheck.Validate(); err != nil {
+		return err
+	}
+	if s.Spec.EndpointsFrom != nil && len(s.Spec.Endpoints) > 0 {
+		return fmt.Errorf("endpoints and endpointsFrom are mutually exclusive")
+	}
+	if size := endpointsSize(s.Spec.Endpoints); size > MaxEndpointsSpecSize {
+		return fmt.Errorf("endpoints too large (%d bytes, limit %d), reference them from a ConfigMap via endpointsFrom instead", size, MaxEndpointsSpecSize)
+	}
+	if err := s.Spec.TargetsFrom.Validate(); err != nil {
+		return err
+	}
+	for _, dependency := range s.Spec.DependsOn {
+		if dependency == s.Name {
+			return fmt.Errorf("invalid dependsOn %q, a record can not depend on itself", dependency)
+		}
+	}
+	if redirect := s.Spec.ApexRedirect; redirect != nil && PunycodeHost(redirect.Target) == rootHost {
+		return fmt.Errorf("invalid apexRedirect target %q, must differ from the rootHost", redirect.Target)
+	}
+	hosts := s.GetHosts()
+	for i, host := range hosts[1:] {
+		if err := validateWildcard(s.Spec.AdditionalHosts[i]); err != nil {
+			return fmt.Errorf("invalid additionalHost: %w", err)
+		}
+		if _, err := punycodeHost(s.Spec.AdditionalHosts[i]); err != nil {
+			return fmt.Errorf("invalid additionalHost: %w", err)
+		}
+		if err := validateDNSName(PunycodeHost(s.Spec.AdditionalHosts[i]), true); err != nil {
+			return fmt.Errorf("invalid additionalHost: %w", err)
+		}
+		if len(strings.Split(host, ".")) <= 1 {
+			return fmt.Errorf("invalid additionalHost %q, must contain at least two groups", s.Spec.AdditionalHosts[i])
+		}
+		if host == rootHost {
+			return fmt.Errorf("invalid additionalHost %q, already covered by the rootHost", s.Spec.AdditionalHosts[i])
+		}
+	}
+	return validateEndpoints(hosts, s.Spec.Endpoints)
+}
+
+// Validate checks the health check fields are usable. A nil spec is valid,
+// health checking is optional.
+func (s *HealthCheckSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if !strings.HasPrefix(s.Path, "/") {
+		return fmt.Errorf("invalid healthCheck path %q, must start with \"/\"", s.Path)
+	}
+	if s.Port <= 0 || s.Port > 65535 {
+		return fmt.Errorf("invalid healthCheck port %d", s.Port)
+	}
+	if s.Protocol != HttpProtocol && s.Protocol != HttpsProtocol {
+		return fmt.Errorf("invalid healthCheck protocol %q, must be %q or %q", s.Protocol, HttpProtocol, HttpsProtocol)
+	}
+	if s.FailureThreshold <= 0 {
+		return fmt.Errorf("invalid healthCheck failureThreshold %d, must be greater than 0", s.FailureThreshold)
+	}
+	if s.Interval.Duration < 5*time.Second {
+		return fmt.Errorf("invalid healthCheck interval %q, must be at least 5s", s.Interval.Duration)
+	}
+	return nil
+}
+
+// Validate checks the synthetic check fields are usable. A nil spec is
+// valid, the check is optional.
+func (s *SyntheticCheckSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.Path != "" && !strings.HasPrefix(s.Path, "/") {
+		return fmt.Errorf("invalid syntheticCheck path %q, must start with \"/\"", s.Path)
+	}
+	if s.Port < 0 || s.Port > 65535 {
+		return fmt.Errorf("invalid syntheticCheck port %d", s.Port)
+	}
+	if s.Protocol != "" && s.Protocol != HttpProtocol && s.Protocol != HttpsProtocol {
+		return fmt.Errorf("invalid syntheticCheck protocol %q, must be %q or %q", s.Protocol, HttpProtocol, HttpsProtocol)
+	}
+	return nil
+}
+
+// Validate checks exactly one target source is set. A nil source is valid,
+// target syncing is optional.
+func (s *TargetsFromSource) Validate() error {
+	if s == nil {
+		return nil
+	}
+	sources := 0
+	if s.ServiceRef != nil {
+		sources++
+	}
+	if len(s.EndpointSliceSelector) > 0 {
+		sources++
+	}
+	if s.Nodes != nil {
+		sources++
+	}
+	if sources != 1 {
+		return fmt.Errorf("invalid targetsFrom, exactly one source must be set")
+	}
+	return nil
+}
+
+// maxTXTLength is the RFC 1035 length limit of a single TXT character
+// string.
+const maxTXTLength = 255
+
+// RFC 1035 length limits of a domain name and of a single label within it.
+const (
+	maxDNSNameLength = 253
+	maxLabelLength   = 63
+)
+
+// validateDNSName checks a name against the RFC 1035 limits: 253 characters
+// overall, labels of 1 to 63 characters made of letters, digits and hyphens,
+// with hyphens neither starting nor ending a label. Labels starting with an
+// underscore are a convention of TXT and SRV style names and are only
+// accepted when underscoreAllowed is set. A leading wildcard label and a
+// trailing dot are tolerated, both are handled elsewhere.
+func validateDNSName(name string, underscoreAllowed bool) error {
+	host := strings.TrimSuffix(name, ".")
+	host, _ = strings.CutPrefix(host, WildcardPrefix)
+	if len(host) > maxDNSNameLength {
+		return fmt.Errorf("name is %d characters, limit %d", len(host), maxDNSNameLength)
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" {
+			return fmt.Errorf("name %q contains an empty label", name)
+		}
+		if len(label) > maxLabelLength {
+			return fmt.Errorf("label %q is %d characters, limit %d", label, len(label), maxLabelLength)
+		}
+		rest := label
+		if strings.HasPrefix(label, "_") {
+			if !underscoreAllowed {
+				return fmt.Errorf("label %q, underscore labels are only valid for TXT and SRV records", label)
+			}
+			rest = label[1:]
+		}
+		for i := 0; i < len(rest); i++ {
+			c := rest[i]
+			if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' {
+				continue
+			}
+			return fmt.Errorf("label %q contains invalid character %q", label, string(c))
+		}
+		if strings.HasPrefix(rest, "-") || strings.HasSuffix(rest, "-") {
+			return fmt.Errorf("label %q must not start or end with a hyphen", label)
+		}
+	}
+	return nil
+}
+
+// underscoreRecordType reports whether underscore labels are conventional
+// for the given record type, e.g. _acme-challenge TXT or _service._tcp SRV
+// names.
+func underscoreRecordType(recordType string) bool {
+	return recordType == string(TXTRecordType) || recordType == "SRV"
+}
+
+// validateWildcard checks that a wildcard, if present, is the entire leftmost
+// label of the name. A "*" as a partial label or anywhere deeper in the name
+// is not a valid RRset name in any provider.
+func validateWildcard(name string) error {
+	rest, found := strings.CutPrefix(name, WildcardPrefix)
+	if !found {
+		rest = name
+	}
+	if strings.Contains(rest, "*") {
+		return fmt.Errorf("invalid wildcard in %q, \"*\" must be the entire leftmost label", name)
+	}
+	return nil
+}
+
+// validateTargets checks the target formats of an endpoint against its
+// record type, so malformed records are rejected at admission instead of
+// surfacing as opaque provider errors.
+func validateTargets(ep *externaldnsendpoint.Endpoint) error {
+	if len(ep.Targets) == 0 {
+		return fmt.Errorf("invalid endpoint %q, must have at least one target", ep.DNSName)
+	}
+	switch ep.RecordType {
+	case string(ARecordType):
+		for _, target := range ep.Targets {
+			if ip := net.ParseIP(target); ip == nil || ip.To4() == nil {
+				return fmt.Errorf("invalid target %q of A endpoint %q, must be an IPv4 address", target, ep.DNSName)
+			}
+		}
+	case string(AAAARecordType):
+		for _, target := range ep.Targets {
+			if ip := net.ParseIP(target); ip == nil || ip.To4() != nil {
+				return fmt.Errorf("invalid target %q of AAAA endpoint %q, must be an IPv6 address", target, ep.DNSName)
+			}
+		}
+	case string(CNAMERecordType):
+		if len(ep.Targets) > 1 {
+			return fmt.Errorf("invalid CNAME endpoint %q, must have exactly one target", ep.DNSName)
+		}
+		target := ep.Targets[0]
+		if net.ParseIP(target) != nil || len(strings.Split(strings.TrimSuffix(target, "."), ".")) <= 1 {
+			return fmt.Errorf("invalid target %q of CNAME endpoint %q, must be a fully qualified domain name", target, ep.DNSName)
+		}
+		if strings.Contains(target, "*") {
+			return fmt.Errorf("invalid target %q of CNAME endpoint %q, must not contain a wildcard", target, ep.DNSName)
+		}
+		if _, err := punycodeHost(target); err != nil {
+			return fmt.Errorf("invalid target of CNAME endpoint %q: %w", ep.DNSName, err)
+		}
+		if err := validateDNSName(PunycodeHost(target), false); err != nil {
+			return fmt.Errorf("invalid target %q of CNAME endpoint %q: %w", target, ep.DNSName, err)
+		}
+	case string(TXTRecordType):
+		for _, target := range ep.Targets {
+			if len(strings.Trim(target, "\"")) > maxTXTLength {
+				return fmt.Errorf("invalid target of TXT endpoint %q, character strings are limited to %d bytes", ep.DNSName, maxTXTLength)
+			}
+		}
+	}
+	return nil
+}
+
+// MaxEndpointsSpecSize is the serialized size limit of inline endpoints.
+// It stays well below the etcd object size limit because the endpoints
+// appear in the object twice, in the spec and in the published status.
+const MaxEndpointsSpecSize = 512 * 1024
+
+// endpointsSize returns the serialized size of the endpoints in bytes.
+func endpointsSize(endpoints []*externaldnsendpoint.Endpoint) int {
+	if len(endpoints) == 0 {
+		return 0
+	}
+	raw, err := json.Marshal(endpoints)
+	if err != nil {
+		return 0
+	}
+	return len(raw)
+}
+
+// underAny reports whether name is equal to or a subdomain of any host.
+func underAny(name string, hosts []string) bool {
+	for _, host := range hosts {
+		if name == host || strings.HasSuffix(name, "."+host) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateEndpoints(hosts []string, endpoints []*externaldnsendpoint.Endpoint) error {
+	// record types published at each dnsName, used to detect CNAME conflicts
+	typesByName := map[string]map[string]struct{}{}
+	// dnsName + setIdentifier pairs seen so far, used to detect duplicates
+	seen := map[string]struct{}{}
+
+	for i, ep := range endpoints {
+		if err := validateWildcard(ep.DNSName); err != nil {
+			return fmt.Errorf("invalid endpoint (endpoints[%d]): %w", i, err)
+		}
+		// compare in punycode form so internationalized dnsNames match hosts
+		// regardless of which form either was written in
+		dnsName, err := punycodeHost(ep.DNSName)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint (endpoints[%d]): %w", i, err)
+		}
+		if err := validateDNSName(dnsName, underscoreRecordType(ep.RecordType)); err != nil {
+			return fmt.Errorf("invalid endpoint %q (endpoints[%d]): %w", ep.DNSName, i, err)
+		}
+		if !underAny(dnsName, hosts) {
+			return fmt.Errorf("invalid endpoint %q, must be equal to or a subdomain of the rootHost %q or an additionalHost", ep.DNSName, hosts[0])
+		}
+
+		// the zone infrastructure records are never ours to manage: a SOA
+		// is owned by the provider, and NS at the rootHost could rewrite
+		// the delegation of the zone apex itself
+		if ep.RecordType == "SOA" {
+			return fmt.Errorf("invalid endpoint %q, SOA records are managed by the DNS provider", ep.DNSName)
+		}
+		if ep.RecordType == "NS" && dnsName == hosts[0] {
+			return fmt.Errorf("invalid endpoint %q, NS records at the rootHost would rewrite the zone delegation", ep.DNSName)
+		}
+
+		// the record type is part of the identity: an A and an AAAA RRset at
+		// the same dnsName is a valid dual-stack pair, not a duplicate
+		key := dnsName + "#" + ep.RecordType + "#" + ep.SetIdentifier
+		if _, ok := seen[key]; ok {
+			if ep.SetIdentifier == "" {
+				return fmt.Errorf("duplicate endpoint for dnsName %q", ep.DNSName)
+			}
+			return fmt.Errorf("duplicate endpoint for dnsName %q with setIdentifier %q", ep.DNSName, ep.SetIdentifier)
+		}
+		seen[key] = struct{}{}
+
+		if err := validateTargets(ep); err != nil {
+			return fmt.Errorf("endpoints[%d]: %w", i, err)
+		}
+
+		if typesByName[dnsName] == nil {
+			typesByName[dnsName] = map[string]struct{}{}
+		}
+		typesByName[dnsName][ep.RecordType] = struct{}{}
+	}
+
+	for dnsName, types := range typesByName {
+		if _, hasCNAME := types[string(CNAMERecordType)]; hasCNAME && len(types) > 1 {
+			return fmt.Errorf("invalid endpoint %q, CNAME record conflicts with other record types for the same dnsName", dnsName)
+		}
+	}
+
+	// Any CNAME target that points back into the record's own domain tree
+	// must have a corresponding endpoint, otherwise the record can never
+	// resolve for the rootHost.
+	for _, ep := range endpoints {
+		if ep.RecordType != string(CNAMERecordType) {
+			continue
+		}
+		for _, target := range ep.Targets {
+			target := PunycodeHost(target)
+			if !underAny(target, hosts) {
+				continue
+			}
+			if _, ok := typesByName[target]; !ok {
+				return fmt.Errorf("invalid target %q of endpoint %q, no endpoint found for it in the record", target, ep.DNSName)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,489 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestDNSRecord_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		rootHost        string
+		additionalHosts []string
+		endpoints       []*externaldnsendpoint.Endpoint
+		wantErr         string
+	}{
+		{
+			name:     "valid record",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					RecordType: "CNAME",
+					Targets:    []string{"klb.app.example.com"},
+				},
+				{
+					DNSName:    "klb.app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+		},
+		{
+			name:     "valid wildcard record",
+			rootHost: "*.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "*.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+		},
+		{
+			name:     "rootHost with single group",
+			rootHost: "example",
+			wantErr:  "must contain at least two groups",
+		},
+		{
+			name:     "wildcard not the leftmost label",
+			rootHost: "app.*.example.com",
+			wantErr:  "must be the entire leftmost label",
+		},
+		{
+			name:     "wildcard as a partial label",
+			rootHost: "w*.example.com",
+			wantErr:  "must be the entire leftmost label",
+		},
+		{
+			name:     "wildcard endpoint under a concrete rootHost",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "*.app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+		},
+		{
+			name:     "wildcard endpoint with an inner wildcard label",
+			rootHost: "*.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "*.sub.*.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+			wantErr: "must be the entire leftmost label",
+		},
+		{
+			name:     "CNAME conflict at a wildcard name",
+			rootHost: "*.app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:       "*.app.example.com",
+					RecordType:    "CNAME",
+					SetIdentifier: "one",
+					Targets:       []string{"lb.external.net"},
+				},
+				{
+					DNSName:       "*.app.example.com",
+					RecordType:    "A",
+					SetIdentifier: "two",
+					Targets:       []string{"172.32.200.1"},
+				},
+			},
+			wantErr: "CNAME record conflicts",
+		},
+		{
+			name:     "CNAME target with a wildcard",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					RecordType: "CNAME",
+					Targets:    []string{"*.lb.external.net"},
+				},
+			},
+			wantErr: "must not contain a wildcard",
+		},
+		{
+			name:            "wildcard additionalHost",
+			rootHost:        "app.example.com",
+			additionalHosts: []string{"*.app.example.org"},
+		},
+		{
+			name:            "additionalHost with a misplaced wildcard",
+			rootHost:        "app.example.com",
+			additionalHosts: []string{"app.*.example.org"},
+			wantErr:         "must be the entire leftmost label",
+		},
+		{
+			name:     "IDN rootHost with endpoints in either form",
+			rootHost: "café.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "café.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+				{
+					DNSName:    "api.xn--caf-dma.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.2"},
+				},
+			},
+		},
+		{
+			name:     "IDN endpoint duplicated across forms",
+			rootHost: "café.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "café.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+				{
+					DNSName:    "xn--caf-dma.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.2"},
+				},
+			},
+			wantErr: "duplicate endpoint",
+		},
+		{
+			name:     "unconvertible IDN rootHost",
+			rootHost: "a☃b--.example.com",
+			wantErr:  "invalid internationalized host",
+		},
+		{
+			name:     "label over 63 characters",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    strings.Repeat("a", 64) + ".app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+			wantErr: "limit 63",
+		},
+		{
+			name:     "name over 253 characters",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    strings.Repeat("abcdefghi.", 25) + "app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+			wantErr: "limit 253",
+		},
+		{
+			name:     "underscore label on an A endpoint",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "_x.app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+			wantErr: "underscore labels are only valid",
+		},
+		{
+			name:     "underscore label on a TXT endpoint",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "_acme-challenge.app.example.com",
+					RecordType: "TXT",
+					Targets:    []string{"\"token\""},
+				},
+			},
+		},
+		{
+			name:     "invalid character in a label",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "bad!.app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+			wantErr: "invalid character",
+		},
+		{
+			name:     "error names the offending endpoint index",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+				{
+					DNSName:    "-bad.app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.2"},
+				},
+			},
+			wantErr: "endpoints[1]",
+		},
+		{
+			name:     "CNAME target with an underscore label",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					RecordType: "CNAME",
+					Targets:    []string{"_lb.external.net"},
+				},
+			},
+			wantErr: "underscore labels are only valid",
+		},
+		{
+			name:     "endpoint not in rootHost domain",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "other.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+			wantErr: "must be equal to or a subdomain of the rootHost",
+		},
+		{
+			name:     "duplicate dnsName and setIdentifier",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:       "app.example.com",
+					RecordType:    "A",
+					SetIdentifier: "eu",
+					Targets:       []string{"172.32.200.1"},
+				},
+				{
+					DNSName:       "app.example.com",
+					RecordType:    "A",
+					SetIdentifier: "eu",
+					Targets:       []string{"172.32.200.2"},
+				},
+			},
+			wantErr: "duplicate endpoint",
+		},
+		{
+			name:     "dual-stack A and AAAA at the same dnsName",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+				{
+					DNSName:    "app.example.com",
+					RecordType: "AAAA",
+					Targets:    []string{"2001:db8::1"},
+				},
+			},
+		},
+		{
+			name:     "CNAME conflicts with A for the same dnsName",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:       "app.example.com",
+					RecordType:    "CNAME",
+					SetIdentifier: "one",
+					Targets:       []string{"klb.app.example.com"},
+				},
+				{
+					DNSName:       "app.example.com",
+					RecordType:    "A",
+					SetIdentifier: "two",
+					Targets:       []string{"172.32.200.1"},
+				},
+				{
+					DNSName:    "klb.app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+			wantErr: "CNAME record conflicts",
+		},
+		{
+			name:     "in tree CNAME target without endpoint",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					RecordType: "CNAME",
+					Targets:    []string{"klb.app.example.com"},
+				},
+			},
+			wantErr: "no endpoint found for it in the record",
+		},
+		{
+			name:            "endpoints under an additionalHost share the rootHost tree",
+			rootHost:        "app.example.com",
+			additionalHosts: []string{"app.example.org"},
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.org",
+					RecordType: "CNAME",
+					Targets:    []string{"klb.app.example.com"},
+				},
+				{
+					DNSName:    "app.example.com",
+					RecordType: "CNAME",
+					Targets:    []string{"klb.app.example.com"},
+				},
+				{
+					DNSName:    "klb.app.example.com",
+					RecordType: "A",
+					Targets:    []string{"172.32.200.1"},
+				},
+			},
+		},
+		{
+			name:            "additionalHost with single group",
+			rootHost:        "app.example.com",
+			additionalHosts: []string{"example"},
+			wantErr:         "invalid additionalHost",
+		},
+		{
+			name:            "additionalHost duplicating the rootHost",
+			rootHost:        "app.example.com",
+			additionalHosts: []string{"app.example.com"},
+			wantErr:         "already covered by the rootHost",
+		},
+		{
+			name:     "out of tree CNAME target is not checked",
+			rootHost: "app.example.com",
+			endpoints: []*externaldnsendpoint.Endpoint{
+				{
+					DNSName:    "app.example.com",
+					RecordType: "CNAME",
+					Targets:    []string{"lb.external.net"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &DNSRecord{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: DNSRecordSpec{
+					RootHost:        tt.rootHost,
+					AdditionalHosts: tt.additionalHosts,
+					ProviderRef:     ProviderRef{Name: "test"},
+					Endpoints:       tt.endpoints,
+				},
+			}
+			err := record.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("Validate() expected error containing %q, got nil", tt.wantErr)
+			} else if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDNSRecord_ValidateDependsOn(t *testing.T) {
+	record := &DNSRecord{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: DNSRecordSpec{
+			RootHost:    "app.example.com",
+			ProviderRef: ProviderRef{Name: "test"},
+			DependsOn:   []string{"app"},
+		},
+	}
+	if err := record.Validate(); err == nil || !strings.Contains(err.Error(), "depend on itself") {
+		t.Errorf("Validate() = %v, want a self dependency error", err)
+	}
+	record.Spec.DependsOn = []string{"cluster-a"}
+	if err := record.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestDNSRecord_ValidateTargetFormats(t *testing.T) {
+	record := func(endpoints ...*externaldnsendpoint.Endpoint) *DNSRecord {
+		return &DNSRecord{Spec: DNSRecordSpec{RootHost: "app.example.com", Endpoints: endpoints}}
+	}
+	tests := []struct {
+		name     string
+		endpoint *externaldnsendpoint.Endpoint
+		wantErr  string
+	}{
+		{
+			name:     "A endpoint with a hostname target",
+			endpoint: &externaldnsendpoint.Endpoint{DNSName: "app.example.com", RecordType: "A", Targets: []string{"lb.example.org"}},
+			wantErr:  "must be an IPv4 address",
+		},
+		{
+			name:     "A endpoint with an IPv6 target",
+			endpoint: &externaldnsendpoint.Endpoint{DNSName: "app.example.com", RecordType: "A", Targets: []string{"2001:db8::1"}},
+			wantErr:  "must be an IPv4 address",
+		},
+		{
+			name:     "AAAA endpoint with an IPv4 target",
+			endpoint: &externaldnsendpoint.Endpoint{DNSName: "app.example.com", RecordType: "AAAA", Targets: []string{"1.2.3.4"}},
+			wantErr:  "must be an IPv6 address",
+		},
+		{
+			name:     "CNAME endpoint with two targets",
+			endpoint: &externaldnsendpoint.Endpoint{DNSName: "app.example.com", RecordType: "CNAME", Targets: []string{"a.example.org", "b.example.org"}},
+			wantErr:  "exactly one target",
+		},
+		{
+			name:     "CNAME endpoint with an IP target",
+			endpoint: &externaldnsendpoint.Endpoint{DNSName: "app.example.com", RecordType: "CNAME", Targets: []string{"1.2.3.4"}},
+			wantErr:  "fully qualified domain name",
+		},
+		{
+			name:     "TXT endpoint over the length limit",
+			endpoint: &externaldnsendpoint.Endpoint{DNSName: "app.example.com", RecordType: "TXT", Targets: []string{strings.Repeat("x", 256)}},
+			wantErr:  "limited to 255 bytes",
+		},
+		{
+			name:     "endpoint without targets",
+			endpoint: &externaldnsendpoint.Endpoint{DNSName: "app.example.com", RecordType: "A"},
+			wantErr:  "at least one target",
+		},
+		{
+			name:     "valid TXT endpoint",
+			endpoint: &externaldnsendpoint.Endpoint{DNSName: "app.example.com", RecordType: "TXT", Targets: []string{"\"heritage=external-dns\""}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := record(tt.endpoint).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
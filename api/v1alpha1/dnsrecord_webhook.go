@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var dnsrecordlog = logf.Log.WithName("dnsrecord-webhook")
+
+// namespace quota enforced at admission, set from the operator
+// configuration via SetNamespaceQuota. The client is injected on webhook
+// setup.
+var (
+	webhookQuota  NamespaceQuota
+	webhookReader client.Reader
+)
+
+// SetNamespaceQuota configures the namespace quota the webhook enforces.
+func SetNamespaceQuota(quota NamespaceQuota) {
+	webhookQuota = quota
+}
+
+func (r *DNSRecord) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookReader = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// validateQuota rejects the record when admitting it would exceed the
+// namespace quota. The controller re-checks on reconcile, covering records
+// admitted while the webhook was unavailable.
+func (r *DNSRecord) validateQuota() error {
+	if webhookReader == nil {
+		return nil
+	}
+	return webhookQuota.Validate(context.Background(), webhookReader, r)
+}
+
+// validateOwnerID rejects the record when its explicit ownerID and rootHost
+// are already claimed by another record in the cluster. The controller
+// re-checks on reconcile, covering records admitted while the webhook was
+// unavailable.
+func (r *DNSRecord) validateOwnerID() error {
+	if webhookReader == nil {
+		return nil
+	}
+	return ValidateOwnerIDConflict(context.Background(), webhookReader, r)
+}
+
+//+kubebuilder:webhook:path=/mutate-kuadrant-io-v1alpha1-dnsrecord,mutating=true,failurePolicy=fail,sideEffects=None,groups=kuadrant.io,resources=dnsrecords,verbs=create;update,versions=v1alpha1,name=mdnsrecord.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &DNSRecord{}
+
+//+kubebuilder:webhook:path=/validate-kuadrant-io-v1alpha1-dnsrecord,mutating=false,failurePolicy=fail,sideEffects=None,groups=kuadrant.io,resources=dnsrecords,verbs=create;update,versions=v1alpha1,name=vdnsrecord.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &DNSRecord{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DNSRecord) ValidateCreate() (admission.Warnings, error) {
+	dnsrecordlog.V(1).Info("validate create", "name", r.Name)
+	if err := r.validateQuota(); err != nil {
+		return nil, err
+	}
+	if err := r.validateOwnerID(); err != nil {
+		return nil, err
+	}
+	return nil, r.Validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DNSRecord) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	dnsrecordlog.V(1).Info("validate update", "name", r.Name)
+	oldRecord, ok := old.(*DNSRecord)
+	if !ok {
+		return nil, fmt.Errorf("expected a DNSRecord but got a %T", old)
+	}
+	if oldRecord.Spec.RootHost != r.Spec.RootHost {
+		return nil, fmt.Errorf("rootHost is immutable")
+	}
+	if len(r.Spec.Endpoints) > len(oldRecord.Spec.Endpoints) {
+		if err := r.validateQuota(); err != nil {
+			return nil, err
+		}
+	}
+	// ownerID and rootHost are immutable, a conflict can only appear on
+	// update when leaving delegation re-activates an explicit ownerID
+	if oldRecord.Spec.Delegate && !r.Spec.Delegate {
+		if err := r.validateOwnerID(); err != nil {
+			return nil, err
+		}
+	}
+	return nil, r.Validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DNSRecord) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
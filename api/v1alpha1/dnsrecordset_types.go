@@ -0,0 +1,104 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSRecordSetSpec defines the desired state of DNSRecordSet
+type DNSRecordSetSpec struct {
+	// hosts generates one DNSRecord per listed host, with the host as the
+	// records rootHost and its endpoints resolving to targets.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
+	// gatewaySelector generates one DNSRecord per listener hostname of the
+	// Gateways in the namespace matching the selector, resolving listener
+	// hostnames to the gateway addresses. Both generation modes may be
+	// combined.
+	// +optional
+	GatewaySelector *metav1.LabelSelector `json:"gatewaySelector,omitempty"`
+
+	// providerRef is the provider secret every generated record publishes
+	// through.
+	ProviderRef ProviderRef `json:"providerRef"`
+
+	// targets are the addresses each host generated record resolves to, IP
+	// addresses or hostnames. Required when hosts is set; gateway generated
+	// records resolve to the gateway addresses instead.
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+
+	// ttl applied to the endpoints of every generated record, in seconds.
+	// Zero uses the generation defaults.
+	// +optional
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// DNSRecordSetStatus defines the observed state of DNSRecordSet
+type DNSRecordSetStatus struct {
+	// conditions for the record set.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// observedGeneration is the most recently reconciled generation of the
+	// set.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// records is the number of DNSRecords the set currently generates.
+	// +optional
+	Records int `json:"records,omitempty"`
+}
+
+const (
+	// ConditionReasonRecordsEnsured indicates all records of the set have
+	// been created or updated.
+	ConditionReasonRecordsEnsured string = "RecordsEnsured"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+//+kubebuilder:printcolumn:name="Records",type="integer",JSONPath=".status.records",description="Number of generated DNSRecords."
+//+kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".spec.providerRef.name",description="Referenced provider secret."
+
+// DNSRecordSet is the Schema for the dnsrecordsets API. It templates many
+// nearly identical DNSRecords, one per listed host or per matching Gateway
+// listener, so bulk consumers declare one resource instead of creating
+// thousands of records themselves.
+type DNSRecordSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSRecordSetSpec   `json:"spec,omitempty"`
+	Status DNSRecordSetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DNSRecordSetList contains a list of DNSRecordSet
+type DNSRecordSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSRecordSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSRecordSet{}, &DNSRecordSetList{})
+}
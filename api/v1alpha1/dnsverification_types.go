@@ -0,0 +1,96 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSVerificationSpec defines the desired state of DNSVerification
+type DNSVerificationSpec struct {
+	// dnsName is the fully qualified domain name whose resolution is
+	// verified.
+	// +kubebuilder:validation:MinLength=1
+	DNSName string `json:"dnsName"`
+
+	// recordType of the expected answer.
+	// +kubebuilder:validation:Enum=A;AAAA;CNAME;TXT;NS
+	RecordType string `json:"recordType"`
+
+	// targets is the expected answer set. Verification passes when live DNS
+	// answers with exactly these targets, in any order.
+	// +kubebuilder:validation:MinItems=1
+	Targets []string `json:"targets"`
+
+	// interval between verifications.
+	// +kubebuilder:default:="5m"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// resolver is the DNS server (host:port) queried. Defaults to the
+	// system resolver of the operator.
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+}
+
+// DNSVerificationStatus defines the observed state of DNSVerification
+type DNSVerificationStatus struct {
+	// conditions for the verification.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// lastVerificationTime is the time of the last verification attempt.
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+
+	// observedTargets is the answer set live DNS returned on the last
+	// verification, for diagnosing divergence.
+	// +optional
+	ObservedTargets []string `json:"observedTargets,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="DNSName",type="string",JSONPath=".spec.dnsName"
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.recordType"
+//+kubebuilder:printcolumn:name="Verified",type="string",JSONPath=".status.conditions[?(@.type==\"Verified\")].status"
+//+kubebuilder:printcolumn:name="Last Check",type="date",JSONPath=".status.lastVerificationTime"
+
+// DNSVerification is the Schema for the dnsverifications API. It declares
+// the resolution result expected for a dnsName and continuously verifies it
+// from the operators vantage point, surfacing divergence between live DNS
+// and the expectation as a condition.
+type DNSVerification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSVerificationSpec   `json:"spec,omitempty"`
+	Status DNSVerificationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DNSVerificationList contains a list of DNSVerification
+type DNSVerificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSVerification `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSVerification{}, &DNSVerificationList{})
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// PunycodeHost returns the A-label (punycode) form of an internationalized
+// host name, the form providers store and resolvers query. Plain ASCII hosts
+// are returned unchanged, so names with underscores or other labels the IDNA
+// lookup profile would reject are never touched. A leading wildcard label is
+// preserved. Hosts that fail conversion are returned unchanged, validation
+// reports those with a precise error instead.
+func PunycodeHost(host string) string {
+	ascii, err := punycodeHost(host)
+	if err != nil {
+		return host
+	}
+	return ascii
+}
+
+// UnicodeHost returns the display (U-label) form of a punycode host name,
+// the counterpart of PunycodeHost.
+func UnicodeHost(host string) string {
+	name, wildcard := strings.CutPrefix(host, WildcardPrefix)
+	unicode, err := idna.Lookup.ToUnicode(name)
+	if err != nil {
+		return host
+	}
+	if wildcard {
+		return WildcardPrefix + unicode
+	}
+	return unicode
+}
+
+func punycodeHost(host string) (string, error) {
+	if isASCII(host) {
+		return host, nil
+	}
+	name, wildcard := strings.CutPrefix(host, WildcardPrefix)
+	ascii, err := idna.Lookup.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalized host %q: %v", host, err)
+	}
+	if wildcard {
+		return WildcardPrefix + ascii, nil
+	}
+	return ascii, nil
+}
+
+// isASCII reports whether the string contains only ASCII bytes.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package v1alpha1
+
+import "testing"
+
+func TestPunycodeHost(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{
+			name: "ascii host is returned unchanged",
+			host: "app.example.com",
+			want: "app.example.com",
+		},
+		{
+			name: "ascii host with underscores is never touched",
+			host: "_acme-challenge.app.example.com",
+			want: "_acme-challenge.app.example.com",
+		},
+		{
+			name: "unicode host converts to its A-label form",
+			host: "café.example.com",
+			want: "xn--caf-dma.example.com",
+		},
+		{
+			name: "wildcard prefix is preserved",
+			host: "*.münchen.example.com",
+			want: "*.xn--mnchen-3ya.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PunycodeHost(tt.host); got != tt.want {
+				t.Errorf("PunycodeHost(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnicodeHost(t *testing.T) {
+	if got := UnicodeHost("*.xn--caf-dma.example.com"); got != "*.café.example.com" {
+		t.Errorf("UnicodeHost() = %q, want %q", got, "*.café.example.com")
+	}
+}
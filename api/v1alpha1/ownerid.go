@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateOwnerIDConflict rejects a record whose explicit ownerID and
+// rootHost are already claimed by another DNSRecord in the cluster. Two
+// records publishing the same rootHost under one owner id each treat the
+// others endpoints as their own stale state and silently revert them.
+// Delegated records intentionally share a derived owner id and are not
+// affected, only explicit spec owner ids are checked.
+func ValidateOwnerIDConflict(ctx context.Context, reader client.Reader, record *DNSRecord) error {
+	if record.Spec.OwnerID == "" || record.Spec.Delegate {
+		return nil
+	}
+	recordList := &DNSRecordList{}
+	if err := reader.List(ctx, recordList); err != nil {
+		return err
+	}
+	rootHost := record.GetRootHost()
+	for i := range recordList.Items {
+		other := &recordList.Items[i]
+		if other.Namespace == record.Namespace && other.Name == record.Name {
+			continue
+		}
+		if other.Spec.OwnerID != record.Spec.OwnerID || other.GetRootHost() != rootHost || other.Spec.Delegate {
+			continue
+		}
+		if claimedFirst(record, other) {
+			// the record admitted first keeps publishing, only the
+			// newcomer is held back
+			continue
+		}
+		return fmt.Errorf("ownerID %q for rootHost %q is already used by DNSRecord %s/%s; records sharing an owner id silently revert each others endpoints",
+			record.Spec.OwnerID, rootHost, other.Namespace, other.Name)
+	}
+	return nil
+}
+
+// claimedFirst returns true when record holds the earlier claim on the
+// contested owner id, by creation time with the namespace/name as a
+// deterministic tie break. A record still being admitted has no creation
+// time yet and never holds the earlier claim.
+func claimedFirst(record, other *DNSRecord) bool {
+	if record.CreationTimestamp.IsZero() {
+		return false
+	}
+	if !record.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return record.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+	return record.Namespace+"/"+record.Name < other.Namespace+"/"+other.Name
+}
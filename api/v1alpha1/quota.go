@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceQuota limits how much DNS a single namespace may publish, so one
+// tenant cannot exhaust provider zone record limits for everyone sharing
+// the zone. Zero limits are unenforced.
+type NamespaceQuota struct {
+	// MaxRecords is the maximum number of DNSRecords per namespace.
+	MaxRecords int
+	// MaxEndpoints is the maximum total number of endpoints across all
+	// DNSRecords of a namespace.
+	MaxEndpoints int
+}
+
+// Enabled returns true when any limit is set.
+func (q NamespaceQuota) Enabled() bool {
+	return q.MaxRecords > 0 || q.MaxEndpoints > 0
+}
+
+// Validate returns an error when admitting the given record would exceed
+// the quota of its namespace. The record itself is counted in place of any
+// stored generation of it.
+func (q NamespaceQuota) Validate(ctx context.Context, reader client.Reader, record *DNSRecord) error {
+	if !q.Enabled() {
+		return nil
+	}
+	recordList := &DNSRecordList{}
+	if err := reader.List(ctx, recordList, client.InNamespace(record.Namespace)); err != nil {
+		return fmt.Errorf("listing DNSRecords to enforce the namespace quota: %w", err)
+	}
+	records := 1
+	endpoints := len(record.Spec.Endpoints)
+	for i := range recordList.Items {
+		other := &recordList.Items[i]
+		if other.Name == record.Name {
+			continue
+		}
+		records++
+		endpoints += len(other.Spec.Endpoints)
+	}
+	if q.MaxRecords > 0 && records > q.MaxRecords {
+		return fmt.Errorf("namespace %q would have %d DNSRecords, exceeding its quota of %d", record.Namespace, records, q.MaxRecords)
+	}
+	if q.MaxEndpoints > 0 && endpoints > q.MaxEndpoints {
+		return fmt.Errorf("namespace %q would publish %d endpoints, exceeding its quota of %d", record.Namespace, endpoints, q.MaxEndpoints)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// ZoneBackupSpec defines the desired state of ZoneBackup
+type ZoneBackupSpec struct {
+	// rootDomain is the domain of the zone to snapshot. The provider zone
+	// with the longest suffix match for this domain is used.
+	// +kubebuilder:validation:MinLength=1
+	RootDomain string `json:"rootDomain"`
+
+	// providerRef is a reference to a provider secret used to read and
+	// restore zone records.
+	ProviderRef ProviderRef `json:"providerRef"`
+
+	// interval between snapshots of the zone.
+	// +kubebuilder:default:="1h"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// suspend stops periodic snapshots being taken without deleting any
+	// previously taken snapshot.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// ZoneBackupStatus defines the observed state of ZoneBackup
+type ZoneBackupStatus struct {
+	// conditions for the backup.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// lastBackupTime is the time the last snapshot was taken.
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
+	// lastRestoreTime is the time the snapshot was last restored into the zone.
+	// +optional
+	LastRestoreTime *metav1.Time `json:"lastRestoreTime,omitempty"`
+
+	// endpoints is the snapshot of all operator owned records in the zone,
+	// including their registry ownership labels.
+	// +optional
+	Endpoints []*externaldnsendpoint.Endpoint `json:"endpoints,omitempty"`
+
+	// recordCount is the number of records in the snapshot.
+	// +optional
+	RecordCount int `json:"recordCount,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="RootDomain",type="string",JSONPath=".spec.rootDomain"
+//+kubebuilder:printcolumn:name="Records",type="integer",JSONPath=".status.recordCount"
+//+kubebuilder:printcolumn:name="Last Backup",type="date",JSONPath=".status.lastBackupTime"
+
+// ZoneBackup is the Schema for the zonebackups API. It periodically
+// snapshots the operator owned records of a provider zone into its status so
+// they can be replayed after accidental deletion in the provider console.
+type ZoneBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ZoneBackupSpec   `json:"spec,omitempty"`
+	Status ZoneBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ZoneBackupList contains a list of ZoneBackup
+type ZoneBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZoneBackup `json:"items"`
+}
+
+// GetProviderRef returns the provider secret reference for the backup.
+func (b *ZoneBackup) GetProviderRef() ProviderRef {
+	return b.Spec.ProviderRef
+}
+
+func init() {
+	SchemeBuilder.Register(&ZoneBackup{}, &ZoneBackupList{})
+}
@@ -0,0 +1,1277 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApexRedirectSpec) DeepCopyInto(out *ApexRedirectSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApexRedirectSpec.
+func (in *ApexRedirectSpec) DeepCopy() *ApexRedirectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApexRedirectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedChange) DeepCopyInto(out *AppliedChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]*endpoint.Endpoint, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(endpoint.Endpoint)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Creates != nil {
+		in, out := &in.Creates, &out.Creates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Updates != nil {
+		in, out := &in.Updates, &out.Updates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deletes != nil {
+		in, out := &in.Deletes, &out.Deletes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedChange.
+func (in *AppliedChange) DeepCopy() *AppliedChange {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapEndpointsRef) DeepCopyInto(out *ConfigMapEndpointsRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapEndpointsRef.
+func (in *ConfigMapEndpointsRef) DeepCopy() *ConfigMapEndpointsRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapEndpointsRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSDomainClaim) DeepCopyInto(out *DNSDomainClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSDomainClaim.
+func (in *DNSDomainClaim) DeepCopy() *DNSDomainClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSDomainClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSDomainClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSDomainClaimList) DeepCopyInto(out *DNSDomainClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSDomainClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSDomainClaimList.
+func (in *DNSDomainClaimList) DeepCopy() *DNSDomainClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSDomainClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSDomainClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSDomainClaimSpec) DeepCopyInto(out *DNSDomainClaimSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSDomainClaimSpec.
+func (in *DNSDomainClaimSpec) DeepCopy() *DNSDomainClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSDomainClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOperatorConfig) DeepCopyInto(out *DNSOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOperatorConfig.
+func (in *DNSOperatorConfig) DeepCopy() *DNSOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOperatorConfigList) DeepCopyInto(out *DNSOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOperatorConfigList.
+func (in *DNSOperatorConfigList) DeepCopy() *DNSOperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOperatorConfigSpec) DeepCopyInto(out *DNSOperatorConfigSpec) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProviderAPITimeout != nil {
+		in, out := &in.ProviderAPITimeout, &out.ProviderAPITimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProviderAPIRetries != nil {
+		in, out := &in.ProviderAPIRetries, &out.ProviderAPIRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxDeletesPerApply != nil {
+		in, out := &in.MaxDeletesPerApply, &out.MaxDeletesPerApply
+		*out = new(int)
+		**out = **in
+	}
+	if in.ZoneRecordLimit != nil {
+		in, out := &in.ZoneRecordLimit, &out.ZoneRecordLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.StalledThreshold != nil {
+		in, out := &in.StalledThreshold, &out.StalledThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ApplyDebounce != nil {
+		in, out := &in.ApplyDebounce, &out.ApplyDebounce
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FlapDampingWindow != nil {
+		in, out := &in.FlapDampingWindow, &out.FlapDampingWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StableOwnerIDs != nil {
+		in, out := &in.StableOwnerIDs, &out.StableOwnerIDs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxRecordsPerNamespace != nil {
+		in, out := &in.MaxRecordsPerNamespace, &out.MaxRecordsPerNamespace
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxEndpointsPerNamespace != nil {
+		in, out := &in.MaxEndpointsPerNamespace, &out.MaxEndpointsPerNamespace
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOperatorConfigSpec.
+func (in *DNSOperatorConfigSpec) DeepCopy() *DNSOperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOperatorConfigStatus) DeepCopyInto(out *DNSOperatorConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOperatorConfigStatus.
+func (in *DNSOperatorConfigStatus) DeepCopy() *DNSOperatorConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOperatorConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnership) DeepCopyInto(out *DNSOwnership) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnership.
+func (in *DNSOwnership) DeepCopy() *DNSOwnership {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnership)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSOwnership) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnershipList) DeepCopyInto(out *DNSOwnershipList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSOwnership, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnershipList.
+func (in *DNSOwnershipList) DeepCopy() *DNSOwnershipList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnershipList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSOwnershipList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnershipSpec) DeepCopyInto(out *DNSOwnershipSpec) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make(map[string]RegistryLabels, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(RegistryLabels, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnershipSpec.
+func (in *DNSOwnershipSpec) DeepCopy() *DNSOwnershipSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnershipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderZones) DeepCopyInto(out *DNSProviderZones) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderZones.
+func (in *DNSProviderZones) DeepCopy() *DNSProviderZones {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderZones)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSProviderZones) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderZonesList) DeepCopyInto(out *DNSProviderZonesList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSProviderZones, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderZonesList.
+func (in *DNSProviderZonesList) DeepCopy() *DNSProviderZonesList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderZonesList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSProviderZonesList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderZonesSpec) DeepCopyInto(out *DNSProviderZonesSpec) {
+	*out = *in
+	out.ProviderRef = in.ProviderRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderZonesSpec.
+func (in *DNSProviderZonesSpec) DeepCopy() *DNSProviderZonesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderZonesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderZonesStatus) DeepCopyInto(out *DNSProviderZonesStatus) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ProviderZone, len(*in))
+		copy(*out, *in)
+	}
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderZonesStatus.
+func (in *DNSProviderZonesStatus) DeepCopy() *DNSProviderZonesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderZonesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecord) DeepCopyInto(out *DNSRecord) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecord.
+func (in *DNSRecord) DeepCopy() *DNSRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSRecord) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordList) DeepCopyInto(out *DNSRecordList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordList.
+func (in *DNSRecordList) DeepCopy() *DNSRecordList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSRecordList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordSet) DeepCopyInto(out *DNSRecordSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordSet.
+func (in *DNSRecordSet) DeepCopy() *DNSRecordSet {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSRecordSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordSetList) DeepCopyInto(out *DNSRecordSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSRecordSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordSetList.
+func (in *DNSRecordSetList) DeepCopy() *DNSRecordSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSRecordSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordSetSpec) DeepCopyInto(out *DNSRecordSetSpec) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GatewaySelector != nil {
+		in, out := &in.GatewaySelector, &out.GatewaySelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ProviderRef = in.ProviderRef
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordSetSpec.
+func (in *DNSRecordSetSpec) DeepCopy() *DNSRecordSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordSetStatus) DeepCopyInto(out *DNSRecordSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordSetStatus.
+func (in *DNSRecordSetStatus) DeepCopy() *DNSRecordSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordSpec) DeepCopyInto(out *DNSRecordSpec) {
+	*out = *in
+	out.ProviderRef = in.ProviderRef
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]*endpoint.Endpoint, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(endpoint.Endpoint)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.EndpointsFrom != nil {
+		in, out := &in.EndpointsFrom, &out.EndpointsFrom
+		*out = new(EndpointsFromSource)
+		**out = **in
+	}
+	if in.TargetsFrom != nil {
+		in, out := &in.TargetsFrom, &out.TargetsFrom
+		*out = new(TargetsFromSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApexRedirect != nil {
+		in, out := &in.ApexRedirect, &out.ApexRedirect
+		*out = new(ApexRedirectSpec)
+		**out = **in
+	}
+	if in.AdditionalHosts != nil {
+		in, out := &in.AdditionalHosts, &out.AdditionalHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheckSpec)
+		**out = **in
+	}
+	if in.SyntheticCheck != nil {
+		in, out := &in.SyntheticCheck, &out.SyntheticCheck
+		*out = new(SyntheticCheckSpec)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordSpec.
+func (in *DNSRecordSpec) DeepCopy() *DNSRecordSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordStatus) DeepCopyInto(out *DNSRecordStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.QueuedAt.DeepCopyInto(&out.QueuedAt)
+	in.NextCheckAt.DeepCopyInto(&out.NextCheckAt)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]*endpoint.Endpoint, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(endpoint.Endpoint)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.DomainOwners != nil {
+		in, out := &in.DomainOwners, &out.DomainOwners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InFlightChange != nil {
+		in, out := &in.InFlightChange, &out.InFlightChange
+		*out = new(InFlightChange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppliedChanges != nil {
+		in, out := &in.AppliedChanges, &out.AppliedChanges
+		*out = make([]AppliedChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastFailedApply != nil {
+		in, out := &in.LastFailedApply, &out.LastFailedApply
+		*out = new(FailedApplySummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DSRecords != nil {
+		in, out := &in.DSRecords, &out.DSRecords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordStatus.
+func (in *DNSRecordStatus) DeepCopy() *DNSRecordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSVerification) DeepCopyInto(out *DNSVerification) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSVerification.
+func (in *DNSVerification) DeepCopy() *DNSVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSVerification) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSVerificationList) DeepCopyInto(out *DNSVerificationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSVerification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSVerificationList.
+func (in *DNSVerificationList) DeepCopy() *DNSVerificationList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSVerificationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSVerificationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSVerificationSpec) DeepCopyInto(out *DNSVerificationSpec) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSVerificationSpec.
+func (in *DNSVerificationSpec) DeepCopy() *DNSVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSVerificationStatus) DeepCopyInto(out *DNSVerificationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ObservedTargets != nil {
+		in, out := &in.ObservedTargets, &out.ObservedTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSVerificationStatus.
+func (in *DNSVerificationStatus) DeepCopy() *DNSVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointsFromSource) DeepCopyInto(out *EndpointsFromSource) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointsFromSource.
+func (in *EndpointsFromSource) DeepCopy() *EndpointsFromSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointsFromSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedApplySummary) DeepCopyInto(out *FailedApplySummary) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Creates != nil {
+		in, out := &in.Creates, &out.Creates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Updates != nil {
+		in, out := &in.Updates, &out.Updates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deletes != nil {
+		in, out := &in.Deletes, &out.Deletes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedApplySummary.
+func (in *FailedApplySummary) DeepCopy() *FailedApplySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedApplySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSpec.
+func (in *HealthCheckSpec) DeepCopy() *HealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InFlightChange) DeepCopyInto(out *InFlightChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InFlightChange.
+func (in *InFlightChange) DeepCopy() *InFlightChange {
+	if in == nil {
+		return nil
+	}
+	out := new(InFlightChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceQuota) DeepCopyInto(out *NamespaceQuota) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceQuota.
+func (in *NamespaceQuota) DeepCopy() *NamespaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTargetSource) DeepCopyInto(out *NodeTargetSource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTargetSource.
+func (in *NodeTargetSource) DeepCopy() *NodeTargetSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTargetSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderRef) DeepCopyInto(out *ProviderRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderRef.
+func (in *ProviderRef) DeepCopy() *ProviderRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderZone) DeepCopyInto(out *ProviderZone) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderZone.
+func (in *ProviderZone) DeepCopy() *ProviderZone {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in RegistryLabels) DeepCopyInto(out *RegistryLabels) {
+	{
+		in := &in
+		*out = make(RegistryLabels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryLabels.
+func (in RegistryLabels) DeepCopy() RegistryLabels {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryLabels)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceTargetRef) DeepCopyInto(out *ServiceTargetRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTargetRef.
+func (in *ServiceTargetRef) DeepCopy() *ServiceTargetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceTargetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyntheticCheckSpec) DeepCopyInto(out *SyntheticCheckSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyntheticCheckSpec.
+func (in *SyntheticCheckSpec) DeepCopy() *SyntheticCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SyntheticCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetsFromSource) DeepCopyInto(out *TargetsFromSource) {
+	*out = *in
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(ServiceTargetRef)
+		**out = **in
+	}
+	if in.EndpointSliceSelector != nil {
+		in, out := &in.EndpointSliceSelector, &out.EndpointSliceSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(NodeTargetSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetsFromSource.
+func (in *TargetsFromSource) DeepCopy() *TargetsFromSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetsFromSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneBackup) DeepCopyInto(out *ZoneBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneBackup.
+func (in *ZoneBackup) DeepCopy() *ZoneBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneBackupList) DeepCopyInto(out *ZoneBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZoneBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneBackupList.
+func (in *ZoneBackupList) DeepCopy() *ZoneBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneBackupSpec) DeepCopyInto(out *ZoneBackupSpec) {
+	*out = *in
+	out.ProviderRef = in.ProviderRef
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneBackupSpec.
+func (in *ZoneBackupSpec) DeepCopy() *ZoneBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneBackupStatus) DeepCopyInto(out *ZoneBackupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRestoreTime != nil {
+		in, out := &in.LastRestoreTime, &out.LastRestoreTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]*endpoint.Endpoint, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(endpoint.Endpoint)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneBackupStatus.
+func (in *ZoneBackupStatus) DeepCopy() *ZoneBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
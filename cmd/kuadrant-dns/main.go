@@ -0,0 +1,218 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kuadrant-dns is a small CLI (usable as a kubectl plugin) for inspecting
+// and poking DNSRecord resources managed by the operator.
+//
+//	kuadrant-dns records -n my-ns my-record    show the effective applied records
+//	kuadrant-dns tree -n my-ns my-record       render the endpoint tree
+//	kuadrant-dns query -n my-ns my-record      query authoritative nameservers for the rootHost
+//	kuadrant-dns reconcile -n my-ns my-record  trigger a forced reconcile
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/endpoints"
+)
+
+// ForceReconcileAnnotation is set by the reconcile subcommand to trigger a
+// reconcile of a record without changing its spec.
+const ForceReconcileAnnotation = "kuadrant.io/force-reconcile-at"
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	cmd := os.Args[1]
+	flags := flag.NewFlagSet(cmd, flag.ExitOnError)
+	namespace := flags.String("n", "default", "namespace of the DNSRecord")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		os.Exit(1)
+	}
+	if flags.NArg() != 1 {
+		usage()
+		os.Exit(1)
+	}
+	name := flags.Arg(0)
+
+	ctx := context.Background()
+	record, c, err := getRecord(ctx, *namespace, name)
+	if err != nil {
+		fatal(err)
+	}
+
+	switch cmd {
+	case "records":
+		err = showRecords(record)
+	case "tree":
+		err = showTree(record)
+	case "query":
+		err = queryAuthoritative(ctx, record)
+	case "reconcile":
+		err = forceReconcile(ctx, c, record)
+	default:
+		usage()
+		os.Exit(1)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: kuadrant-dns <records|tree|query|reconcile> [-n namespace] <name>\n")
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	os.Exit(1)
+}
+
+func getRecord(ctx context.Context, namespace, name string) (*v1alpha1.DNSRecord, client.Client, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	scheme, err := v1alpha1.SchemeBuilder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, nil, err
+	}
+	record := &v1alpha1.DNSRecord{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, record); err != nil {
+		return nil, nil, err
+	}
+	return record, c, nil
+}
+
+// showRecords prints the endpoints last successfully applied to the provider
+// zone from the records status.
+func showRecords(record *v1alpha1.DNSRecord) error {
+	fmt.Printf("rootHost: %s\n", record.Spec.RootHost)
+	fmt.Printf("zone: %s (%s)\n", record.Status.ZoneDomainName, record.Status.ZoneID)
+	fmt.Printf("ownerID: %s\n", record.Status.OwnerID)
+	if len(record.Status.Endpoints) == 0 {
+		fmt.Println("no endpoints have been applied")
+		return nil
+	}
+	for _, ep := range record.Status.Endpoints {
+		fmt.Printf("%s %s %d %v\n", ep.DNSName, ep.RecordType, ep.RecordTTL, ep.Targets)
+	}
+	return nil
+}
+
+// showTree renders the spec endpoints as a tree rooted at the rootHost.
+func showTree(record *v1alpha1.DNSRecord) error {
+	endpoints.RenderTree(os.Stdout, endpoints.TreeFor(record.Spec.RootHost, record.Spec.Endpoints))
+	return nil
+}
+
+// queryAuthoritative discovers the authoritative nameservers for the records
+// zone and queries each of them directly for the rootHost, bypassing any
+// local caching resolvers.
+func queryAuthoritative(ctx context.Context, record *v1alpha1.DNSRecord) error {
+	rootHost := record.GetRootHost()
+	zone := record.Status.ZoneDomainName
+	if zone == "" {
+		zone = rootHost
+	}
+
+	nameservers, err := net.DefaultResolver.LookupNS(ctx, zone)
+	for err != nil {
+		// walk up the domain until we find the delegated zone
+		zone = domainParent(zone)
+		if zone == "" {
+			return fmt.Errorf("unable to find authoritative nameservers for %s: %w", rootHost, err)
+		}
+		nameservers, err = net.DefaultResolver.LookupNS(ctx, zone)
+	}
+
+	fmt.Printf("authoritative nameservers for %s:\n", zone)
+	for _, ns := range nameservers {
+		fmt.Printf("  %s\n", ns.Host)
+	}
+
+	for _, ns := range nameservers {
+		resolver := resolverFor(ns.Host)
+		fmt.Printf("%s:\n", ns.Host)
+		cname, err := resolver.LookupCNAME(ctx, rootHost)
+		if err == nil && cname != "" && cname != rootHost+"." {
+			fmt.Printf("  CNAME %s\n", cname)
+		}
+		addrs, err := resolver.LookupHost(ctx, rootHost)
+		if err != nil {
+			fmt.Printf("  no records: %v\n", err)
+			continue
+		}
+		for _, addr := range addrs {
+			fmt.Printf("  %s\n", addr)
+		}
+	}
+	return nil
+}
+
+// resolverFor returns a resolver that sends all queries to the given nameserver.
+func resolverFor(host string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: 10 * time.Second}
+			return d.DialContext(ctx, network, net.JoinHostPort(host, "53"))
+		},
+	}
+}
+
+func domainParent(domain string) string {
+	for i := 0; i < len(domain); i++ {
+		if domain[i] == '.' {
+			return domain[i+1:]
+		}
+	}
+	return ""
+}
+
+// forceReconcile triggers a reconcile of the record by updating a timestamp
+// annotation on it.
+func forceReconcile(ctx context.Context, c client.Client, record *v1alpha1.DNSRecord) error {
+	if record.Annotations == nil {
+		record.Annotations = map[string]string{}
+	}
+	record.Annotations[ForceReconcileAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := c.Update(ctx, record); err != nil {
+		return err
+	}
+	fmt.Printf("triggered reconcile of %s/%s\n", record.Namespace, record.Name)
+	return nil
+}
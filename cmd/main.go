@@ -0,0 +1,550 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	// to ensure that exec-entrypoint and run can make use of them.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/controller"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/notify"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/preflight"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/probe"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/propagation"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+	_ "github.com/mikenairn/kuadrant-dns-operator/internal/provider/aws"
+	_ "github.com/mikenairn/kuadrant-dns-operator/internal/provider/google"
+	_ "github.com/mikenairn/kuadrant-dns-operator/internal/provider/inmemory"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/identity"
+	//+kubebuilder:scaffold:imports
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(gatewayapiv1.AddToScheme(scheme))
+	//+kubebuilder:scaffold:scheme
+}
+
+func main() {
+	var metricsAddr string
+	var enableLeaderElection bool
+	var probeAddr string
+	var providers stringSliceFlags
+	var gcInterval time.Duration
+	var gcGracePeriod time.Duration
+	var heartbeatInterval time.Duration
+	var heartbeatThreshold time.Duration
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	var leaseDuration time.Duration
+	var renewDeadline time.Duration
+	var retryPeriod time.Duration
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"How long a leader election lease is valid before non-leaders may take it over. Longer values survive apiserver blips, shorter values fail over faster.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"How long the leader keeps retrying to renew its lease before giving up leadership.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"How often leader election clients retry acquiring and renewing the lease.")
+	var gracefulShutdownTimeout time.Duration
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 30*time.Second,
+		"How long a terminating instance waits for in-flight provider applies to drain before exiting. "+
+			"Applies interrupted anyway are detected through the persisted in-flight change tokens on restart.")
+	flag.Var(&providers, "provider", "DNS providers to enable. Can be passed multiple times e.g. --provider aws --provider inmemory, "+
+		"or as a comma separated list e.g. --provider aws,inmemory. (default all registered providers)")
+	flag.DurationVar(&gcInterval, "zone-gc-interval", 0,
+		"How often to scan provider zones for orphaned records. Set to 0 to disable the scan. "+
+			"When other clusters publish into the same zones, every cluster must run with --heartbeat-interval "+
+			"so the gc can tell dead clusters from live ones; owners without a heartbeat are never pruned "+
+			"unless --zone-gc-heartbeat-threshold is set to 0.")
+	flag.DurationVar(&gcGracePeriod, "zone-gc-grace-period", 24*time.Hour,
+		"How long a record must remain orphaned before the zone gc deletes it. "+
+			"Only applies with --zone-gc-heartbeat-threshold set to 0; otherwise pruning is heartbeat based.")
+	flag.DurationVar(&heartbeatInterval, "heartbeat-interval", 0,
+		"How often to refresh heartbeat records for this clusters owners. Set to 0 to disable heartbeats.")
+	flag.DurationVar(&heartbeatThreshold, "zone-gc-heartbeat-threshold", 30*time.Minute,
+		"How stale an owners heartbeat may be before the zone gc considers the owner dead and prunes its records.")
+	var clusterID string
+	var clusterGeo string
+	flag.StringVar(&clusterID, "cluster-id", "",
+		"Identifier of the cluster this instance runs in, used when generating load balanced endpoints. "+
+			"If unset an id is derived from the kube-system namespace UID.")
+	flag.StringVar(&clusterGeo, "cluster-geo", "",
+		"Default geo code applied to endpoints generated for this cluster e.g. GEO-EU.")
+	var stableOwnerIDs bool
+	flag.BoolVar(&stableOwnerIDs, "stable-owner-ids", false,
+		"Derive default owner ids from the cluster id, namespace and name instead of the record UID, so recreated "+
+			"records re-adopt their provider entries. Enabling this on existing records changes their owner id; the "+
+			v1alpha1.TakeOverAnnotation+" annotation recovers entries registered to the old one.")
+	var enableGatewayController bool
+	flag.BoolVar(&enableGatewayController, "enable-gateway-controller", false,
+		"Enable the gateway controller, creating DNSRecords for Gateway listener hostnames annotated with "+v1alpha1.DNSProviderAnnotation+".")
+	var repairOnStart bool
+	var repairInterval time.Duration
+	flag.BoolVar(&repairOnStart, "repair-scan-on-start", false,
+		"Run a repair scan when this instance becomes leader, re-applying all records to correct out-of-band zone edits.")
+	flag.DurationVar(&repairInterval, "repair-scan-interval", 0,
+		"How often to run periodic repair scans. Set to 0 to disable them.")
+	var maxDeletesPerApply int
+	flag.IntVar(&maxDeletesPerApply, "max-deletes-per-apply", 0,
+		"Abort any apply that would delete more than this many owned records at once, unless confirmed on the record. Set to 0 to disable the protection.")
+	var maxDeleteAttempts int
+	flag.IntVar(&maxDeleteAttempts, "max-delete-attempts", controller.DefaultMaxDeleteAttempts,
+		"How many times provider cleanup of a record being deleted is retried before the deletion is marked blocked.")
+	var namespaceDomainEntries stringSliceFlags
+	flag.Var(&namespaceDomainEntries, "namespace-domain",
+		"Restrict a namespace to a domain, e.g. --namespace-domain team-a=*.team-a.example.com. "+
+			"Can be passed multiple times; namespaces without entries are unrestricted.")
+	var shardIndex int
+	var shardCount int
+	flag.IntVar(&shardIndex, "shard-index", 0,
+		"Index of this replica when sharding DNSRecords over multiple replicas, in [0, shard-count).")
+	flag.IntVar(&shardCount, "shard-count", 0,
+		"Number of replicas sharing DNSRecord reconciliation. Set to 0 or 1 to disable sharding.")
+	var tagLabels stringSliceFlags
+	flag.Var(&tagLabels, "provider-tag-label",
+		"DNSRecord label or annotation key propagated as a tag onto provider resources, on providers that support tagging. "+
+			"Can be passed multiple times.")
+	var applyDebounce time.Duration
+	flag.DurationVar(&applyDebounce, "apply-debounce", 0,
+		"Quiet period a changed DNSRecord spec must hold before it is applied, so rapid churn writes only the settled state. Zero applies immediately.")
+	var flapDamping time.Duration
+	flag.DurationVar(&flapDamping, "flap-damping-window", 0,
+		"Keep a vanished target address published for this long, so addresses flapping within the window are never withdrawn. Zero disables damping.")
+	var notificationWebhookURL string
+	flag.StringVar(&notificationWebhookURL, "notification-webhook-url", "",
+		"URL notified with a JSON event whenever a DNSRecord Ready condition transitions. Empty disables notifications.")
+	var notificationFormat string
+	flag.StringVar(&notificationFormat, "notification-format", "json",
+		"Format of notification events, one of \"json\" or \"cloudevents\". With \"cloudevents\" all state transitions "+
+			"(created, applied, conflict, deleted, health) are published in the CloudEvents 1.0 structured mode.")
+	var maintenanceWindowEntries stringSliceFlags
+	flag.Var(&maintenanceWindowEntries, "maintenance-window",
+		"Recurring change-freeze window, \"HH:MM-HH:MM\" (daily) or \"Mon HH:MM-HH:MM\" (weekly) in UTC, during which no provider mutations are performed. "+
+			"Can be passed multiple times.")
+	var propagationInterval time.Duration
+	flag.DurationVar(&propagationInterval, "propagation-check-interval", 0,
+		"How often applied changes are re-queried until they are observable on the zone's authoritative servers and the "+
+			"propagation resolvers, exporting per provider propagation latency metrics. Zero disables the checks.")
+	var propagationResolvers stringSliceFlags
+	flag.Var(&propagationResolvers, "propagation-resolver",
+		"Public resolver (host:port) propagation is additionally measured against. Can be passed multiple times, "+
+			"defaults to 8.8.8.8:53 and 1.1.1.1:53.")
+	var registryPollInterval time.Duration
+	flag.DurationVar(&registryPollInterval, "registry-poll-interval", 0,
+		"How often the registry TXT state of provider zones is polled for out-of-band changes, triggering prompt "+
+			"reconciles of affected records, e.g. when a remote owner resolves a conflict. Zero disables the poll.")
+	var namespaceQuota v1alpha1.NamespaceQuota
+	flag.IntVar(&namespaceQuota.MaxRecords, "max-records-per-namespace", 0,
+		"Maximum number of DNSRecords a namespace may hold. Set to 0 to disable the quota.")
+	flag.IntVar(&namespaceQuota.MaxEndpoints, "max-endpoints-per-namespace", 0,
+		"Maximum total number of endpoints across the DNSRecords of a namespace. Set to 0 to disable the quota.")
+	var operatorConfigName string
+	flag.StringVar(&operatorConfigName, "operator-config", "",
+		"Name of a cluster scoped DNSOperatorConfig resource read at startup. Fields set in the resource override flag "+
+			"defaults, flags passed explicitly always win. Empty disables the configuration resource.")
+	var providerRetryDefaults provider.RetryDefaults
+	flag.DurationVar(&providerRetryDefaults.Timeout, "provider-api-timeout", 0,
+		"Bound each provider API call by this timeout. Zero leaves the provider client defaults in effect. "+
+			"Overridable per provider secret with the "+provider.APITimeoutKey+" data key.")
+	flag.IntVar(&providerRetryDefaults.Retries, "provider-api-retries", 0,
+		"Number of times a failed provider API call is retried with exponential backoff. "+
+			"Overridable per provider secret with the "+provider.APIRetriesKey+" data key.")
+	var stalledThreshold time.Duration
+	flag.DurationVar(&stalledThreshold, "stalled-threshold", controller.DefaultStalledThreshold,
+		"How long a DNSRecord may stay not Ready with the same error before the Stalled condition is set.")
+	var preflightChecks bool
+	var preflightACMEIssuers stringSliceFlags
+	flag.BoolVar(&preflightChecks, "preflight-checks", false,
+		"Check the public CAA/DNSSEC configuration around a records rootHost before publishing and surface findings as a condition.")
+	flag.Var(&preflightACMEIssuers, "preflight-acme-issuer",
+		"Issuer domain certificates are expected from, e.g. letsencrypt.org. Can be passed multiple times. "+
+			"With --preflight-checks, CAA records covering a rootHost must permit at least one of them.")
+	var providerHealthThreshold time.Duration
+	flag.DurationVar(&providerHealthThreshold, "provider-health-threshold", controller.DefaultProviderHealthThreshold,
+		"How recent a successful provider interaction must be for readiness to consider the provider reachable.")
+	var zoneRecordLimit int
+	flag.IntVar(&zoneRecordLimit, "zone-record-limit", controller.DefaultZoneRecordLimit,
+		"Per zone record limit enforced by the provider, used to surface the ZoneCapacity condition.")
+	var delegationRole string
+	flag.StringVar(&delegationRole, "delegation-role", controller.DelegationRolePrimary,
+		"Role of this instance for delegating DNSRecords, one of \"primary\" (merge and publish them) or \"secondary\" (never write them).")
+	registryConfig := provider.DefaultRegistryConfig()
+	flag.StringVar(&registryConfig.Backend, "registry-backend", registryConfig.Backend,
+		"Registry backend used to track record ownership, one of \"txt\" or \"crd\". Can be overridden per provider secret.")
+	flag.StringVar(&registryConfig.TXTPrefix, "txt-prefix", registryConfig.TXTPrefix,
+		"Prefix applied to registry TXT record names. Can be overridden per provider secret.")
+	flag.StringVar(&registryConfig.TXTSuffix, "txt-suffix", registryConfig.TXTSuffix,
+		"Suffix applied to registry TXT record names. Can be overridden per provider secret.")
+	flag.StringVar(&registryConfig.TXTWildcardReplacement, "txt-wildcard-replacement", registryConfig.TXTWildcardReplacement,
+		"Replacement for wildcard labels in registry TXT record names. Can be overridden per provider secret.")
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	explicitFlags := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { explicitFlags[f.Name] = true })
+
+	var operatorConfigSpec v1alpha1.DNSOperatorConfigSpec
+	if operatorConfigName != "" {
+		configClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create client for the operator configuration")
+			os.Exit(1)
+		}
+		operatorConfig := &v1alpha1.DNSOperatorConfig{}
+		if err := configClient.Get(context.Background(), types.NamespacedName{Name: operatorConfigName}, operatorConfig); err != nil {
+			setupLog.Error(err, "unable to read the operator configuration", "name", operatorConfigName)
+			os.Exit(1)
+		}
+		operatorConfigSpec = operatorConfig.Spec
+
+		if len(operatorConfigSpec.Providers) > 0 && !explicitFlags["provider"] {
+			providers = operatorConfigSpec.Providers
+		}
+		if operatorConfigSpec.ProviderAPITimeout != nil && !explicitFlags["provider-api-timeout"] {
+			providerRetryDefaults.Timeout = operatorConfigSpec.ProviderAPITimeout.Duration
+		}
+		if operatorConfigSpec.ProviderAPIRetries != nil && !explicitFlags["provider-api-retries"] {
+			providerRetryDefaults.Retries = *operatorConfigSpec.ProviderAPIRetries
+		}
+		if operatorConfigSpec.MaxDeletesPerApply != nil && !explicitFlags["max-deletes-per-apply"] {
+			maxDeletesPerApply = *operatorConfigSpec.MaxDeletesPerApply
+		}
+		if operatorConfigSpec.ZoneRecordLimit != nil && !explicitFlags["zone-record-limit"] {
+			zoneRecordLimit = *operatorConfigSpec.ZoneRecordLimit
+		}
+		if operatorConfigSpec.StalledThreshold != nil && !explicitFlags["stalled-threshold"] {
+			stalledThreshold = operatorConfigSpec.StalledThreshold.Duration
+		}
+		if operatorConfigSpec.ApplyDebounce != nil && !explicitFlags["apply-debounce"] {
+			applyDebounce = operatorConfigSpec.ApplyDebounce.Duration
+		}
+		if operatorConfigSpec.FlapDampingWindow != nil && !explicitFlags["flap-damping-window"] {
+			flapDamping = operatorConfigSpec.FlapDampingWindow.Duration
+		}
+		if operatorConfigSpec.StableOwnerIDs != nil && !explicitFlags["stable-owner-ids"] {
+			stableOwnerIDs = *operatorConfigSpec.StableOwnerIDs
+		}
+		if operatorConfigSpec.MaxRecordsPerNamespace != nil && !explicitFlags["max-records-per-namespace"] {
+			namespaceQuota.MaxRecords = *operatorConfigSpec.MaxRecordsPerNamespace
+		}
+		if operatorConfigSpec.MaxEndpointsPerNamespace != nil && !explicitFlags["max-endpoints-per-namespace"] {
+			namespaceQuota.MaxEndpoints = *operatorConfigSpec.MaxEndpointsPerNamespace
+		}
+		setupLog.Info("loaded operator configuration", "name", operatorConfigName)
+	}
+
+	if len(providers) == 0 {
+		providers = stringSliceFlags(provider.RegisteredProviders())
+	}
+	setupLog.Info("enabled providers", "providers", providers)
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
+		WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443}),
+		HealthProbeBindAddress: probeAddr,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       "a41725c8.kuadrant.io",
+		// keep leadership through the drain so another instance does not
+		// start writing while our in-flight applies finish
+		LeaderElectionReleaseOnCancel: false,
+		LeaseDuration:                 &leaseDuration,
+		RenewDeadline:                 &renewDeadline,
+		RetryPeriod:                   &retryPeriod,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	directClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		os.Exit(1)
+	}
+	clusterIdentity, err := identity.GetClusterIdentity(context.Background(), directClient, clusterID, clusterGeo)
+	if err != nil {
+		setupLog.Error(err, "unable to determine cluster identity")
+		os.Exit(1)
+	}
+	setupLog.Info("cluster identity", "clusterID", clusterIdentity.ClusterID, "geo", clusterIdentity.Geo)
+
+	namespaceDomains, err := controller.ParseNamespaceDomains(namespaceDomainEntries)
+	if err != nil {
+		setupLog.Error(err, "unable to parse namespace domains")
+		os.Exit(1)
+	}
+
+	providerFactory, err := provider.NewFactory(mgr.GetClient(), providers, providerRetryDefaults)
+	if err != nil {
+		setupLog.Error(err, "unable to create provider factory")
+		os.Exit(1)
+	}
+
+	var preflightChecker *preflight.Checker
+	if preflightChecks {
+		preflightChecker, err = preflight.NewChecker(preflightACMEIssuers)
+		if err != nil {
+			setupLog.Error(err, "unable to set up pre-flight checks")
+			os.Exit(1)
+		}
+	}
+
+	maintenanceWindows, err := controller.ParseMaintenanceWindows(maintenanceWindowEntries)
+	if err != nil {
+		setupLog.Error(err, "unable to parse maintenance windows")
+		os.Exit(1)
+	}
+
+	providerHealth := controller.NewProviderHealth(providerHealthThreshold)
+
+	dnsRecordReconciler := &controller.DNSRecordReconciler{
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		ProviderFactory:    providerFactory,
+		RegistryConfig:     registryConfig,
+		DelegationRole:     delegationRole,
+		ZoneRecordLimit:    zoneRecordLimit,
+		MaxDeletesPerApply: maxDeletesPerApply,
+		MaxDeleteAttempts:  maxDeleteAttempts,
+		NamespaceDomains:   namespaceDomains,
+		Shard:              controller.ShardConfig{Index: shardIndex, Count: shardCount},
+		ProviderHealth:     providerHealth,
+		Preflight:          preflightChecker,
+		TagLabels:          tagLabels,
+		StalledThreshold:   stalledThreshold,
+		ApplyDebounce:      applyDebounce,
+		FlapDamping:        flapDamping,
+		Maintenance:        maintenanceWindows,
+		Prober:             probe.NewProber(),
+		Quota:              namespaceQuota,
+		StableOwnerIDs:     stableOwnerIDs,
+		ClusterID:          clusterIdentity.ClusterID,
+	}
+	if operatorConfigSpec.ReconcileInterval != nil {
+		dnsRecordReconciler.ReconcileInterval = operatorConfigSpec.ReconcileInterval.Duration
+	}
+	dnsRecordReconciler.ConcurrentReconciles = operatorConfigSpec.MaxConcurrentReconciles
+	if propagationInterval > 0 {
+		if len(propagationResolvers) == 0 {
+			propagationResolvers = stringSliceFlags{"8.8.8.8:53", "1.1.1.1:53"}
+		}
+		dnsRecordReconciler.Propagation = propagation.NewMonitor(propagationResolvers, propagationInterval)
+	}
+	if notificationWebhookURL != "" {
+		switch notificationFormat {
+		case "json":
+			dnsRecordReconciler.Notifier = notify.NewNotifier(notificationWebhookURL)
+		case "cloudevents":
+			dnsRecordReconciler.Notifier = notify.NewCloudEventsNotifier(notificationWebhookURL)
+		default:
+			setupLog.Error(fmt.Errorf("invalid notification format %q", notificationFormat), "unable to set up notifications")
+			os.Exit(1)
+		}
+	}
+	var registryWatcher *controller.RegistryWatcher
+	if registryPollInterval > 0 {
+		registryWatcher = controller.NewRegistryWatcher(mgr.GetClient(), providerFactory, registryPollInterval)
+		dnsRecordReconciler.RegistryWatch = registryWatcher
+	}
+	// provider change notifications are opted into per provider secret, the
+	// consumer is idle for secrets that configure none
+	changeEventConsumer := controller.NewChangeEventConsumer(mgr.GetClient())
+	dnsRecordReconciler.ChangeEvents = changeEventConsumer
+	if err = dnsRecordReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DNSRecord")
+		os.Exit(1)
+	}
+	if registryWatcher != nil {
+		if err = mgr.Add(registryWatcher); err != nil {
+			setupLog.Error(err, "unable to create registry watcher")
+			os.Exit(1)
+		}
+	}
+	if err = mgr.Add(changeEventConsumer); err != nil {
+		setupLog.Error(err, "unable to create change event consumer")
+		os.Exit(1)
+	}
+	if repairOnStart || repairInterval > 0 {
+		if err = mgr.Add(&controller.RepairScan{
+			Reconciler: dnsRecordReconciler,
+			OnStart:    repairOnStart,
+			Interval:   repairInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to create repair scan")
+			os.Exit(1)
+		}
+	}
+	if err = (&controller.ZoneBackupReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		ProviderFactory: providerFactory,
+		RegistryConfig:  registryConfig,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ZoneBackup")
+		os.Exit(1)
+	}
+	if err = (&controller.DNSVerificationReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DNSVerification")
+		os.Exit(1)
+	}
+	if err = (&controller.DNSRecordSetReconciler{
+		Client:          mgr.GetClient(),
+		ClusterIdentity: clusterIdentity,
+		WatchGateways:   enableGatewayController,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DNSRecordSet")
+		os.Exit(1)
+	}
+	if operatorConfigName != "" {
+		if err = (&controller.DNSOperatorConfigReconciler{
+			Client:     mgr.GetClient(),
+			Scheme:     mgr.GetScheme(),
+			ConfigName: operatorConfigName,
+			Applied:    operatorConfigSpec,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DNSOperatorConfig")
+			os.Exit(1)
+		}
+	}
+	if gcInterval > 0 {
+		if err = mgr.Add(&controller.OrphanGC{
+			Client:             mgr.GetClient(),
+			ProviderFactory:    providerFactory,
+			Interval:           gcInterval,
+			GracePeriod:        gcGracePeriod,
+			RegistryConfig:     registryConfig,
+			HeartbeatThreshold: heartbeatThreshold,
+		}); err != nil {
+			setupLog.Error(err, "unable to create orphan gc")
+			os.Exit(1)
+		}
+	}
+	if heartbeatInterval > 0 {
+		if err = mgr.Add(&controller.HeartbeatWriter{
+			Client:          mgr.GetClient(),
+			ProviderFactory: providerFactory,
+			Interval:        heartbeatInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to create heartbeat writer")
+			os.Exit(1)
+		}
+	}
+	if enableGatewayController {
+		if err = (&controller.GatewayReconciler{
+			Client:          mgr.GetClient(),
+			ClusterIdentity: clusterIdentity,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Gateway")
+			os.Exit(1)
+		}
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		v1alpha1.SetNamespaceQuota(namespaceQuota)
+		v1alpha1.SetStableOwnerIDs(stableOwnerIDs)
+		if err = (&v1alpha1.DNSRecord{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DNSRecord")
+			os.Exit(1)
+		}
+	}
+	//+kubebuilder:scaffold:builder
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+	if err := (&controller.ProviderSecretReconciler{
+		Client:          mgr.GetClient(),
+		ProviderFactory: providerFactory,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ProviderSecret")
+		os.Exit(1)
+	}
+
+	if err := mgr.AddReadyzCheck("providers", providerHealth.Check); err != nil {
+		setupLog.Error(err, "unable to set up provider ready check")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
+
+// stringSliceFlags is a repeatable string flag that also accepts comma
+// separated values.
+type stringSliceFlags []string
+
+func (n *stringSliceFlags) String() string {
+	return strings.Join(*n, ",")
+}
+
+func (n *stringSliceFlags) Set(s string) error {
+	for _, v := range strings.Split(s, ",") {
+		if v == "" {
+			continue
+		}
+		*n = append(*n, v)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+// ToBase36Hash returns a base36 hash of the given string.
+func ToBase36Hash(s string) string {
+	hash := sha256.Sum224([]byte(s))
+	// convert the hash to base36 (alphanumeric) to decrease collision probabilities
+	return big.NewInt(0).SetBytes(hash[:]).Text(36)
+}
+
+// ToBase36HashLen returns the first l characters of a base36 hash of the given string.
+func ToBase36HashLen(s string, l int) string {
+	return ToBase36Hash(s)[:l]
+}
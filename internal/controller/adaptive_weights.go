@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"math"
+	"net"
+	"strconv"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/probe"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/builder"
+)
+
+// applyAdaptiveWeights scales the weight of each weighted endpoint by the
+// recent probe success ratio of its targets. A fully healthy endpoint keeps
+// its configured weight; a degraded one keeps receiving a proportionally
+// reduced share instead of being withdrawn outright, and only an endpoint
+// with no succeeding targets drops to weight zero.
+func (r *DNSRecordReconciler) applyAdaptiveWeights(dnsRecord *v1alpha1.DNSRecord, endpoints []*externaldnsendpoint.Endpoint) {
+	healthCheck := dnsRecord.Spec.HealthCheck
+	if r.Prober == nil || healthCheck == nil || !healthCheck.AdaptiveWeights {
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		weight, ok := endpoint.GetProviderSpecificProperty(builder.ProviderSpecificWeight)
+		if !ok {
+			continue
+		}
+		baseWeight, err := strconv.Atoi(weight)
+		if err != nil || baseWeight <= 0 {
+			continue
+		}
+		ratio := r.endpointSuccessRatio(dnsRecord, endpoint)
+		if ratio >= 1 {
+			continue
+		}
+		scaled := int(math.Round(float64(baseWeight) * ratio))
+		if scaled < 1 && ratio > 0 {
+			scaled = 1
+		}
+		endpoint.SetProviderSpecificProperty(builder.ProviderSpecificWeight, strconv.Itoa(scaled))
+	}
+}
+
+// endpointSuccessRatio averages the probe success ratio of the endpoints
+// address targets. Non-address targets (CNAMEs into the record tree) are
+// not probed and count as healthy.
+func (r *DNSRecordReconciler) endpointSuccessRatio(dnsRecord *v1alpha1.DNSRecord, endpoint *externaldnsendpoint.Endpoint) float64 {
+	healthCheck := dnsRecord.Spec.HealthCheck
+	scheme := "http"
+	if healthCheck.Protocol == v1alpha1.HttpsProtocol {
+		scheme = "https"
+	}
+
+	total := 0.0
+	probed := 0
+	for _, target := range endpoint.Targets {
+		if net.ParseIP(target) == nil {
+			continue
+		}
+		total += r.Prober.SuccessRatio(probe.Check{
+			Host:     dnsRecord.GetRootHost(),
+			Address:  target,
+			Path:     healthCheck.Path,
+			Port:     healthCheck.Port,
+			Scheme:   scheme,
+			Interval: healthCheck.Interval.Duration,
+		})
+		probed++
+	}
+	if probed == 0 {
+		return 1
+	}
+	return total / float64(probed)
+}
@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// changeEventResync is how often the consumer re-lists provider secrets to
+// pick up added or removed change event configurations.
+const changeEventResync = time.Minute
+
+// changeEventBackoff is the wait after a failed receive before retrying.
+const changeEventBackoff = 30 * time.Second
+
+// ChangeEventConsumer reads provider native change notifications (e.g.
+// Route53 events delivered through EventBridge/SQS) for every provider
+// secret that configures a source and converts them into reconcile triggers
+// for the affected records, detecting out-of-band zone changes without
+// waiting for periodic polling.
+type ChangeEventConsumer struct {
+	client.Client
+
+	events chan event.GenericEvent
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+}
+
+var _ manager.Runnable = &ChangeEventConsumer{}
+
+// NewChangeEventConsumer returns a consumer reading change notifications
+// with the given client.
+func NewChangeEventConsumer(c client.Client) *ChangeEventConsumer {
+	return &ChangeEventConsumer{
+		Client:  c,
+		events:  make(chan event.GenericEvent),
+		running: map[string]context.CancelFunc{},
+	}
+}
+
+// Events is the source of reconcile triggers for records whose zones
+// changed.
+func (c *ChangeEventConsumer) Events() <-chan event.GenericEvent {
+	return c.events
+}
+
+// NeedLeaderElection ensures only the leader consumes the notification
+// queues, messages are deleted on receipt and must feed a single instance.
+func (c *ChangeEventConsumer) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *ChangeEventConsumer) Start(ctx context.Context) error {
+	c.sync(ctx)
+	ticker := time.NewTicker(changeEventResync)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.sync(ctx)
+		}
+	}
+}
+
+// sync starts a consuming goroutine for every provider secret configuring a
+// change event source and stops the goroutines of secrets that are gone.
+func (c *ChangeEventConsumer) sync(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("change_events")
+
+	secretList := &corev1.SecretList{}
+	if err := c.List(ctx, secretList); err != nil {
+		logger.Error(err, "Failed to list provider secrets, skipping sync")
+		return
+	}
+
+	seen := map[string]struct{}{}
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if !provider.IsProviderSecret(secret) {
+			continue
+		}
+		secretKey := secret.Namespace + "/" + secret.Name
+		seen[secretKey] = struct{}{}
+
+		c.mu.Lock()
+		_, alreadyRunning := c.running[secretKey]
+		c.mu.Unlock()
+		if alreadyRunning {
+			continue
+		}
+
+		source, err := provider.ChangeEventSourceFor(ctx, secret)
+		if err != nil {
+			logger.Error(err, "Failed to create change event source", "secret", secretKey)
+			continue
+		}
+		if source == nil {
+			continue
+		}
+
+		consumeCtx, cancel := context.WithCancel(ctx)
+		c.mu.Lock()
+		c.running[secretKey] = cancel
+		c.mu.Unlock()
+		logger.Info("Consuming provider change events", "secret", secretKey)
+		go c.consume(consumeCtx, secret.DeepCopy())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for secretKey, cancel := range c.running {
+		if _, ok := seen[secretKey]; !ok {
+			cancel()
+			delete(c.running, secretKey)
+		}
+	}
+}
+
+// consume receives change events for one provider secret until its context
+// is cancelled. The source is rebuilt per receive failure so rotated
+// credentials are picked up.
+func (c *ChangeEventConsumer) consume(ctx context.Context, secret *corev1.Secret) {
+	logger := log.FromContext(ctx).WithName("change_events")
+	secretKey := secret.Namespace + "/" + secret.Name
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.running, secretKey)
+		c.mu.Unlock()
+	}()
+
+	source, err := provider.ChangeEventSourceFor(ctx, secret)
+	if err != nil || source == nil {
+		return
+	}
+	for {
+		names, err := source.Receive(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Error(err, "Failed to receive change events", "secret", secretKey)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(changeEventBackoff):
+			}
+			if rebuilt, err := provider.ChangeEventSourceFor(ctx, secret); err == nil && rebuilt != nil {
+				source = rebuilt
+			}
+			continue
+		}
+		c.enqueueRecords(ctx, secret, names)
+	}
+}
+
+// enqueueRecords triggers reconciles of the records publishing through the
+// given provider secret. With a non-empty name list only records whose
+// rootHost subtree was touched are enqueued, an empty list enqueues them
+// all.
+func (c *ChangeEventConsumer) enqueueRecords(ctx context.Context, secret *corev1.Secret, names []string) {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := c.List(ctx, recordList,
+		client.InNamespace(secret.Namespace),
+		client.MatchingFields{ProviderRefNameIndex: secret.Name}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list DNSRecords for provider secret", "secret", secret.Namespace+"/"+secret.Name)
+		return
+	}
+	for i := range recordList.Items {
+		record := &recordList.Items[i]
+		if len(names) > 0 && !touchesRootHost(names, record.GetRootHost()) {
+			continue
+		}
+		select {
+		case c.events <- event.GenericEvent{Object: &v1alpha1.DNSRecord{
+			ObjectMeta: metav1.ObjectMeta{Namespace: record.Namespace, Name: record.Name},
+		}}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// touchesRootHost returns true when any changed name is the rootHost or
+// under it, including registry TXT names prefixed onto a name in the
+// subtree.
+func touchesRootHost(names []string, rootHost string) bool {
+	for _, name := range names {
+		if name == rootHost || strings.HasSuffix(name, rootHost) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// errCoOwned marks applies held back because a foreign tool manages names
+// under the rootHost and the records coOwnershipPolicy refuses to touch them.
+var errCoOwned = errors.New("a foreign owner manages names under the rootHost")
+
+// heritage markers of external-dns registry TXT records. Our own registry
+// uses the same value format but writes under the configured TXT prefix, so
+// a heritage TXT outside that prefix belongs to another tool.
+const (
+	heritageValue  = "heritage=external-dns"
+	heritageOwner  = "external-dns/owner="
+	maxOwnerDetail = 5
+)
+
+// checkCoOwnership looks for registry TXT records of other tools under the
+// rootHost and surfaces them in the CoOwnershipDetected condition. Two
+// uncoordinated owners of the same names silently fight, each reverting the
+// others changes, so unless the records coOwnershipPolicy is TakeOver the
+// apply is held back until the conflict is resolved. Read failures are
+// ignored, the next reconcile repeats the check.
+func (r *DNSRecordReconciler) checkCoOwnership(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, dnsProvider provider.Provider, cfg provider.RegistryConfig) error {
+	rawRecords, err := dnsProvider.Records(ctx)
+	if err != nil {
+		return nil
+	}
+
+	owners := foreignOwners(rawRecords, dnsRecord.GetRootHost(), dnsRecord.Status.OwnerID, cfg)
+	if len(owners) == 0 {
+		meta.RemoveStatusCondition(&dnsRecord.Status.Conditions, v1alpha1.ConditionTypeCoOwnershipDetected)
+		return nil
+	}
+
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeCoOwnershipDetected, metav1.ConditionTrue, v1alpha1.ConditionReasonForeignOwner, describeForeignOwners(owners))
+	if dnsRecord.Spec.CoOwnershipPolicy == v1alpha1.CoOwnershipPolicyTakeOver {
+		log.FromContext(ctx).Info("Publishing despite a foreign owner per the co-ownership policy", "owners", len(owners))
+		return nil
+	}
+	return fmt.Errorf("%w: %s; set coOwnershipPolicy to TakeOver to publish anyway", errCoOwned, describeForeignOwners(owners))
+}
+
+// foreignOwners returns the registry TXT names of other tools under the
+// rootHost, keyed by the owner they declare. A heritage TXT is foreign when
+// it names a different owner and does not carry our registry TXT prefix,
+// i.e. it was written by a registry with a different format.
+func foreignOwners(rawRecords []*externaldnsendpoint.Endpoint, rootHost, ownerID string, cfg provider.RegistryConfig) map[string][]string {
+	owners := map[string][]string{}
+	for _, record := range rawRecords {
+		if record.RecordType != externaldnsendpoint.RecordTypeTXT || !strings.HasSuffix(record.DNSName, rootHost) {
+			continue
+		}
+		if cfg.TXTPrefix != "" && strings.HasPrefix(record.DNSName, cfg.TXTPrefix) {
+			continue
+		}
+		for _, target := range record.Targets {
+			if !strings.Contains(target, heritageValue) {
+				continue
+			}
+			owner := ownerFromHeritage(target)
+			if owner == "" || owner == ownerID {
+				continue
+			}
+			owners[owner] = append(owners[owner], record.DNSName)
+		}
+	}
+	return owners
+}
+
+func ownerFromHeritage(target string) string {
+	for _, field := range strings.Split(strings.Trim(target, "\""), ",") {
+		if value, found := strings.CutPrefix(field, heritageOwner); found {
+			return value
+		}
+	}
+	return ""
+}
+
+// describeForeignOwners renders the foreign owners deterministically for the
+// condition message, truncating long name lists.
+func describeForeignOwners(owners map[string][]string) string {
+	names := make([]string, 0, len(owners))
+	for owner := range owners {
+		names = append(names, owner)
+	}
+	sort.Strings(names)
+
+	descriptions := make([]string, 0, len(names))
+	for _, owner := range names {
+		records := owners[owner]
+		sort.Strings(records)
+		if len(records) > maxOwnerDetail {
+			records = append(records[:maxOwnerDetail:maxOwnerDetail], fmt.Sprintf("and %d more", len(records)-maxOwnerDetail))
+		}
+		descriptions = append(descriptions, fmt.Sprintf("owner %q manages %s", owner, strings.Join(records, ", ")))
+	}
+	return "Foreign registry records found under the rootHost: " + strings.Join(descriptions, "; ")
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+func TestForeignOwners(t *testing.T) {
+	txt := func(dnsName, value string) *externaldnsendpoint.Endpoint {
+		return &externaldnsendpoint.Endpoint{DNSName: dnsName, RecordType: externaldnsendpoint.RecordTypeTXT, Targets: []string{value}}
+	}
+	cfg := provider.DefaultRegistryConfig()
+	rawRecords := []*externaldnsendpoint.Endpoint{
+		// our own registry entry, excluded by the TXT prefix
+		txt("kuadrant-a-app.example.com", "\"heritage=external-dns,external-dns/owner=owner1\""),
+		// a foreign external-dns entry for a name under the rootHost
+		txt("a-app.example.com", "\"heritage=external-dns,external-dns/owner=legacy\""),
+		// a heritage entry declaring our own owner id stays ours
+		txt("extdns-a-app.example.com", "\"heritage=external-dns,external-dns/owner=owner1\""),
+		// a foreign entry outside the rootHost subtree
+		txt("a-app.other.com", "\"heritage=external-dns,external-dns/owner=legacy\""),
+		// a plain TXT record without heritage markers
+		txt("txt.app.example.com", "\"v=spf1 -all\""),
+		{DNSName: "app.example.com", RecordType: externaldnsendpoint.RecordTypeA, Targets: []string{"1.1.1.1"}},
+	}
+
+	owners := foreignOwners(rawRecords, "app.example.com", "owner1", cfg)
+	if len(owners) != 1 {
+		t.Fatalf("foreignOwners() found %d owners, want 1: %v", len(owners), owners)
+	}
+	records, ok := owners["legacy"]
+	if !ok || len(records) != 1 || records[0] != "a-app.example.com" {
+		t.Errorf("foreignOwners() = %v, want the legacy owner with a-app.example.com", owners)
+	}
+}
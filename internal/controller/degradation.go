@@ -0,0 +1,81 @@
+package controller
+
+import (
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// routingProperties are the provider specific properties carrying geo and
+// weighted routing, as produced by the endpoints builder. They are stripped
+// when degrading to round-robin.
+var routingProperties = map[string]struct{}{
+	"weight":              {},
+	"geo-code":            {},
+	"geoproximity-region": {},
+	"latitude":            {},
+	"longitude":           {},
+	"bias":                {},
+	"client-subnet":       {},
+}
+
+// needsDegradation returns true if the record opted into round-robin
+// degradation and the provider lacks a routing feature its endpoints use.
+func needsDegradation(dnsRecord *v1alpha1.DNSRecord, capabilities provider.Capabilities) bool {
+	if dnsRecord.Spec.DegradationPolicy != v1alpha1.DegradationPolicyRoundRobin {
+		return false
+	}
+	if capabilities.Weighted && capabilities.GeoGranularity != provider.GeoGranularityNone && capabilities.GeoGranularity != "" {
+		return false
+	}
+	for _, endpoint := range dnsRecord.Spec.Endpoints {
+		for _, property := range endpoint.ProviderSpecific {
+			if _, ok := routingProperties[property.Name]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// degradeEndpoints converts geo or weighted endpoints into plain round-robin
+// RRsets: routing properties are stripped and RRsets differing only in their
+// set identifier are merged into one with the union of their targets. The
+// documented trade-off of the RoundRobin policy is that every target answers
+// everywhere, instead of the record failing outright.
+func degradeEndpoints(endpoints []*externaldnsendpoint.Endpoint) []*externaldnsendpoint.Endpoint {
+	merged := map[string]*externaldnsendpoint.Endpoint{}
+	var order []string
+	for _, original := range endpoints {
+		key := original.DNSName + "/" + original.RecordType
+		existing, ok := merged[key]
+		if !ok {
+			degraded := &externaldnsendpoint.Endpoint{
+				DNSName:    original.DNSName,
+				RecordType: original.RecordType,
+				RecordTTL:  original.RecordTTL,
+				Labels:     original.Labels,
+			}
+			for _, property := range original.ProviderSpecific {
+				if _, routing := routingProperties[property.Name]; !routing {
+					degraded.ProviderSpecific = append(degraded.ProviderSpecific, property)
+				}
+			}
+			merged[key] = degraded
+			order = append(order, key)
+			existing = degraded
+		}
+		for _, target := range original.Targets {
+			if !contains(existing.Targets, target) {
+				existing.Targets = append(existing.Targets, target)
+			}
+		}
+	}
+
+	result := make([]*externaldnsendpoint.Endpoint, 0, len(order))
+	for _, key := range order {
+		result = append(result, merged[key])
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestDegradeEndpoints(t *testing.T) {
+	weighted := func(setID, target, weight string) *externaldnsendpoint.Endpoint {
+		return &externaldnsendpoint.Endpoint{
+			DNSName:       "lb.example.com",
+			RecordType:    externaldnsendpoint.RecordTypeCNAME,
+			SetIdentifier: setID,
+			Targets:       []string{target},
+			ProviderSpecific: externaldnsendpoint.ProviderSpecific{
+				{Name: "weight", Value: weight},
+			},
+		}
+	}
+	plain := &externaldnsendpoint.Endpoint{
+		DNSName:    "app.example.com",
+		RecordType: externaldnsendpoint.RecordTypeA,
+		Targets:    []string{"1.1.1.1"},
+	}
+
+	got := degradeEndpoints([]*externaldnsendpoint.Endpoint{
+		weighted("cluster1", "c1.example.com", "100"),
+		weighted("cluster2", "c2.example.com", "200"),
+		plain,
+	})
+	want := []*externaldnsendpoint.Endpoint{
+		{
+			DNSName:    "lb.example.com",
+			RecordType: externaldnsendpoint.RecordTypeCNAME,
+			Targets:    []string{"c1.example.com", "c2.example.com"},
+		},
+		{
+			DNSName:    "app.example.com",
+			RecordType: externaldnsendpoint.RecordTypeA,
+			Targets:    []string{"1.1.1.1"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("degradeEndpoints() = %v, want %v", got, want)
+	}
+}
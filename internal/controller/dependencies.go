@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// DependsOnIndex indexes DNSRecords by the names of the records they depend
+// on, allowing a dependency turning Ready to be mapped to exactly the
+// records waiting on it.
+const DependsOnIndex = "spec.dependsOn"
+
+// checkDependencies returns an error naming the dependsOn records that are
+// not yet Ready, so publication of a dependent record is held back until
+// everything it points at is confirmed live. Only publication is gated,
+// deletion never waits on dependencies.
+func (r *DNSRecordReconciler) checkDependencies(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) error {
+	var notReady []string
+	for _, name := range dnsRecord.Spec.DependsOn {
+		dependency := &v1alpha1.DNSRecord{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: dnsRecord.Namespace, Name: name}, dependency); err != nil {
+			if apierrors.IsNotFound(err) {
+				notReady = append(notReady, name+" (not found)")
+				continue
+			}
+			return err
+		}
+		if !meta.IsStatusConditionTrue(dependency.Status.Conditions, v1alpha1.ConditionTypeReady) {
+			notReady = append(notReady, name)
+		}
+	}
+	if len(notReady) > 0 {
+		return fmt.Errorf("waiting for dependencies to be ready: %s", strings.Join(notReady, ", "))
+	}
+	return nil
+}
+
+// recordsForDependency maps a DNSRecord event to the records in its
+// namespace depending on it, so a dependency turning Ready releases its
+// dependents without waiting for their periodic requeue.
+func (r *DNSRecordReconciler) recordsForDependency(ctx context.Context, obj client.Object) []reconcile.Request {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, recordList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{DependsOnIndex: obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list DNSRecords for dependency", "dnsrecord", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(recordList.Items))
+	for i := range recordList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&recordList.Items[i])})
+	}
+	return requests
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+// filterUnchangedUpdates removes update pairs whose old and new endpoints
+// would publish the identical RRset, returning how many were dropped. The
+// plan pairs whole record sets, so a reconcile touching one RRset of a
+// record can still produce no-op upserts for all the others; submitting
+// them only burns provider write quota and churns propagation.
+func filterUnchangedUpdates(changes *externaldnsplan.Changes) int {
+	if len(changes.UpdateOld) == 0 || len(changes.UpdateOld) != len(changes.UpdateNew) {
+		return 0
+	}
+	oldKept := changes.UpdateOld[:0]
+	newKept := changes.UpdateNew[:0]
+	dropped := 0
+	for i := range changes.UpdateNew {
+		oldEndpoint, newEndpoint := changes.UpdateOld[i], changes.UpdateNew[i]
+		if sameRRset(oldEndpoint, newEndpoint) && endpointsEquivalent(oldEndpoint, newEndpoint) {
+			dropped++
+			continue
+		}
+		oldKept = append(oldKept, oldEndpoint)
+		newKept = append(newKept, newEndpoint)
+	}
+	changes.UpdateOld = oldKept
+	changes.UpdateNew = newKept
+	return dropped
+}
+
+// sameRRset reports whether two endpoints address the same RRset.
+func sameRRset(a, b *externaldnsendpoint.Endpoint) bool {
+	return a.DNSName == b.DNSName && a.RecordType == b.RecordType && a.SetIdentifier == b.SetIdentifier
+}
+
+// endpointsEquivalent reports whether two endpoints of the same RRset would
+// publish the same values: equal targets regardless of order, TTL, provider
+// specific properties and registry labels.
+func endpointsEquivalent(a, b *externaldnsendpoint.Endpoint) bool {
+	if a.RecordTTL != b.RecordTTL {
+		return false
+	}
+	if !sameTargets(a.Targets, b.Targets) {
+		return false
+	}
+	if !sameProperties(a.ProviderSpecific, b.ProviderSpecific) {
+		return false
+	}
+	return sameLabels(a.Labels, b.Labels)
+}
+
+func sameTargets(a, b externaldnsendpoint.Targets) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	remaining := map[string]int{}
+	for _, target := range a {
+		remaining[strings.ToLower(target)]++
+	}
+	for _, target := range b {
+		key := strings.ToLower(target)
+		if remaining[key] == 0 {
+			return false
+		}
+		remaining[key]--
+	}
+	return true
+}
+
+func sameProperties(a, b externaldnsendpoint.ProviderSpecific) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	values := map[string]string{}
+	for _, property := range a {
+		values[property.Name] = property.Value
+	}
+	for _, property := range b {
+		value, ok := values[property.Name]
+		if !ok || value != property.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func sameLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if b[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+func TestFilterUnchangedUpdates(t *testing.T) {
+	endpoint := func(dnsName string, ttl int64, targets ...string) *externaldnsendpoint.Endpoint {
+		return &externaldnsendpoint.Endpoint{
+			DNSName:    dnsName,
+			RecordType: externaldnsendpoint.RecordTypeA,
+			RecordTTL:  externaldnsendpoint.TTL(ttl),
+			Targets:    targets,
+		}
+	}
+	changes := &externaldnsplan.Changes{
+		UpdateOld: []*externaldnsendpoint.Endpoint{
+			// identical values, only the target order differs
+			endpoint("same.example.com", 60, "1.1.1.1", "2.2.2.2"),
+			endpoint("ttl.example.com", 60, "1.1.1.1"),
+			endpoint("targets.example.com", 60, "1.1.1.1", "2.2.2.2"),
+		},
+		UpdateNew: []*externaldnsendpoint.Endpoint{
+			endpoint("same.example.com", 60, "2.2.2.2", "1.1.1.1"),
+			endpoint("ttl.example.com", 120, "1.1.1.1"),
+			endpoint("targets.example.com", 60, "1.1.1.1", "3.3.3.3"),
+		},
+	}
+
+	if dropped := filterUnchangedUpdates(changes); dropped != 1 {
+		t.Errorf("filterUnchangedUpdates() dropped %d pairs, want 1", dropped)
+	}
+	if len(changes.UpdateNew) != 2 || len(changes.UpdateOld) != 2 {
+		t.Fatalf("expected 2 update pairs to remain, got %d / %d", len(changes.UpdateOld), len(changes.UpdateNew))
+	}
+	for i, want := range []string{"ttl.example.com", "targets.example.com"} {
+		if changes.UpdateNew[i].DNSName != want {
+			t.Errorf("UpdateNew[%d] = %s, want %s", i, changes.UpdateNew[i].DNSName, want)
+		}
+	}
+}
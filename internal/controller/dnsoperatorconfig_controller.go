@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// DNSOperatorConfigReconciler reconciles a DNSOperatorConfig object. The
+// configuration is read once at startup; this controller only reports
+// through the Applied condition whether the running instance still matches
+// the spec, so GitOps pipelines see when a restart is required.
+type DNSOperatorConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ConfigName is the resource name this instance was started with.
+	ConfigName string
+	// Applied is the spec snapshot the running instance loaded at startup.
+	Applied v1alpha1.DNSOperatorConfigSpec
+}
+
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsoperatorconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsoperatorconfigs/status,verbs=get;update;patch
+
+func (r *DNSOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	previous := &v1alpha1.DNSOperatorConfig{}
+	if err := r.Get(ctx, req.NamespacedName, previous); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	config := previous.DeepCopy()
+
+	if config.Name != r.ConfigName {
+		// only the named singleton configures this instance
+		setDNSOperatorConfigCondition(config, metav1.ConditionFalse, v1alpha1.ConditionReasonRestartRequired,
+			"This configuration is not selected by the --operator-config flag")
+		return ctrl.Result{}, r.updateStatus(ctx, previous, config)
+	}
+
+	if equality.Semantic.DeepEqual(config.Spec, r.Applied) {
+		setDNSOperatorConfigCondition(config, metav1.ConditionTrue, v1alpha1.ConditionReasonApplied,
+			"The running instance was started with this configuration")
+	} else {
+		setDNSOperatorConfigCondition(config, metav1.ConditionFalse, v1alpha1.ConditionReasonRestartRequired,
+			"The configuration changed since startup, restart the operator to apply it")
+	}
+	return ctrl.Result{}, r.updateStatus(ctx, previous, config)
+}
+
+func (r *DNSOperatorConfigReconciler) updateStatus(ctx context.Context, previous, current *v1alpha1.DNSOperatorConfig) error {
+	if !equality.Semantic.DeepEqual(previous.Status, current.Status) {
+		return r.Status().Update(ctx, current)
+	}
+	return nil
+}
+
+// setDNSOperatorConfigCondition adds or updates the Applied condition in the
+// DNSOperatorConfig status.
+func setDNSOperatorConfigCondition(config *v1alpha1.DNSOperatorConfig, status metav1.ConditionStatus, reason, message string) {
+	cond := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeApplied,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: config.Generation,
+	}
+	meta.SetStatusCondition(&config.Status.Conditions, cond)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DNSOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DNSOperatorConfig{}).
+		Complete(r)
+}
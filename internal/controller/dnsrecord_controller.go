@@ -0,0 +1,1547 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/common/hash"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/notify"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/preflight"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/probe"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/propagation"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+	dnsregistry "github.com/mikenairn/kuadrant-dns-operator/internal/registry"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/endpoints"
+)
+
+const (
+	DNSRecordFinalizer = "kuadrant.io/dns-record"
+
+	// delegation roles an operator instance can run with
+	DelegationRolePrimary   = "primary"
+	DelegationRoleSecondary = "secondary"
+
+	// DefaultZoneRecordLimit is the per zone record limit assumed when none
+	// is configured, matching the Route53 RRset limit.
+	DefaultZoneRecordLimit = 10000
+
+	// DefaultMaxDeleteAttempts is how many times provider cleanup is
+	// retried during deletion when no limit is configured.
+	DefaultMaxDeleteAttempts = 10
+	// zoneCapacityWarningPercent is how full a zone may be, in percent of
+	// the record limit, before the ZoneCapacity condition degrades.
+	zoneCapacityWarningPercent = 90
+
+	// validationRequeueTime is how long we wait before checking that
+	// applied changes have taken effect in the provider zone.
+	validationRequeueTime = 5 * time.Second
+	// maxValidationRequeueTime caps the exponential validation backoff of
+	// records that keep producing writes.
+	maxValidationRequeueTime = 5 * time.Minute
+	// defaultRequeueTime is how long we wait before re-reconciling a
+	// record that is ready and has no pending changes.
+	defaultRequeueTime = 15 * time.Minute
+
+	// DefaultStalledThreshold is how long a record may stay not Ready with
+	// the same error before it is marked Stalled.
+	DefaultStalledThreshold = 10 * time.Minute
+
+	// frozenRequeueTime is how often a record with changes held back by a
+	// maintenance window rechecks whether the window has closed.
+	frozenRequeueTime = time.Minute
+)
+
+var managedDNSRecordTypes = []string{externaldnsendpoint.RecordTypeA, externaldnsendpoint.RecordTypeAAAA, externaldnsendpoint.RecordTypeCNAME}
+
+// DNSRecordReconciler reconciles a DNSRecord object
+type DNSRecordReconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	ProviderFactory provider.Factory
+	// RegistryConfig is the default registry format, overridable per
+	// provider secret.
+	RegistryConfig provider.RegistryConfig
+	// DelegationRole determines whether this instance performs provider
+	// writes for delegating records ("primary") or leaves them to another
+	// instance ("secondary").
+	DelegationRole string
+	// ZoneRecordLimit is the per zone record limit enforced by the provider,
+	// used to surface the ZoneCapacity condition. Defaults to
+	// DefaultZoneRecordLimit.
+	ZoneRecordLimit int
+	// MaxDeletesPerApply aborts any plan deleting more than this many owned
+	// records at once, unless confirmed on the record. Zero disables the
+	// protection.
+	MaxDeletesPerApply int
+	// MaxDeleteAttempts is how many times provider cleanup of a record
+	// being deleted is retried before the deletion is marked blocked and
+	// retries stop. Defaults to DefaultMaxDeleteAttempts.
+	MaxDeleteAttempts int
+	// NamespaceDomains restricts the domains namespaces may publish under.
+	NamespaceDomains NamespaceDomains
+	// Quota limits the DNSRecords and total endpoints per namespace. The
+	// webhook enforces it at admission; this re-check covers records
+	// admitted while the webhook was unavailable.
+	Quota v1alpha1.NamespaceQuota
+	// Shard assigns records to this replica when running multiple sharded
+	// replicas.
+	Shard ShardConfig
+	// ProviderHealth tracks provider connectivity for the readiness probe.
+	ProviderHealth *ProviderHealth
+	// Preflight, when set, checks the public CAA/DNSSEC configuration
+	// around a rootHost before publishing and surfaces findings as a
+	// condition.
+	Preflight *preflight.Checker
+	// TagLabels are DNSRecord label and annotation keys propagated as tags
+	// onto provider resources, on providers that support tagging.
+	TagLabels []string
+	// StalledThreshold is how long a record may stay not Ready with the
+	// same error before the Stalled condition is set. Defaults to
+	// DefaultStalledThreshold.
+	StalledThreshold time.Duration
+	// ApplyDebounce is a quiet period a changed spec must hold before it
+	// is applied, so rapid churn (e.g. gateway address flapping) writes
+	// only the settled state to the provider. Zero applies immediately.
+	ApplyDebounce time.Duration
+	// FlapDamping keeps a vanished target published for this long, so an
+	// address disappearing and reappearing within the window (LB churn)
+	// never reaches the provider. Zero disables damping.
+	FlapDamping time.Duration
+	// Maintenance are operator wide change-freeze windows. While a window is
+	// active no provider mutations are performed; reads and status updates
+	// continue. Records may declare additional windows via annotation.
+	Maintenance MaintenanceWindows
+	// Notifier, when set, delivers a notification whenever a records Ready
+	// condition transitions, so external pipelines can gate on DNS
+	// publication without polling.
+	Notifier *notify.Notifier
+	// Prober, when set, health probes weighted targets so records with
+	// adaptiveWeights shift traffic away from degraded clusters.
+	Prober *probe.Prober
+	// Propagation, when set, watches applied changes until they are
+	// observable on the zones authoritative servers and the configured
+	// public resolvers, exporting per provider propagation latency metrics.
+	Propagation *propagation.Monitor
+	// ReconcileInterval overrides the default re-validation interval of
+	// ready records with no pending changes. Zero uses defaultRequeueTime;
+	// records may still override it per spec.
+	ReconcileInterval time.Duration
+	// ConcurrentReconciles is the number of records reconciled in
+	// parallel. Zero uses the controller-runtime default.
+	ConcurrentReconciles int
+	// RegistryWatch, when set, feeds reconcile triggers for records whose
+	// registry state changed out of band, e.g. a remote owner resolving a
+	// conflict, so recovery does not wait for the periodic requeue.
+	RegistryWatch *RegistryWatcher
+	// ChangeEvents, when set, feeds reconcile triggers from provider native
+	// change notifications (e.g. Route53 events via EventBridge/SQS) for
+	// secrets that configure them.
+	ChangeEvents *ChangeEventConsumer
+	// StableOwnerIDs derives default owner ids from ClusterID, namespace
+	// and name instead of the record UID, so a recreated record re-adopts
+	// its provider entries. Explicit spec owner ids and delegated records
+	// are unaffected.
+	StableOwnerIDs bool
+	// ClusterID seeds stable owner ids.
+	ClusterID string
+
+	// zoneLock serializes zone writes across replicas when sharding.
+	zoneLock *zoneLock
+	// damper suppresses withdrawals of recently seen targets.
+	damper *flapDamper
+
+	// debounce tracks when each records generation was first observed, so
+	// applies can wait out spec churn.
+	debounceMu sync.Mutex
+	debounce   map[types.NamespacedName]debounceEntry
+}
+
+// debounceEntry is the generation of a record and when it was first seen.
+type debounceEntry struct {
+	generation int64
+	seenAt     time.Time
+}
+
+// settleTime returns how much longer the records current generation must
+// stay unchanged before it may be applied, and zero once it has settled.
+func (r *DNSRecordReconciler) settleTime(dnsRecord *v1alpha1.DNSRecord) time.Duration {
+	if r.ApplyDebounce <= 0 {
+		return 0
+	}
+	r.debounceMu.Lock()
+	defer r.debounceMu.Unlock()
+	if r.debounce == nil {
+		r.debounce = map[types.NamespacedName]debounceEntry{}
+	}
+
+	key := types.NamespacedName{Namespace: dnsRecord.Namespace, Name: dnsRecord.Name}
+	entry, ok := r.debounce[key]
+	if !ok || entry.generation != dnsRecord.Generation {
+		r.debounce[key] = debounceEntry{generation: dnsRecord.Generation, seenAt: time.Now()}
+		return r.ApplyDebounce
+	}
+	if remaining := r.ApplyDebounce - time.Since(entry.seenAt); remaining > 0 {
+		return remaining
+	}
+	delete(r.debounce, key)
+	return 0
+}
+
+// errDeleteThresholdExceeded marks plans aborted by the deletion safety
+// policy, so the reconciler can surface them as a condition awaiting manual
+// confirmation rather than a provider error.
+var errDeleteThresholdExceeded = errors.New("apply exceeds the deletion safety policy")
+
+// errChangeInFlight marks applies skipped because the identical change batch
+// was already submitted and its outcome is not confirmed yet.
+var errChangeInFlight = errors.New("identical change batch already in flight")
+
+// errApplyInProgress marks applies deferred because a long-running provider
+// operation from a previous apply has not completed yet.
+var errApplyInProgress = errors.New("provider operation in progress")
+
+// errFrozen marks applies held back by an active maintenance window.
+var errFrozen = errors.New("provider changes are frozen by a maintenance window")
+
+// errUnsupportedSpec marks specs rejected because the selected provider can
+// not satisfy them, a permanent condition until the spec or provider change.
+var errUnsupportedSpec = errors.New("spec not supported by the provider")
+
+// frozen returns true if a maintenance window, operator wide or declared on
+// the record, is active. An unparsable record annotation is ignored so a typo
+// never freezes (or unfreezes) anything silently past validation.
+func (r *DNSRecordReconciler) frozen(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) bool {
+	now := time.Now()
+	if r.Maintenance.Active(now) {
+		return true
+	}
+	if entries := dnsRecord.GetMaintenanceWindows(); len(entries) > 0 {
+		windows, err := ParseMaintenanceWindows(entries)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Ignoring invalid maintenance windows annotation")
+			return false
+		}
+		return windows.Active(now)
+	}
+	return false
+}
+
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsrecords,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsrecords/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsrecords/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsownerships,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsdomainclaims,verbs=get;list;watch
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+func (r *DNSRecordReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	previous := &v1alpha1.DNSRecord{}
+	if err := r.Get(ctx, req.NamespacedName, previous); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	dnsRecord := previous.DeepCopy()
+
+	if dnsRecord.DeletionTimestamp != nil && !dnsRecord.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(dnsRecord, DNSRecordFinalizer) {
+			if dnsRecord.Spec.Delegate && r.DelegationRole == DelegationRoleSecondary {
+				logger.Info("Delegated DNSRecord deleted on a secondary, skipping provider cleanup")
+			} else if dnsRecord.IsPaused() {
+				// a paused record must not touch the zone, deliberately
+				// leaving any published endpoints behind
+				logger.Info("DNSRecord is paused, skipping provider cleanup on deletion")
+			} else if dnsRecord.IsMigrating() {
+				// the published endpoints and registry entries are left
+				// for the replacement record to adopt via migrate-from
+				logger.Info("DNSRecord is migrating, skipping provider cleanup on deletion")
+			} else {
+				logger.Info("Deleting DNSRecord")
+				if err := r.deleteRecord(ctx, dnsRecord); err != nil {
+					if errors.Is(err, errFrozen) {
+						// cleanup is a mutation too, hold the deletion until
+						// the window closes without counting it as blocked
+						logger.Info("Maintenance window active, deferring provider cleanup")
+						return ctrl.Result{RequeueAfter: frozenRequeueTime}, nil
+					}
+					logger.Error(err, "Failed to delete DNSRecord in provider")
+					return r.blockedDeletion(ctx, previous, dnsRecord, err)
+				}
+			}
+			controllerutil.RemoveFinalizer(dnsRecord, DNSRecordFinalizer)
+			if err := r.Update(ctx, dnsRecord); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.notifyEvent(ctx, dnsRecord, notify.EventDeleted, "", "DNSRecord deleted")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(dnsRecord, DNSRecordFinalizer) {
+		logger.Info("Adding finalizer to DNSRecord")
+		controllerutil.AddFinalizer(dnsRecord, DNSRecordFinalizer)
+		if err := r.Update(ctx, dnsRecord); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.notifyEvent(ctx, dnsRecord, notify.EventCreated, "", "DNSRecord accepted for reconciliation")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if dnsRecord.IsPaused() {
+		logger.Info("DNSRecord is paused, skipping reconcile")
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionUnknown, v1alpha1.ConditionReasonPaused, "DNSRecord reconciliation is paused")
+		return ctrl.Result{}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if dnsRecord.Spec.Delegate && r.DelegationRole == DelegationRoleSecondary {
+		// a secondary never writes delegated records to the provider, the
+		// primary merges and publishes them
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionUnknown, v1alpha1.ConditionReasonDelegated, "DNSRecord is delegated to the primary instance")
+		return ctrl.Result{}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	dnsRecord.Status.OwnerID = dnsRecord.GetOwnerID()
+	if r.StableOwnerIDs && !dnsRecord.Spec.Delegate && dnsRecord.Spec.OwnerID == "" {
+		dnsRecord.Status.OwnerID = dnsRecord.GetStableOwnerID(r.ClusterID)
+	}
+
+	if dnsRecord.IsAdopting() && len(dnsRecord.Spec.Endpoints) == 0 {
+		imported, err := r.adoptEndpoints(ctx, dnsRecord)
+		if err != nil {
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonProviderError, fmt.Sprintf("Failed to adopt existing records: %v", provider.SanitizeError(err)))
+			if updateErr := r.updateStatus(ctx, previous, dnsRecord); updateErr != nil {
+				return ctrl.Result{}, updateErr
+			}
+			return ctrl.Result{}, err
+		}
+		if imported {
+			logger.Info("Adopted existing records from the provider zone")
+			if err := r.Update(ctx, dnsRecord); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	if fromOwner, requested := dnsRecord.GetTakeOverSource(); requested && fromOwner != dnsRecord.Status.OwnerID {
+		claimed, err := r.takeOverOwnership(ctx, dnsRecord, fromOwner)
+		if err != nil {
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonProviderError, fmt.Sprintf("Failed to take over records: %v", provider.SanitizeError(err)))
+			if updateErr := r.updateStatus(ctx, previous, dnsRecord); updateErr != nil {
+				return ctrl.Result{}, updateErr
+			}
+			return ctrl.Result{}, err
+		}
+		logger.Info("Took over registry entries from another owner", "fromOwner", fromOwner, "recordSets", claimed)
+		delete(dnsRecord.Annotations, v1alpha1.TakeOverAnnotation)
+		if err := r.Update(ctx, dnsRecord); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if source, requested := dnsRecord.GetMigrationSource(); requested {
+		claimed, err := r.migrateOwnership(ctx, dnsRecord)
+		if err != nil {
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonProviderError, fmt.Sprintf("Failed to adopt records from the migration source: %v", provider.SanitizeError(err)))
+			if updateErr := r.updateStatus(ctx, previous, dnsRecord); updateErr != nil {
+				return ctrl.Result{}, updateErr
+			}
+			return ctrl.Result{}, err
+		}
+		logger.Info("Adopted registry entries from the migration source", "source", source, "recordSets", claimed)
+		delete(dnsRecord.Annotations, v1alpha1.MigrateFromAnnotation)
+		if err := r.Update(ctx, dnsRecord); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if err := r.resolveEndpoints(ctx, dnsRecord); err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, fmt.Sprintf("resolving endpointsFrom failed: %v", err))
+		return ctrl.Result{RequeueAfter: validationRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if err := r.resolveTargets(ctx, dnsRecord); err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, fmt.Sprintf("resolving targetsFrom failed: %v", err))
+		return ctrl.Result{RequeueAfter: validationRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	r.applyRollback(dnsRecord)
+
+	if err := r.validateDomainClaims(ctx, dnsRecord); err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, fmt.Sprintf("validation of DNSRecord failed: %v", err))
+		r.notifyEvent(ctx, dnsRecord, notify.EventConflict, v1alpha1.ConditionReasonValidationError, err.Error())
+		return ctrl.Result{}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if err := r.NamespaceDomains.Validate(dnsRecord.Namespace, dnsRecord.Spec.RootHost); err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, fmt.Sprintf("validation of DNSRecord failed: %v", err))
+		return ctrl.Result{}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if err := v1alpha1.ValidateOwnerIDConflict(ctx, r.Client, dnsRecord); err != nil {
+		// the webhook rejects conflicts at admission, this covers records
+		// admitted while it was unavailable
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, fmt.Sprintf("validation of DNSRecord failed: %v", err))
+		r.notifyEvent(ctx, dnsRecord, notify.EventConflict, v1alpha1.ConditionReasonValidationError, err.Error())
+		return ctrl.Result{}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if err := r.Quota.Validate(ctx, r.Client, dnsRecord); err != nil {
+		// quota may free up without this record changing, re-check later
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonQuotaExceeded, err.Error())
+		return ctrl.Result{RequeueAfter: defaultRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if err := r.validateProviderSpecific(ctx, dnsRecord); err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, fmt.Sprintf("validation of DNSRecord failed: %v", err))
+		return ctrl.Result{RequeueAfter: validationRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if err := dnsRecord.Validate(); err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, fmt.Sprintf("validation of DNSRecord failed: %v", err))
+		return ctrl.Result{}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if err := r.checkDependencies(ctx, dnsRecord); err != nil {
+		// dependencies publish independently, their Ready transition enqueues
+		// this record again; the requeue only covers missed transitions
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonDependenciesNotReady, err.Error())
+		return ctrl.Result{RequeueAfter: defaultRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	if remaining := r.settleTime(dnsRecord); remaining > 0 {
+		// wait out spec churn so only the settled state is applied
+		logger.V(1).Info("Debouncing apply of a changed spec", "requeueAfter", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	r.runPreflight(ctx, dnsRecord)
+
+	hadChanges, err := r.publishRecord(ctx, dnsRecord)
+	if err != nil {
+		if errors.Is(err, errZoneLocked) {
+			// another replica is writing to the zone, try again shortly
+			return ctrl.Result{RequeueAfter: validationRequeueTime}, nil
+		}
+		if errors.Is(err, errChangeInFlight) {
+			// the same batch was already submitted, wait for it to settle
+			// instead of fighting the in-flight change
+			return ctrl.Result{RequeueAfter: validationRequeueTime}, nil
+		}
+		if errors.Is(err, errApplyInProgress) {
+			// poll the long-running provider operation across reconciles
+			// instead of blocking a worker for its whole duration
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonApplying,
+				fmt.Sprintf("Provider operation %s is in progress", dnsRecord.Status.ProviderOperationID))
+			return ctrl.Result{RequeueAfter: validationRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+		}
+		if errors.Is(err, errUnsupportedSpec) {
+			// permanent until the spec or provider changes, no error requeue
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, fmt.Sprintf("validation of DNSRecord failed: %v", err))
+			return ctrl.Result{}, r.updateStatus(ctx, previous, dnsRecord)
+		}
+		if errors.Is(err, errFrozen) {
+			// hold pending changes until the maintenance window closes; the
+			// status fields above still reflect the latest provider reads
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonFrozen,
+				"Pending changes are held back by an active maintenance window")
+			return ctrl.Result{RequeueAfter: frozenRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+		}
+		if errors.Is(err, errCoOwned) {
+			// hold writes until the foreign owner is gone or the policy
+			// allows a takeover, without error requeue
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonForeignOwner, fmt.Sprintf("Apply held back: %v", err))
+			return ctrl.Result{RequeueAfter: defaultRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+		}
+		if errors.Is(err, errDeleteThresholdExceeded) {
+			// hold the apply until a human confirms it, without error requeue
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonAwaitingConfirmation, fmt.Sprintf("Apply aborted: %v", err))
+			return ctrl.Result{RequeueAfter: defaultRequeueTime}, r.updateStatus(ctx, previous, dnsRecord)
+		}
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonProviderError, fmt.Sprintf("The DNS provider failed to ensure the record: %v", provider.SanitizeError(err)))
+		if updateErr := r.updateStatus(ctx, previous, dnsRecord); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	if hadChanges {
+		r.notifyEvent(ctx, dnsRecord, notify.EventApplied, "", "Changes applied to the provider zone")
+	}
+
+	requeueTime := r.setStatusForChanges(dnsRecord, hadChanges)
+
+	if readyCondition := meta.FindStatusCondition(dnsRecord.Status.Conditions, v1alpha1.ConditionTypeReady); readyCondition != nil && readyCondition.Status == metav1.ConditionTrue {
+		r.runSyntheticCheck(ctx, dnsRecord)
+	}
+
+	dnsRecord.Status.ObservedGeneration = dnsRecord.Generation
+	dnsRecord.Status.Endpoints = dnsRecord.Spec.Endpoints
+	dnsRecord.Status.EndpointCount = len(dnsRecord.Spec.Endpoints)
+	if err = r.updateStatus(ctx, previous, dnsRecord); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueTime}, nil
+}
+
+// resolveEndpoints loads the endpoints of a record referencing them from a
+// ConfigMap into the in-memory spec. The spec in the cluster is never
+// modified; the rest of the reconcile operates on spec.endpoints regardless
+// of where they came from.
+func (r *DNSRecordReconciler) resolveEndpoints(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) error {
+	if dnsRecord.Spec.EndpointsFrom == nil {
+		return nil
+	}
+	ref := dnsRecord.Spec.EndpointsFrom.ConfigMapRef
+	key := ref.Key
+	if key == "" {
+		key = v1alpha1.DefaultEndpointsKey
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: dnsRecord.Namespace, Name: ref.Name}, configMap); err != nil {
+		return fmt.Errorf("loading ConfigMap %q: %w", ref.Name, err)
+	}
+	raw, ok := configMap.Data[key]
+	if !ok {
+		return fmt.Errorf("ConfigMap %q has no key %q", ref.Name, key)
+	}
+	var endpoints []*externaldnsendpoint.Endpoint
+	if err := json.Unmarshal([]byte(raw), &endpoints); err != nil {
+		return fmt.Errorf("parsing endpoints from ConfigMap %q key %q: %w", ref.Name, key, err)
+	}
+	dnsRecord.Spec.Endpoints = endpoints
+	// cleared in memory only, so validation does not flag the resolved
+	// endpoints as conflicting with the reference they came from
+	dnsRecord.Spec.EndpointsFrom = nil
+	return nil
+}
+
+// runPreflight checks the public CAA/DNSSEC configuration around the
+// rootHost and surfaces findings in the Preflight condition. Findings never
+// block publishing; a broken parent zone is a problem to surface, not one
+// more way for records to get stuck.
+func (r *DNSRecordReconciler) runPreflight(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) {
+	if r.Preflight == nil {
+		return
+	}
+	findings, err := r.Preflight.Check(ctx, dnsRecord.GetRootHost(), dnsRecord.Status.ZoneDomainName, dnsRecord.Status.DSRecords)
+	if err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypePreflight, metav1.ConditionUnknown, v1alpha1.ConditionReasonChecksFailed,
+			fmt.Sprintf("Pre-flight checks could not complete: %v", err))
+		return
+	}
+	if len(findings) > 0 {
+		messages := make([]string, 0, len(findings))
+		for _, finding := range findings {
+			messages = append(messages, finding.String())
+		}
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypePreflight, metav1.ConditionFalse, v1alpha1.ConditionReasonChecksFailed, strings.Join(messages, "; "))
+		return
+	}
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypePreflight, metav1.ConditionTrue, v1alpha1.ConditionReasonChecksPassed, "Pre-flight checks passed")
+}
+
+// blockedDeletion handles a failed provider cleanup during deletion. Retries
+// back off exponentially up to MaxDeleteAttempts; once exhausted the
+// DeletionBlocked condition becomes terminal and retries stop, leaving the
+// finalizer in place for a human to resolve or remove.
+func (r *DNSRecordReconciler) blockedDeletion(ctx context.Context, previous, dnsRecord *v1alpha1.DNSRecord, deleteErr error) (ctrl.Result, error) {
+	maxAttempts := r.MaxDeleteAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxDeleteAttempts
+	}
+
+	dnsRecord.Status.DeleteCounter++
+	attempts := dnsRecord.Status.DeleteCounter
+
+	if attempts >= int64(maxAttempts) {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeDeletionBlocked, metav1.ConditionTrue, v1alpha1.ConditionReasonRetriesExhausted,
+			fmt.Sprintf("Provider cleanup failed %d times, giving up: %v", attempts, provider.SanitizeError(deleteErr)))
+		return ctrl.Result{}, r.updateStatus(ctx, previous, dnsRecord)
+	}
+
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeDeletionBlocked, metav1.ConditionTrue, v1alpha1.ConditionReasonProviderError,
+		fmt.Sprintf("Provider cleanup failed (attempt %d of %d): %v", attempts, maxAttempts, provider.SanitizeError(deleteErr)))
+	if err := r.updateStatus(ctx, previous, dnsRecord); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	backoff := validationRequeueTime << (attempts - 1)
+	if backoff > defaultRequeueTime {
+		backoff = defaultRequeueTime
+	}
+	return ctrl.Result{RequeueAfter: backoff}, nil
+}
+
+// setStatusForChanges updates the records status based on whether the last
+// publish resulted in changes in the provider zone and returns the
+// appropriate requeue duration. A write means the record must be re-validated
+// shortly, no changes means the zone agrees with the spec and the record is
+// ready.
+func (r *DNSRecordReconciler) setStatusForChanges(dnsRecord *v1alpha1.DNSRecord, hadChanges bool) time.Duration {
+	if hadChanges {
+		dnsRecord.Status.WriteCounter++
+		backoff := validationBackoff(dnsRecord.Status.WriteCounter)
+		dnsRecord.Status.QueuedAt = metav1.Now()
+		dnsRecord.Status.ValidFor = backoff.String()
+		dnsRecord.Status.NextCheckAt = metav1.NewTime(time.Now().Add(backoff))
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonAwaitingValidation, "Awaiting validation")
+		return backoff
+	}
+	requeueTime := defaultRequeueTime
+	if r.ReconcileInterval > 0 {
+		requeueTime = r.ReconcileInterval
+	}
+	if dnsRecord.Spec.ReconcileInterval != nil {
+		requeueTime = dnsRecord.Spec.ReconcileInterval.Duration
+	}
+	dnsRecord.Status.WriteCounter = 0
+	dnsRecord.Status.ValidFor = requeueTime.String()
+	dnsRecord.Status.NextCheckAt = metav1.NewTime(time.Now().Add(requeueTime))
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, metav1.ConditionTrue, v1alpha1.ConditionReasonProviderSuccess, "Provider ensured the dns record")
+	return requeueTime
+}
+
+// validationBackoff returns how long to wait before re-validating after a
+// write, doubling the validation interval per consecutive write on the same
+// generation up to a cap, with jitter of up to 20% so many records writing
+// at once do not re-check in lockstep.
+func validationBackoff(writeAttempts int64) time.Duration {
+	backoff := validationRequeueTime
+	for i := int64(1); i < writeAttempts && backoff < maxValidationRequeueTime; i++ {
+		backoff *= 2
+	}
+	if backoff > maxValidationRequeueTime {
+		backoff = maxValidationRequeueTime
+	}
+	return backoff + time.Duration(rand.Int63n(int64(backoff/5)))
+}
+
+// publishRecord ensures the records endpoints are published into the
+// provider zone, returning true if any changes were made.
+func (r *DNSRecordReconciler) publishRecord(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) (bool, error) {
+	return r.applyChanges(ctx, dnsRecord, false)
+}
+
+// deleteRecord removes all endpoints owned by the record from the provider zone.
+func (r *DNSRecordReconciler) deleteRecord(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) error {
+	_, err := r.applyChanges(ctx, dnsRecord, true)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// provider secret is already gone, nothing we can clean up
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// applyChanges calculates and applies the plan of changes required to move
+// the provider zone towards the records desired endpoints, or towards their
+// removal when isDelete is true.
+func (r *DNSRecordReconciler) applyChanges(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, isDelete bool) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	rootDomainFilter := externaldnsendpoint.NewDomainFilter([]string{dnsRecord.GetRootHost()})
+
+	dnsProvider, err := r.ProviderFactory.ProviderFor(ctx, dnsRecord, provider.Config{
+		DomainFilter: rootDomainFilter,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	zone, err := dnsProvider.DNSZoneForHost(ctx, dnsRecord.GetRootHost())
+	r.trackProviderHealth(dnsRecord, err)
+	if err != nil {
+		return false, err
+	}
+	dnsRecord.Status.ZoneID = zone.ID
+	dnsRecord.Status.ZoneDomainName = zone.DNSName
+	dnsRecord.Status.ZoneProject = zone.Project
+	dnsRecord.Status.RootHost = v1alpha1.PunycodeHost(dnsRecord.Spec.RootHost)
+
+	if !isDelete {
+		// reject endpoints the selected provider can not satisfy before
+		// anything is applied, naming the capability matrix in the error
+		capabilities := provider.CapabilitiesOf(dnsProvider)
+		if needsDegradation(dnsRecord, capabilities) {
+			// the record opted into round-robin over failing outright
+			dnsRecord.Spec.Endpoints = degradeEndpoints(dnsRecord.Spec.Endpoints)
+			setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeDegraded, metav1.ConditionTrue, v1alpha1.ConditionReasonRoundRobinFallback,
+				"The provider does not support the requested routing, publishing plain round-robin RRsets with all targets")
+		} else {
+			meta.RemoveStatusCondition(&dnsRecord.Status.Conditions, v1alpha1.ConditionTypeDegraded)
+		}
+		if err := capabilities.Check(dnsRecord.Spec.Endpoints); err != nil {
+			return false, fmt.Errorf("%w: %v (provider capabilities: %s)", errUnsupportedSpec, err, capabilities)
+		}
+
+		r.ensureDNSSEC(ctx, dnsRecord, dnsProvider, zone)
+		r.ensureProviderTags(ctx, dnsRecord, dnsProvider, zone)
+		r.ensureApexRedirect(ctx, dnsRecord, dnsProvider, zone)
+	}
+
+	if operationID := dnsRecord.Status.ProviderOperationID; operationID != "" {
+		if tracker, ok := provider.As[provider.OperationTracker](dnsProvider); ok {
+			done, err := tracker.OperationDone(ctx, operationID)
+			if err != nil {
+				return false, err
+			}
+			if !done {
+				return false, errApplyInProgress
+			}
+		}
+		dnsRecord.Status.ProviderOperationID = ""
+	}
+
+	if r.zoneLock != nil {
+		if err := r.zoneLock.acquire(ctx, zone.ID); err != nil {
+			return false, err
+		}
+		defer r.zoneLock.release(ctx, zone.ID)
+	}
+
+	providerSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: dnsRecord.GetProviderRef().Name, Namespace: dnsRecord.Namespace}, providerSecret); err != nil {
+		return false, err
+	}
+
+	registry, err := registryForSecret(r.Client, providerSecret, dnsProvider, dnsRecord.Status.OwnerID, r.RegistryConfig)
+	if err != nil {
+		return false, err
+	}
+
+	// a record type filter on the provider secret keeps the integration away
+	// from types other tooling writes into the same zone
+	managedTypes := managedDNSRecordTypes
+	if filter := provider.RecordTypeFilterFromSecret(providerSecret); filter != nil {
+		managedTypes = intersect(managedTypes, filter)
+	}
+
+	policy := externaldnsplan.Policies["sync"]
+
+	records, err := registry.Records(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	dnsRecord.Status.DomainOwners = domainOwners(records, dnsRecord)
+	r.setZoneCapacityStatus(dnsRecord, zone, len(records))
+	r.setProviderRecordCount(ctx, dnsRecord, dnsProvider)
+
+	// deletes only remove our own registry entries, a foreign owner never
+	// blocks cleanup
+	if !isDelete {
+		if err := r.checkCoOwnership(ctx, dnsRecord, dnsProvider, r.RegistryConfig.WithSecretOverrides(providerSecret)); err != nil {
+			return false, err
+		}
+	}
+
+	specEndpoints := dnsRecord.Spec.Endpoints
+	if dnsRecord.Spec.Delegate {
+		// the desired state of a delegated rootHost is the merged endpoints
+		// of all its member records, excluding this one when it is being
+		// deleted so its endpoints are removed from the merged set
+		specEndpoints, err = r.delegatedEndpoints(ctx, dnsRecord, isDelete)
+		if err != nil {
+			return false, err
+		}
+	} else if isDelete {
+		specEndpoints = nil
+	}
+	// canonicalize before planning so formatting differences against the
+	// canonicalized values the provider returns never read as drift
+	endpoints.Normalize(specEndpoints)
+	specEndpoints, err = registry.AdjustEndpoints(specEndpoints)
+	if err != nil {
+		return false, fmt.Errorf("adjusting specEndpoints: %w", err)
+	}
+	if !isDelete {
+		r.applyAdaptiveWeights(dnsRecord, specEndpoints)
+	}
+
+	plan := &externaldnsplan.Plan{
+		Policies: []externaldnsplan.Policy{policy},
+		Current:  records,
+		Desired:  specEndpoints,
+		//Note: overall record TTL will be the TTL of the record when the
+		//provider default is used on initial creation.
+		DomainFilter:   externaldnsendpoint.MatchAllDomainFilters{&rootDomainFilter},
+		ManagedRecords: managedTypes,
+		OwnerID:        registry.OwnerID(),
+	}
+
+	if r.damper != nil && !isDelete {
+		r.damper.observe(specEndpoints)
+	}
+
+	plan = plan.Calculate()
+	// track which targets this owner contributes and never withdraw other
+	// owners targets from a shared RRset on delete
+	dnsregistry.LabelOwnedTargets(plan.Changes)
+	changes := dnsregistry.AdjustDeletesForSharedTargets(plan.Changes)
+	if dropped := filterProtectedChanges(changes, zone.DNSName); dropped > 0 {
+		logger.Info("Dropped changes touching protected zone infrastructure records", "count", dropped)
+	}
+	if dropped := filterUnchangedUpdates(changes); dropped > 0 {
+		logger.V(1).Info("Dropped no-op updates for unchanged record sets", "count", dropped)
+	}
+	if r.damper != nil && !isDelete {
+		if suppressed := r.damper.dampen(changes); suppressed > 0 {
+			suppressedFlapsCounter.Add(float64(suppressed))
+			logger.V(1).Info("Suppressed flapping target withdrawals", "count", suppressed)
+		}
+	}
+	if !changes.HasChanges() {
+		return false, nil
+	}
+
+	// mutation point: everything above is reads and status bookkeeping,
+	// which continue during a change freeze
+	if r.frozen(ctx, dnsRecord) {
+		return false, errFrozen
+	}
+
+	if r.MaxDeletesPerApply > 0 && len(changes.Delete) > r.MaxDeletesPerApply && !dnsRecord.IsMassDeletionConfirmed() {
+		return false, fmt.Errorf("%w: plan would delete %d records, more than the allowed %d; annotate the record with %s=true to confirm",
+			errDeleteThresholdExceeded, len(changes.Delete), r.MaxDeletesPerApply, v1alpha1.ConfirmMassDeletionAnnotation)
+	}
+
+	// a retry computing the identical batch while the previous submission
+	// is still settling means the provider read is stale, not that the
+	// change was lost; wait instead of double-applying
+	token := changeToken(dnsRecord.Generation, changes)
+	if inFlight := dnsRecord.Status.InFlightChange; inFlight != nil &&
+		inFlight.Token == token && time.Since(inFlight.Time.Time) < inFlightChangeTTL {
+		return false, errChangeInFlight
+	}
+	// persist the token before submitting so a crash between submit and
+	// status update is still detected on the next reconcile
+	dnsRecord.Status.InFlightChange = &v1alpha1.InFlightChange{Token: token, Time: metav1.Now()}
+	if err := r.Status().Update(ctx, dnsRecord); err != nil {
+		return false, err
+	}
+
+	logger.Info("Applying changes", "zone", zone.DNSName)
+	var applyErr error
+	batches := provider.SplitChanges(changes, provider.BatchLimitsOf(dnsProvider))
+	if len(batches) > 1 {
+		logger.Info("Splitting changes into batches for the provider limits", "batches", len(batches))
+	}
+	for _, batch := range batches {
+		if applyErr = registry.ApplyChanges(ctx, batch); applyErr != nil {
+			// the summary below names the whole submission, batches already
+			// applied have succeeded and will not be replayed
+			break
+		}
+	}
+	if r.ProviderHealth != nil {
+		r.ProviderHealth.RecordApply(dnsRecord.Namespace+"/"+dnsRecord.Spec.ProviderRef.Name, applyErr)
+	}
+	if err := applyErr; err != nil {
+		if operationID, pending := provider.IsOperationPending(err); pending {
+			// the provider accepted the batch as a long-running operation
+			dnsRecord.Status.ProviderOperationID = operationID
+			return true, errApplyInProgress
+		}
+		// record the submitted batch so a partial apply can be reconciled
+		// manually; the provider does not report per change outcomes
+		dnsRecord.Status.LastFailedApply = failedApplySummary(changes, provider.SanitizeError(err))
+		return true, err
+	}
+	dnsRecord.Status.LastFailedApply = nil
+	dnsRecord.Status.InFlightChange = nil
+	recordAppliedChange(dnsRecord, changes)
+	r.observePropagation(ctx, dnsRecord, zone, changes)
+	return true, nil
+}
+
+// observePropagation registers the applied additions and updates with the
+// propagation monitor. Deletions are skipped, absence has no observable
+// moment to time against caches.
+func (r *DNSRecordReconciler) observePropagation(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, zone *provider.DNSZone, changes *externaldnsplan.Changes) {
+	if r.Propagation == nil {
+		return
+	}
+	applied := time.Now()
+	for _, ep := range append(changes.Create, changes.UpdateNew...) {
+		// registry TXT records are bookkeeping, their propagation is not
+		// interesting
+		if ep.RecordType == externaldnsendpoint.RecordTypeTXT {
+			continue
+		}
+		r.Propagation.Observe(ctx, propagation.Check{
+			Provider:   dnsRecord.Namespace + "/" + dnsRecord.Spec.ProviderRef.Name,
+			Zone:       zone.DNSName,
+			DNSName:    ep.DNSName,
+			RecordType: ep.RecordType,
+			Targets:    ep.Targets,
+			Applied:    applied,
+		})
+	}
+}
+
+// inFlightChangeTTL is how long a submitted change batch suppresses identical
+// re-applies, covering asynchronous provider propagation (Route53 change
+// status, GCP operation waits).
+const inFlightChangeTTL = time.Minute
+
+// changeToken derives a stable identity for a change batch from the record
+// generation and the batch contents.
+func changeToken(generation int64, changes *externaldnsplan.Changes) string {
+	var entries []string
+	describe := func(action string, endpoints []*externaldnsendpoint.Endpoint) {
+		for _, endpoint := range endpoints {
+			entries = append(entries, fmt.Sprintf("%s/%s/%s/%s/%s",
+				action, endpoint.RecordType, endpoint.DNSName, endpoint.SetIdentifier, strings.Join(endpoint.Targets, ",")))
+		}
+	}
+	describe("create", changes.Create)
+	describe("update", changes.UpdateNew)
+	describe("delete", changes.Delete)
+	sort.Strings(entries)
+	return hash.ToBase36Hash(fmt.Sprintf("%d|%s", generation, strings.Join(entries, "|")))
+}
+
+// intersect returns the elements of a that are also in b, preserving order.
+func intersect(a, b []string) []string {
+	var result []string
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// maxFailedApplyEntries caps each change list of a FailedApplySummary and
+// an AppliedChange history entry.
+const maxFailedApplyEntries = 10
+
+// maxAppliedChangeHistory is how many successful applies are kept in the
+// status history.
+const maxAppliedChangeHistory = 5
+
+// describeChanges renders a change endpoint list as bounded
+// "<type> <dnsName>" status entries.
+func describeChanges(endpoints []*externaldnsendpoint.Endpoint) []string {
+	var entries []string
+	for i, endpoint := range endpoints {
+		if i == maxFailedApplyEntries {
+			entries = append(entries, fmt.Sprintf("... %d more", len(endpoints)-maxFailedApplyEntries))
+			break
+		}
+		entry := fmt.Sprintf("%s %s", endpoint.RecordType, endpoint.DNSName)
+		if endpoint.SetIdentifier != "" {
+			entry += " " + endpoint.SetIdentifier
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// failedApplySummary describes the change batch of a failed apply in a form
+// small enough for status.
+func failedApplySummary(changes *externaldnsplan.Changes, applyErr error) *v1alpha1.FailedApplySummary {
+	return &v1alpha1.FailedApplySummary{
+		Time:    metav1.Now(),
+		Error:   applyErr.Error(),
+		Creates: describeChanges(changes.Create),
+		Updates: describeChanges(changes.UpdateNew),
+		Deletes: describeChanges(changes.Delete),
+	}
+}
+
+// recordAppliedChange prepends a successful apply to the bounded history in
+// status, answering "what did DNS look like at 14:32" during incident
+// review and feeding annotation triggered rollbacks.
+func recordAppliedChange(dnsRecord *v1alpha1.DNSRecord, changes *externaldnsplan.Changes) {
+	entry := v1alpha1.AppliedChange{
+		Time:       metav1.Now(),
+		Generation: dnsRecord.Generation,
+		Endpoints:  dnsRecord.Spec.Endpoints,
+		Creates:    describeChanges(changes.Create),
+		Updates:    describeChanges(changes.UpdateNew),
+		Deletes:    describeChanges(changes.Delete),
+	}
+	history := append([]v1alpha1.AppliedChange{entry}, dnsRecord.Status.AppliedChanges...)
+	if len(history) > maxAppliedChangeHistory {
+		history = history[:maxAppliedChangeHistory]
+	}
+	dnsRecord.Status.AppliedChanges = history
+}
+
+// ensureDNSSEC turns on zone signing when the record requests it and the
+// provider supports managed DNSSEC, surfacing the signing health and the DS
+// records for the parent zone in the record status. Signing problems degrade
+// the DNSSEC condition without failing the apply.
+func (r *DNSRecordReconciler) ensureDNSSEC(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, dnsProvider provider.Provider, zone *provider.DNSZone) {
+	if !dnsRecord.IsDNSSECRequested() {
+		meta.RemoveStatusCondition(&dnsRecord.Status.Conditions, v1alpha1.ConditionTypeDNSSEC)
+		dnsRecord.Status.DSRecords = nil
+		return
+	}
+
+	manager, ok := provider.As[provider.DNSSECManager](dnsProvider)
+	if !ok {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeDNSSEC, metav1.ConditionFalse, v1alpha1.ConditionReasonSigningNotSupported,
+			"The DNS provider does not support managed DNSSEC signing")
+		return
+	}
+
+	signing, err := manager.EnsureDNSSEC(ctx, zone)
+	if err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeDNSSEC, metav1.ConditionFalse, v1alpha1.ConditionReasonSigningUnhealthy,
+			fmt.Sprintf("Failed to ensure zone signing: %v", provider.SanitizeError(err)))
+		return
+	}
+
+	dnsRecord.Status.DSRecords = signing.DSRecords
+	if !signing.Enabled || !signing.Healthy {
+		message := signing.Message
+		if message == "" {
+			message = "The provider reports an unhealthy signing state"
+		}
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeDNSSEC, metav1.ConditionFalse, v1alpha1.ConditionReasonSigningUnhealthy, message)
+		return
+	}
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeDNSSEC, metav1.ConditionTrue, v1alpha1.ConditionReasonSigningHealthy,
+		"The provider zone is signed; place the DS records in the parent zone")
+}
+
+// ensureApexRedirect creates the provider-native redirect construct when the
+// record requests one, surfacing the outcome in the ApexRedirect condition.
+// Redirect problems degrade the condition without failing the apply.
+func (r *DNSRecordReconciler) ensureApexRedirect(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, dnsProvider provider.Provider, zone *provider.DNSZone) {
+	redirect := dnsRecord.Spec.ApexRedirect
+	if redirect == nil {
+		meta.RemoveStatusCondition(&dnsRecord.Status.Conditions, v1alpha1.ConditionTypeApexRedirect)
+		return
+	}
+
+	redirector, ok := provider.As[provider.ApexRedirector](dnsProvider)
+	if !ok {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeApexRedirect, metav1.ConditionFalse, v1alpha1.ConditionReasonRedirectNotSupported,
+			"The DNS provider does not support apex redirects")
+		return
+	}
+	if err := redirector.EnsureApexRedirect(ctx, zone, dnsRecord.GetRootHost(), redirect.Target, redirect.Permanent); err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeApexRedirect, metav1.ConditionFalse, v1alpha1.ConditionReasonRedirectError,
+			fmt.Sprintf("Failed to ensure the apex redirect: %v", provider.SanitizeError(err)))
+		return
+	}
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeApexRedirect, metav1.ConditionTrue, v1alpha1.ConditionReasonRedirectEnsured,
+		fmt.Sprintf("Requests for %s redirect to %s", dnsRecord.GetRootHost(), redirect.Target))
+}
+
+// ensureProviderTags propagates the configured record labels and annotations
+// as tags onto the provider resources backing the zone. Tagging failures are
+// logged but never fail the apply.
+func (r *DNSRecordReconciler) ensureProviderTags(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, dnsProvider provider.Provider, zone *provider.DNSZone) {
+	if len(r.TagLabels) == 0 {
+		return
+	}
+	tagger, ok := provider.As[provider.ResourceTagger](dnsProvider)
+	if !ok {
+		return
+	}
+
+	tags := map[string]string{}
+	for _, key := range r.TagLabels {
+		if value, ok := dnsRecord.Labels[key]; ok {
+			tags[key] = value
+		}
+		if value, ok := dnsRecord.Annotations[key]; ok {
+			tags[key] = value
+		}
+	}
+	if len(tags) == 0 {
+		return
+	}
+	if err := tagger.EnsureTags(ctx, zone, tags); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to propagate tags to the provider", "zone", zone.DNSName)
+	}
+}
+
+// trackProviderHealth feeds the readiness probe with the outcome of a
+// provider interaction for the records provider secret.
+func (r *DNSRecordReconciler) trackProviderHealth(dnsRecord *v1alpha1.DNSRecord, err error) {
+	if r.ProviderHealth == nil {
+		return
+	}
+	r.ProviderHealth.RecordZoneList(dnsRecord.Namespace+"/"+dnsRecord.Spec.ProviderRef.Name, err)
+}
+
+// validateProviderSpecific checks the provider specific properties of the
+// record endpoints against the keys its provider supports, so typos surface
+// as a validation condition listing the supported keys instead of being
+// silently dropped by the provider.
+func (r *DNSRecordReconciler) validateProviderSpecific(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) error {
+	providerSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: dnsRecord.Namespace, Name: dnsRecord.Spec.ProviderRef.Name}, providerSecret); err != nil {
+		// the missing secret will surface as a provider error on publish
+		return client.IgnoreNotFound(err)
+	}
+	providerName, err := provider.NameForProviderSecret(providerSecret)
+	if err != nil {
+		return err
+	}
+	return provider.ValidateProviderSpecific(providerName, dnsRecord.Spec.Endpoints)
+}
+
+// setZoneCapacityStatus records how full the provider zone is in a
+// ZoneCapacity condition and the zone records gauge, so approaching provider
+// record limits is visible before applies start failing.
+func (r *DNSRecordReconciler) setZoneCapacityStatus(dnsRecord *v1alpha1.DNSRecord, zone *provider.DNSZone, recordCount int) {
+	limit := r.ZoneRecordLimit
+	if limit <= 0 {
+		limit = DefaultZoneRecordLimit
+	}
+	zoneRecordsGauge.WithLabelValues(zone.DNSName).Set(float64(recordCount))
+
+	if recordCount >= (limit*zoneCapacityWarningPercent)/100 {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeZoneCapacity, metav1.ConditionFalse, v1alpha1.ConditionReasonApproachingRecordLimit,
+			fmt.Sprintf("Zone %q holds %d of the %d record limit", zone.DNSName, recordCount, limit))
+		return
+	}
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeZoneCapacity, metav1.ConditionTrue, v1alpha1.ConditionReasonWithinRecordLimit,
+		fmt.Sprintf("Zone %q holds %d of the %d record limit", zone.DNSName, recordCount, limit))
+}
+
+// setProviderRecordCount publishes how many provider records, including the
+// registry TXT records, the record materializes into, in status and in a per
+// record metric. Counting failures are ignored, the next reconcile repeats
+// the count.
+func (r *DNSRecordReconciler) setProviderRecordCount(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, dnsProvider provider.Provider) {
+	rawRecords, err := dnsProvider.Records(ctx)
+	if err != nil {
+		return
+	}
+	rootHost := dnsRecord.GetRootHost()
+	ownerID := dnsRecord.Status.OwnerID
+
+	count := 0
+	for _, record := range rawRecords {
+		underRoot := record.DNSName == rootHost || strings.HasSuffix(record.DNSName, "."+rootHost)
+		if underRoot {
+			count++
+			continue
+		}
+		// registry TXT records carry the owner id in their content but may
+		// live under prefixed names outside the rootHost subtree
+		if record.RecordType == externaldnsendpoint.RecordTypeTXT && ownerID != "" &&
+			strings.Contains(strings.Join(record.Targets, ""), ownerID) {
+			count++
+		}
+	}
+	dnsRecord.Status.ProviderRecordCount = count
+	recordProviderRecordsGauge.WithLabelValues(dnsRecord.Namespace, dnsRecord.Name).Set(float64(count))
+}
+
+// adoptEndpoints imports the existing records under the records rootHost
+// from the provider zone into its spec endpoints, returning true if any were
+// imported. Ownership of the imported endpoints is claimed by the registry on
+// the publish that follows.
+func (r *DNSRecordReconciler) adoptEndpoints(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) (bool, error) {
+	rootHost := dnsRecord.GetRootHost()
+
+	dnsProvider, err := r.ProviderFactory.ProviderFor(ctx, dnsRecord, provider.Config{
+		DomainFilter: externaldnsendpoint.NewDomainFilter([]string{rootHost}),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// read raw zone records rather than going through the registry, records
+	// being adopted are by definition not owned by us yet
+	records, err := dnsProvider.Records(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var imported []*externaldnsendpoint.Endpoint
+	for _, record := range records {
+		if record.DNSName != rootHost && !strings.HasSuffix(record.DNSName, "."+rootHost) {
+			continue
+		}
+		if !slices.Contains(managedDNSRecordTypes, record.RecordType) {
+			continue
+		}
+		record.Labels = nil
+		imported = append(imported, record)
+	}
+	if len(imported) == 0 {
+		return false, nil
+	}
+	dnsRecord.Spec.Endpoints = imported
+	return true, nil
+}
+
+// delegatedEndpoints merges the endpoints of all delegating records sharing
+// the given records rootHost. Targets of endpoints with the same dnsName,
+// setIdentifier and record type are merged so each member cluster
+// contributes its targets to the shared record set.
+func (r *DNSRecordReconciler) delegatedEndpoints(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, excludeSelf bool) ([]*externaldnsendpoint.Endpoint, error) {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, recordList); err != nil {
+		return nil, err
+	}
+
+	var members []*v1alpha1.DNSRecord
+	for i := range recordList.Items {
+		member := &recordList.Items[i]
+		if !member.Spec.Delegate || member.GetRootHost() != dnsRecord.GetRootHost() {
+			continue
+		}
+		if excludeSelf && member.Namespace == dnsRecord.Namespace && member.Name == dnsRecord.Name {
+			continue
+		}
+		if member.DeletionTimestamp != nil && !member.DeletionTimestamp.IsZero() {
+			continue
+		}
+		members = append(members, member)
+	}
+	return mergeEndpoints(members), nil
+}
+
+// mergeEndpoints merges the spec endpoints of the given records, unioning
+// the targets of endpoints sharing dnsName, setIdentifier and record type.
+func mergeEndpoints(records []*v1alpha1.DNSRecord) []*externaldnsendpoint.Endpoint {
+	merged := map[string]*externaldnsendpoint.Endpoint{}
+	var keys []string
+	for _, record := range records {
+		for _, ep := range record.Spec.Endpoints {
+			key := ep.DNSName + "#" + ep.SetIdentifier + "#" + ep.RecordType
+			existing, ok := merged[key]
+			if !ok {
+				merged[key] = ep.DeepCopy()
+				keys = append(keys, key)
+				continue
+			}
+			for _, target := range ep.Targets {
+				if !slices.Contains(existing.Targets, target) {
+					existing.Targets = append(existing.Targets, target)
+				}
+			}
+		}
+	}
+	endpoints := make([]*externaldnsendpoint.Endpoint, 0, len(merged))
+	sort.Strings(keys)
+	for _, key := range keys {
+		sort.Strings(merged[key].Targets)
+		endpoints = append(endpoints, merged[key])
+	}
+	return endpoints
+}
+
+// domainOwners returns the sorted owner ids of all records, from the current
+// registry state, that share the root domain of the given record.
+func domainOwners(records []*externaldnsendpoint.Endpoint, dnsRecord *v1alpha1.DNSRecord) []string {
+	ownersSet := map[string]struct{}{dnsRecord.Status.OwnerID: {}}
+	for _, record := range records {
+		if owner, ok := record.Labels[externaldnsendpoint.OwnerLabelKey]; ok && owner != "" {
+			ownersSet[owner] = struct{}{}
+		}
+	}
+	owners := make([]string, 0, len(ownersSet))
+	for owner := range ownersSet {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+// summaryFor returns a single line summary of the records current state for
+// the status.summary column.
+func summaryFor(dnsRecord *v1alpha1.DNSRecord) string {
+	readyCond := meta.FindStatusCondition(dnsRecord.Status.Conditions, v1alpha1.ConditionTypeReady)
+	if readyCond == nil {
+		return ""
+	}
+	if readyCond.Status == metav1.ConditionTrue {
+		return fmt.Sprintf("%d endpoints published to zone %q", dnsRecord.Status.EndpointCount, dnsRecord.Status.ZoneDomainName)
+	}
+	return fmt.Sprintf("%s: %s", readyCond.Reason, readyCond.Message)
+}
+
+// updateStatus persists the records status if it has changed from the
+// previously observed state.
+func (r *DNSRecordReconciler) updateStatus(ctx context.Context, previous, current *v1alpha1.DNSRecord) error {
+	r.updateStalled(previous, current)
+	updateReconciling(current)
+	current.Status.Summary = summaryFor(current)
+	if !equality.Semantic.DeepEqual(previous.Status, current.Status) {
+		if err := r.Status().Update(ctx, current); err != nil {
+			return err
+		}
+		r.notifyReadyTransition(ctx, previous, current)
+	}
+	return nil
+}
+
+// notifyReadyTransition delivers a notification when the Ready condition
+// transitioned to true or to a failure. Delivery is best effort and never
+// fails the reconcile.
+func (r *DNSRecordReconciler) notifyReadyTransition(ctx context.Context, previous, current *v1alpha1.DNSRecord) {
+	if r.Notifier == nil {
+		return
+	}
+	ready := meta.FindStatusCondition(current.Status.Conditions, v1alpha1.ConditionTypeReady)
+	if ready == nil {
+		return
+	}
+	wasReady := meta.FindStatusCondition(previous.Status.Conditions, v1alpha1.ConditionTypeReady)
+	if wasReady != nil && wasReady.Status == ready.Status && wasReady.Reason == ready.Reason {
+		return
+	}
+
+	eventType := notify.EventFailed
+	if ready.Status == metav1.ConditionTrue {
+		eventType = notify.EventReady
+	}
+	r.notifyEvent(ctx, current, eventType, ready.Reason, ready.Message)
+
+	stalled := meta.FindStatusCondition(current.Status.Conditions, v1alpha1.ConditionTypeStalled)
+	wasStalled := meta.FindStatusCondition(previous.Status.Conditions, v1alpha1.ConditionTypeStalled)
+	if stalled != nil && wasStalled == nil {
+		r.notifyEvent(ctx, current, notify.EventUnhealthy, stalled.Reason, stalled.Message)
+	} else if stalled == nil && wasStalled != nil {
+		r.notifyEvent(ctx, current, notify.EventHealthy, "", "DNSRecord recovered")
+	}
+}
+
+// notifyEvent delivers a state transition event for the record to the
+// configured sink, if any. Delivery is best effort and never fails the
+// reconcile.
+func (r *DNSRecordReconciler) notifyEvent(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, eventType, reason, message string) {
+	if r.Notifier == nil {
+		return
+	}
+	event := notify.Event{
+		Type:     eventType,
+		Record:   dnsRecord.Namespace + "/" + dnsRecord.Name,
+		RootHost: dnsRecord.Spec.RootHost,
+		Reason:   reason,
+		Message:  message,
+	}
+	if err := r.Notifier.Send(ctx, event); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to deliver notification", "type", eventType)
+	}
+}
+
+// updateReconciling maintains the kstatus Reconciling condition: set while
+// the record has not reached the desired state and is not stalled, so GitOps
+// tooling reports it as progressing instead of healthy.
+func updateReconciling(current *v1alpha1.DNSRecord) {
+	ready := meta.FindStatusCondition(current.Status.Conditions, v1alpha1.ConditionTypeReady)
+	stalled := meta.IsStatusConditionTrue(current.Status.Conditions, v1alpha1.ConditionTypeStalled)
+	if ready == nil || ready.Status == metav1.ConditionTrue || stalled {
+		meta.RemoveStatusCondition(&current.Status.Conditions, v1alpha1.ConditionTypeReconciling)
+		return
+	}
+	setDNSRecordCondition(current, v1alpha1.ConditionTypeReconciling, metav1.ConditionTrue, ready.Reason, ready.Message)
+}
+
+// updateStalled maintains the Stalled condition and its metric: a record not
+// Ready with the same error past the threshold is stalled until the Ready
+// condition transitions again.
+func (r *DNSRecordReconciler) updateStalled(previous, current *v1alpha1.DNSRecord) {
+	threshold := r.StalledThreshold
+	if threshold <= 0 {
+		threshold = DefaultStalledThreshold
+	}
+
+	ready := meta.FindStatusCondition(current.Status.Conditions, v1alpha1.ConditionTypeReady)
+	// a freeze window is deliberate, not a record that stopped progressing
+	stalled := ready != nil && ready.Status == metav1.ConditionFalse && ready.Reason != v1alpha1.ConditionReasonFrozen &&
+		time.Since(ready.LastTransitionTime.Time) >= threshold
+	if stalled {
+		setDNSRecordCondition(current, v1alpha1.ConditionTypeStalled, metav1.ConditionTrue, ready.Reason,
+			fmt.Sprintf("DNSRecord has been not ready for more than %s: %s", threshold, ready.Message))
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, v1alpha1.ConditionTypeStalled)
+	}
+
+	wasStalled := meta.FindStatusCondition(previous.Status.Conditions, v1alpha1.ConditionTypeStalled)
+	if stalled && wasStalled == nil {
+		stalledRecordsGauge.WithLabelValues(ready.Reason).Inc()
+	}
+	if !stalled && wasStalled != nil {
+		stalledRecordsGauge.WithLabelValues(wasStalled.Reason).Dec()
+	}
+}
+
+// setDNSRecordCondition adds or updates a given condition in the DNSRecord status.
+func setDNSRecordCondition(dnsRecord *v1alpha1.DNSRecord, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	cond := metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: dnsRecord.Generation,
+	}
+	meta.SetStatusCondition(&dnsRecord.Status.Conditions, cond)
+}
+
+// ProviderRefNameIndex indexes DNSRecords by the name of the provider
+// secret they reference, allowing secret events to be mapped to exactly the
+// dependent records.
+const ProviderRefNameIndex = "spec.providerRef.name"
+
+// EndpointsConfigMapIndex indexes DNSRecords by the name of the ConfigMap
+// their endpointsFrom references, allowing ConfigMap events to be mapped to
+// exactly the dependent records.
+const EndpointsConfigMapIndex = "spec.endpointsFrom.configMapRef.name"
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DNSRecordReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.FlapDamping > 0 {
+		r.damper = newFlapDamper(r.FlapDamping)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DNSRecord{}, ProviderRefNameIndex, func(obj client.Object) []string {
+		return []string{obj.(*v1alpha1.DNSRecord).Spec.ProviderRef.Name}
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DNSRecord{}, EndpointsConfigMapIndex, func(obj client.Object) []string {
+		if from := obj.(*v1alpha1.DNSRecord).Spec.EndpointsFrom; from != nil {
+			return []string{from.ConfigMapRef.Name}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DNSRecord{}, TargetServiceNameIndex, func(obj client.Object) []string {
+		if from := obj.(*v1alpha1.DNSRecord).Spec.TargetsFrom; from != nil && from.ServiceRef != nil {
+			return []string{from.ServiceRef.Name}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DNSRecord{}, DependsOnIndex, func(obj client.Object) []string {
+		return obj.(*v1alpha1.DNSRecord).Spec.DependsOn
+	}); err != nil {
+		return err
+	}
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DNSRecord{}).
+		Watches(&v1alpha1.DNSRecord{}, handler.EnqueueRequestsFromMapFunc(r.recordsForDependency)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.recordsForProviderSecret)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.recordsForEndpointsConfigMap)).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(r.recordsForTargetService)).
+		Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(r.recordsForEndpointSlice)).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.recordsForNode))
+	if r.RegistryWatch != nil {
+		controllerBuilder = controllerBuilder.WatchesRawSource(&source.Channel{Source: r.RegistryWatch.Events()}, &handler.EnqueueRequestForObject{})
+	}
+	if r.ChangeEvents != nil {
+		controllerBuilder = controllerBuilder.WatchesRawSource(&source.Channel{Source: r.ChangeEvents.Events()}, &handler.EnqueueRequestForObject{})
+	}
+	if r.ConcurrentReconciles > 0 {
+		controllerBuilder = controllerBuilder.WithOptions(ctrlcontroller.Options{MaxConcurrentReconciles: r.ConcurrentReconciles})
+	}
+	if r.Shard.Enabled() {
+		hostname, _ := os.Hostname()
+		r.zoneLock = newZoneLock(mgr.GetClient(), fmt.Sprintf("%s-%d", hostname, r.Shard.Index))
+		controllerBuilder = controllerBuilder.WithEventFilter(r.Shard.Predicate())
+	}
+	return controllerBuilder.Complete(r)
+}
+
+// recordsForEndpointsConfigMap maps a ConfigMap event to the DNSRecords in
+// its namespace referencing it through endpointsFrom, so edited endpoints
+// are published without waiting for the next periodic re-validation.
+func (r *DNSRecordReconciler) recordsForEndpointsConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, recordList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{EndpointsConfigMapIndex: obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list DNSRecords for endpoints ConfigMap", "configmap", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(recordList.Items))
+	for i := range recordList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&recordList.Items[i])})
+	}
+	return requests
+}
+
+// recordsForProviderSecret maps a provider secret event to the DNSRecords in
+// its namespace referencing it, so credential or filter changes trigger
+// immediate reconciliation of exactly the dependent records.
+func (r *DNSRecordReconciler) recordsForProviderSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok || !provider.IsProviderSecret(secret) {
+		return nil
+	}
+
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, recordList,
+		client.InNamespace(secret.Namespace),
+		client.MatchingFields{ProviderRefNameIndex: secret.Name}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list DNSRecords for provider secret", "secret", secret.Namespace+"/"+secret.Name)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(recordList.Items))
+	for i := range recordList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&recordList.Items[i])})
+	}
+	return requests
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+func TestMergeEndpoints(t *testing.T) {
+	record := func(endpoints ...*externaldnsendpoint.Endpoint) *v1alpha1.DNSRecord {
+		return &v1alpha1.DNSRecord{Spec: v1alpha1.DNSRecordSpec{Endpoints: endpoints}}
+	}
+
+	testCases := []struct {
+		name    string
+		records []*v1alpha1.DNSRecord
+		want    []*externaldnsendpoint.Endpoint
+	}{
+		{
+			name:    "no records",
+			records: nil,
+			want:    []*externaldnsendpoint.Endpoint{},
+		},
+		{
+			name: "targets of matching endpoints are unioned",
+			records: []*v1alpha1.DNSRecord{
+				record(externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1")),
+				record(externaldnsendpoint.NewEndpoint("app.example.com", "A", "2.2.2.2", "1.1.1.1")),
+			},
+			want: []*externaldnsendpoint.Endpoint{
+				externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1", "2.2.2.2"),
+			},
+		},
+		{
+			name: "distinct set identifiers are kept apart",
+			records: []*v1alpha1.DNSRecord{
+				record(externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1").WithSetIdentifier("eu")),
+				record(externaldnsendpoint.NewEndpoint("app.example.com", "A", "2.2.2.2").WithSetIdentifier("us")),
+			},
+			want: []*externaldnsendpoint.Endpoint{
+				externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1").WithSetIdentifier("eu"),
+				externaldnsendpoint.NewEndpoint("app.example.com", "A", "2.2.2.2").WithSetIdentifier("us"),
+			},
+		},
+		{
+			name: "distinct record types are kept apart",
+			records: []*v1alpha1.DNSRecord{
+				record(externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1")),
+				record(externaldnsendpoint.NewEndpoint("app.example.com", "TXT", "owner")),
+			},
+			want: []*externaldnsendpoint.Endpoint{
+				externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1"),
+				externaldnsendpoint.NewEndpoint("app.example.com", "TXT", "owner"),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := mergeEndpoints(testCase.records)
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("mergeEndpoints() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidationBackoff(t *testing.T) {
+	base := 5 * time.Second
+	tests := []struct {
+		attempts int64
+		want     time.Duration
+	}{
+		{attempts: 1, want: base},
+		{attempts: 2, want: 2 * base},
+		{attempts: 4, want: 8 * base},
+		// capped regardless of further attempts
+		{attempts: 20, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		got := validationBackoff(tt.attempts)
+		// jitter adds up to 20% on top of the base backoff
+		if got < tt.want || got > tt.want+tt.want/5 {
+			t.Errorf("validationBackoff(%d) = %v, want within [%v, %v]", tt.attempts, got, tt.want, tt.want+tt.want/5)
+		}
+	}
+}
+
+func TestShardConfigOwns(t *testing.T) {
+	record := func(namespace, name string) *v1alpha1.DNSRecord {
+		r := &v1alpha1.DNSRecord{}
+		r.Namespace = namespace
+		r.Name = name
+		return r
+	}
+
+	disabled := ShardConfig{}
+	if !disabled.Owns(record("ns", "a")) {
+		t.Errorf("expected a disabled shard config to own everything")
+	}
+
+	shards := []ShardConfig{{Index: 0, Count: 3}, {Index: 1, Count: 3}, {Index: 2, Count: 3}}
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for _, name := range names {
+		owners := 0
+		for _, shard := range shards {
+			if shard.Owns(record("ns", name)) {
+				owners++
+			}
+		}
+		if owners != 1 {
+			t.Errorf("record %q owned by %d shards, want exactly 1", name, owners)
+		}
+	}
+}
+
+func TestUpdateReconciling(t *testing.T) {
+	withReady := func(status metav1.ConditionStatus, reason string) *v1alpha1.DNSRecord {
+		dnsRecord := &v1alpha1.DNSRecord{}
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReady, status, reason, "test")
+		return dnsRecord
+	}
+
+	notReady := withReady(metav1.ConditionFalse, v1alpha1.ConditionReasonAwaitingValidation)
+	updateReconciling(notReady)
+	reconciling := meta.FindStatusCondition(notReady.Status.Conditions, v1alpha1.ConditionTypeReconciling)
+	if reconciling == nil || reconciling.Status != metav1.ConditionTrue {
+		t.Fatalf("expected Reconciling true on a not ready record, got %+v", reconciling)
+	}
+	if reconciling.Reason != v1alpha1.ConditionReasonAwaitingValidation {
+		t.Errorf("expected the Ready reason to be mirrored, got %q", reconciling.Reason)
+	}
+
+	ready := withReady(metav1.ConditionTrue, v1alpha1.ConditionReasonProviderSuccess)
+	setDNSRecordCondition(ready, v1alpha1.ConditionTypeReconciling, metav1.ConditionTrue, v1alpha1.ConditionReasonAwaitingValidation, "test")
+	updateReconciling(ready)
+	if meta.FindStatusCondition(ready.Status.Conditions, v1alpha1.ConditionTypeReconciling) != nil {
+		t.Error("expected Reconciling to be removed once the record is ready")
+	}
+
+	stalled := withReady(metav1.ConditionFalse, v1alpha1.ConditionReasonProviderError)
+	setDNSRecordCondition(stalled, v1alpha1.ConditionTypeStalled, metav1.ConditionTrue, v1alpha1.ConditionReasonProviderError, "test")
+	updateReconciling(stalled)
+	if meta.FindStatusCondition(stalled.Status.Conditions, v1alpha1.ConditionTypeReconciling) != nil {
+		t.Error("expected a stalled record not to be marked Reconciling")
+	}
+}
@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/common/hash"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/builder"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/identity"
+)
+
+const (
+	// RecordSetLabel is set on DNSRecords generated for a DNSRecordSet and
+	// carries the set name, used to find stale records when hosts or
+	// listeners are removed.
+	RecordSetLabel = "kuadrant.io/dnsrecordset"
+)
+
+// DNSRecordSetReconciler generates one DNSRecord per host or matching
+// Gateway listener of a DNSRecordSet, so bulk consumers maintain one
+// resource instead of thousands of nearly identical records.
+type DNSRecordSetReconciler struct {
+	client.Client
+	ClusterIdentity identity.ClusterIdentity
+	// WatchGateways enables the Gateway watch driving gatewaySelector
+	// generation, off unless the Gateway API is installed.
+	WatchGateways bool
+}
+
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsrecordsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsrecordsets/status,verbs=get;update;patch
+
+func (r *DNSRecordSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("Reconciling DNSRecordSet")
+
+	previous := &v1alpha1.DNSRecordSet{}
+	if err := r.Get(ctx, req.NamespacedName, previous); err != nil {
+		if apierrors.IsNotFound(err) {
+			// owned records are removed by garbage collection
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	recordSet := previous.DeepCopy()
+
+	if recordSet.DeletionTimestamp != nil && !recordSet.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	desired, err := r.desiredRecords(ctx, recordSet)
+	if err != nil {
+		setDNSRecordSetCondition(recordSet, metav1.ConditionFalse, v1alpha1.ConditionReasonValidationError, err.Error())
+		if statusErr := r.updateStatus(ctx, previous, recordSet); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, nil
+	}
+
+	for name, record := range desired {
+		current := &v1alpha1.DNSRecord{}
+		current.Namespace = record.Namespace
+		current.Name = name
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, current, func() error {
+			if current.Labels == nil {
+				current.Labels = map[string]string{}
+			}
+			current.Labels[RecordSetLabel] = recordSet.Name
+			current.Spec.RootHost = record.Spec.RootHost
+			current.Spec.ProviderRef = record.Spec.ProviderRef
+			current.Spec.Endpoints = record.Spec.Endpoints
+			return controllerutil.SetControllerReference(recordSet, current, r.Scheme())
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if op != controllerutil.OperationResultNone {
+			logger.Info("Reconciled DNSRecord for DNSRecordSet", "dnsrecord", name, "operation", op)
+		}
+	}
+
+	// remove records of hosts or listeners that no longer exist
+	existing := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, existing, client.InNamespace(recordSet.Namespace), client.MatchingLabels{RecordSetLabel: recordSet.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	for i := range existing.Items {
+		record := &existing.Items[i]
+		if _, ok := desired[record.Name]; ok {
+			continue
+		}
+		logger.Info("Deleting DNSRecord removed from DNSRecordSet", "dnsrecord", record.Name)
+		if err := r.Delete(ctx, record); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	}
+
+	recordSet.Status.Records = len(desired)
+	setDNSRecordSetCondition(recordSet, metav1.ConditionTrue, v1alpha1.ConditionReasonRecordsEnsured,
+		fmt.Sprintf("%d DNSRecords generated", len(desired)))
+	if err := r.updateStatus(ctx, previous, recordSet); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// desiredRecords builds the records the set should generate, keyed by name.
+func (r *DNSRecordSetReconciler) desiredRecords(ctx context.Context, recordSet *v1alpha1.DNSRecordSet) (map[string]*v1alpha1.DNSRecord, error) {
+	desired := map[string]*v1alpha1.DNSRecord{}
+
+	if len(recordSet.Spec.Hosts) > 0 && len(recordSet.Spec.Targets) == 0 {
+		return nil, fmt.Errorf("targets must be set when hosts are listed")
+	}
+	for _, host := range recordSet.Spec.Hosts {
+		target := recordSetTarget{name: recordSet.Name, shortCode: r.ClusterIdentity.ClusterID, targets: recordSet.Spec.Targets}
+		record, err := r.buildRecord(recordSet, recordSetRecordName(recordSet.Name, host), host, target)
+		if err != nil {
+			return nil, err
+		}
+		desired[record.Name] = record
+	}
+
+	if recordSet.Spec.GatewaySelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(recordSet.Spec.GatewaySelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gatewaySelector: %w", err)
+		}
+		gateways := &gatewayapiv1.GatewayList{}
+		if err := r.List(ctx, gateways, client.InNamespace(recordSet.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			if meta.IsNoMatchError(err) {
+				return nil, fmt.Errorf("gatewaySelector requires the Gateway API to be installed")
+			}
+			return nil, err
+		}
+		for i := range gateways.Items {
+			gateway := &gateways.Items[i]
+			target := gatewayTarget{gateway: gateway, shortCode: r.ClusterIdentity.ClusterID}
+			for _, listener := range gateway.Spec.Listeners {
+				if listener.Hostname == nil || *listener.Hostname == "" {
+					continue
+				}
+				if len(target.GetAddresses()) == 0 {
+					// the gateway has no addresses yet, wait for a status update
+					break
+				}
+				name := fmt.Sprintf("%s-%s-%s", recordSet.Name, gateway.Name, listener.Name)
+				record, err := r.buildRecord(recordSet, name, string(*listener.Hostname), target)
+				if err != nil {
+					return nil, err
+				}
+				desired[record.Name] = record
+			}
+		}
+	}
+
+	return desired, nil
+}
+
+// buildRecord builds one generated record resolving host to the addresses
+// of the given target.
+func (r *DNSRecordSetReconciler) buildRecord(recordSet *v1alpha1.DNSRecordSet, name, host string, target builder.Target) (*v1alpha1.DNSRecord, error) {
+	endpoints, err := builder.NewEndpointsBuilder(target, host).Build()
+	if err != nil {
+		return nil, fmt.Errorf("building endpoints for host %q: %w", host, err)
+	}
+	recordBuilder := builder.NewDNSRecordBuilder(recordSet.Namespace, name).
+		WithRootHost(host).
+		WithProviderRef(recordSet.Spec.ProviderRef.Name).
+		WithEndpoints(endpoints)
+	if recordSet.Spec.TTL > 0 {
+		recordBuilder = recordBuilder.WithTTL(externaldnsendpoint.TTL(recordSet.Spec.TTL))
+	}
+	record, err := recordBuilder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("building record for host %q: %w", host, err)
+	}
+	return record, nil
+}
+
+// recordSetRecordName returns the deterministic name of the record generated
+// for a host, the set name suffixed with a short host hash so any host,
+// including wildcards, yields a valid object name.
+func recordSetRecordName(setName, host string) string {
+	return setName + "-" + hash.ToBase36HashLen(host, 6)
+}
+
+func (r *DNSRecordSetReconciler) updateStatus(ctx context.Context, previous, current *v1alpha1.DNSRecordSet) error {
+	current.Status.ObservedGeneration = current.Generation
+	if !equality.Semantic.DeepEqual(previous.Status, current.Status) {
+		return r.Status().Update(ctx, current)
+	}
+	return nil
+}
+
+// setDNSRecordSetCondition adds or updates the Ready condition in the
+// DNSRecordSet status.
+func setDNSRecordSetCondition(recordSet *v1alpha1.DNSRecordSet, status metav1.ConditionStatus, reason, message string) {
+	cond := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: recordSet.Generation,
+	}
+	meta.SetStatusCondition(&recordSet.Status.Conditions, cond)
+}
+
+// recordSetsForGateway enqueues the record sets of the gateways namespace
+// with a selector matching the gateway.
+func (r *DNSRecordSetReconciler) recordSetsForGateway(ctx context.Context, obj client.Object) []ctrl.Request {
+	recordSets := &v1alpha1.DNSRecordSetList{}
+	if err := r.List(ctx, recordSets, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []ctrl.Request
+	for i := range recordSets.Items {
+		recordSet := &recordSets.Items[i]
+		if recordSet.Spec.GatewaySelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(recordSet.Spec.GatewaySelector)
+		if err != nil || !selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(recordSet)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DNSRecordSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DNSRecordSet{}).
+		Owns(&v1alpha1.DNSRecord{})
+	if r.WatchGateways {
+		controllerBuilder = controllerBuilder.Watches(&gatewayapiv1.Gateway{}, handler.EnqueueRequestsFromMapFunc(r.recordSetsForGateway))
+	}
+	return controllerBuilder.Complete(r)
+}
+
+// recordSetTarget adapts a static target list to the builder.
+type recordSetTarget struct {
+	name      string
+	shortCode string
+	targets   []string
+}
+
+func (t recordSetTarget) GetName() string {
+	return t.name
+}
+
+func (t recordSetTarget) GetShortCode() string {
+	return t.shortCode
+}
+
+func (t recordSetTarget) GetAddresses() []builder.TargetAddress {
+	var addresses []builder.TargetAddress
+	for _, target := range t.targets {
+		addressType := builder.HostnameAddressType
+		if net.ParseIP(target) != nil {
+			addressType = builder.IPAddressType
+		}
+		addresses = append(addresses, builder.TargetAddress{Type: addressType, Value: target})
+	}
+	return addresses
+}
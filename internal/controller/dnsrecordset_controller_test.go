@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/builder"
+)
+
+func TestRecordSetTargetAddresses(t *testing.T) {
+	target := recordSetTarget{
+		name:      "bulk",
+		shortCode: "cluster1",
+		targets:   []string{"172.32.200.1", "2001:db8::1", "lb.provider.example.org"},
+	}
+
+	addresses := target.GetAddresses()
+	if len(addresses) != 3 {
+		t.Fatalf("GetAddresses() returned %d addresses, want 3", len(addresses))
+	}
+	wantTypes := []builder.AddressType{builder.IPAddressType, builder.IPAddressType, builder.HostnameAddressType}
+	for i, address := range addresses {
+		if address.Type != wantTypes[i] {
+			t.Errorf("address %q type = %q, want %q", address.Value, address.Type, wantTypes[i])
+		}
+	}
+}
+
+func TestRecordSetRecordName(t *testing.T) {
+	name := recordSetRecordName("bulk", "*.apps.example.com")
+	if name != recordSetRecordName("bulk", "*.apps.example.com") {
+		t.Error("record names are not deterministic")
+	}
+	if name == recordSetRecordName("bulk", "other.example.com") {
+		t.Error("different hosts yield the same record name")
+	}
+	for _, c := range name {
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' {
+			continue
+		}
+		t.Errorf("record name %q contains invalid object name character %q", name, string(c))
+	}
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+const (
+	// defaultVerificationInterval is used when the spec does not set one.
+	defaultVerificationInterval = 5 * time.Minute
+	// verificationQueryTimeout bounds a single verification query.
+	verificationQueryTimeout = 5 * time.Second
+)
+
+// DNSVerificationReconciler reconciles a DNSVerification object
+type DNSVerificationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsverifications,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsverifications/status,verbs=get;update;patch
+
+func (r *DNSVerificationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	previous := &v1alpha1.DNSVerification{}
+	if err := r.Get(ctx, req.NamespacedName, previous); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	verification := previous.DeepCopy()
+
+	if verification.DeletionTimestamp != nil && !verification.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	interval := verification.Spec.Interval.Duration
+	if interval <= 0 {
+		interval = defaultVerificationInterval
+	}
+
+	observed, err := r.resolve(ctx, verification)
+	verification.Status.LastVerificationTime = &metav1.Time{Time: time.Now()}
+	verification.Status.ObservedTargets = observed
+	switch {
+	case err != nil:
+		setDNSVerificationCondition(verification, metav1.ConditionUnknown, v1alpha1.ConditionReasonQueryError,
+			fmt.Sprintf("Verification query failed: %v", err))
+	case matchesExpectation(verification.Spec.Targets, observed):
+		setDNSVerificationCondition(verification, metav1.ConditionTrue, v1alpha1.ConditionReasonVerificationPassed,
+			fmt.Sprintf("Live DNS answers %s %s with the expected targets", verification.Spec.DNSName, verification.Spec.RecordType))
+	default:
+		logger.Info("Live DNS diverges from the expected answer", "dnsName", verification.Spec.DNSName, "observed", observed)
+		setDNSVerificationCondition(verification, metav1.ConditionFalse, v1alpha1.ConditionReasonVerificationFailed,
+			fmt.Sprintf("Live DNS answers %s %s with [%s], expected [%s]", verification.Spec.DNSName, verification.Spec.RecordType,
+				strings.Join(observed, ", "), strings.Join(verification.Spec.Targets, ", ")))
+	}
+
+	if err := r.updateStatus(ctx, previous, verification); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// resolve queries the verifications dnsName and returns the normalized
+// answer set of the expected record type.
+func (r *DNSVerificationReconciler) resolve(ctx context.Context, verification *v1alpha1.DNSVerification) ([]string, error) {
+	server := verification.Spec.Resolver
+	if server == "" {
+		config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+		if err != nil {
+			return nil, fmt.Errorf("reading resolver configuration: %w", err)
+		}
+		if len(config.Servers) == 0 {
+			return nil, fmt.Errorf("no resolvers configured")
+		}
+		server = config.Servers[0] + ":" + config.Port
+	} else if !strings.Contains(server, ":") {
+		server += ":53"
+	}
+
+	qtype, ok := dns.StringToType[verification.Spec.RecordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %q", verification.Spec.RecordType)
+	}
+
+	message := new(dns.Msg)
+	message.SetQuestion(dns.Fqdn(verification.Spec.DNSName), qtype)
+	dnsClient := &dns.Client{Timeout: verificationQueryTimeout}
+	response, _, err := dnsClient.ExchangeContext(ctx, message, server)
+	if err != nil {
+		return nil, err
+	}
+
+	var observed []string
+	for _, rr := range response.Answer {
+		if rr.Header().Rrtype != qtype {
+			continue
+		}
+		if target, ok := answerTarget(rr); ok {
+			observed = append(observed, target)
+		}
+	}
+	sort.Strings(observed)
+	return observed, nil
+}
+
+// answerTarget extracts the comparable target value of an answer record.
+func answerTarget(rr dns.RR) (string, bool) {
+	switch record := rr.(type) {
+	case *dns.A:
+		return record.A.String(), true
+	case *dns.AAAA:
+		return record.AAAA.String(), true
+	case *dns.CNAME:
+		return strings.ToLower(strings.TrimSuffix(record.Target, ".")), true
+	case *dns.TXT:
+		return strings.Join(record.Txt, ""), true
+	case *dns.NS:
+		return strings.ToLower(strings.TrimSuffix(record.Ns, ".")), true
+	}
+	return "", false
+}
+
+// matchesExpectation reports whether the observed answer set is exactly the
+// expected one, in any order. Name targets compare case insensitively and
+// without a trailing dot.
+func matchesExpectation(expected, observed []string) bool {
+	if len(expected) != len(observed) {
+		return false
+	}
+	remaining := map[string]int{}
+	for _, target := range observed {
+		remaining[normalizeVerificationTarget(target)]++
+	}
+	for _, target := range expected {
+		key := normalizeVerificationTarget(target)
+		if remaining[key] == 0 {
+			return false
+		}
+		remaining[key]--
+	}
+	return true
+}
+
+func normalizeVerificationTarget(target string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.Trim(target, "\""), "."))
+}
+
+func (r *DNSVerificationReconciler) updateStatus(ctx context.Context, previous, current *v1alpha1.DNSVerification) error {
+	if !equality.Semantic.DeepEqual(previous.Status, current.Status) {
+		return r.Status().Update(ctx, current)
+	}
+	return nil
+}
+
+// setDNSVerificationCondition adds or updates the Verified condition in the
+// DNSVerification status.
+func setDNSVerificationCondition(verification *v1alpha1.DNSVerification, status metav1.ConditionStatus, reason, message string) {
+	cond := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeVerified,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: verification.Generation,
+	}
+	meta.SetStatusCondition(&verification.Status.Conditions, cond)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DNSVerificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DNSVerification{}).
+		Complete(r)
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// validateDomainClaims rejects the record if its rootHost falls under a
+// claimed domain and its namespace is not listed on the claim.
+func (r *DNSRecordReconciler) validateDomainClaims(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) error {
+	claimList := &v1alpha1.DNSDomainClaimList{}
+	if err := r.List(ctx, claimList); err != nil {
+		return err
+	}
+
+	claim := domainClaimFor(dnsRecord.GetRootHost(), claimList.Items)
+	if claim == nil {
+		return nil
+	}
+	for _, namespace := range claim.Spec.Namespaces {
+		if namespace == dnsRecord.Namespace {
+			return nil
+		}
+	}
+	return fmt.Errorf("rootHost %q falls under domain %q claimed by namespaces %s",
+		dnsRecord.GetRootHost(), claim.Spec.Domain, strings.Join(claim.Spec.Namespaces, ", "))
+}
+
+// domainClaimFor returns the most specific claim covering the given host, or
+// nil if no claim covers it.
+func domainClaimFor(host string, claims []v1alpha1.DNSDomainClaim) *v1alpha1.DNSDomainClaim {
+	var match *v1alpha1.DNSDomainClaim
+	for i := range claims {
+		domain := strings.ToLower(claims[i].Spec.Domain)
+		if host != domain && !strings.HasSuffix(host, "."+domain) {
+			continue
+		}
+		if match == nil || len(domain) > len(match.Spec.Domain) {
+			match = &claims[i]
+		}
+	}
+	return match
+}
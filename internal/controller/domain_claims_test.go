@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+func TestDomainClaimFor(t *testing.T) {
+	claim := func(domain string, namespaces ...string) v1alpha1.DNSDomainClaim {
+		return v1alpha1.DNSDomainClaim{Spec: v1alpha1.DNSDomainClaimSpec{Domain: domain, Namespaces: namespaces}}
+	}
+	claims := []v1alpha1.DNSDomainClaim{
+		claim("example.com", "platform"),
+		claim("team-a.example.com", "team-a"),
+	}
+
+	tests := []struct {
+		name       string
+		host       string
+		wantDomain string
+	}{
+		{name: "most specific claim wins", host: "app.team-a.example.com", wantDomain: "team-a.example.com"},
+		{name: "parent claim covers other subdomains", host: "app.team-b.example.com", wantDomain: "example.com"},
+		{name: "claimed apex", host: "example.com", wantDomain: "example.com"},
+		{name: "unclaimed domain", host: "app.example.net", wantDomain: ""},
+		{name: "suffix trick does not match", host: "evilexample.com", wantDomain: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := domainClaimFor(tt.host, claims)
+			if tt.wantDomain == "" {
+				if got != nil {
+					t.Errorf("domainClaimFor() = %v, want nil", got.Spec.Domain)
+				}
+				return
+			}
+			if got == nil || got.Spec.Domain != tt.wantDomain {
+				t.Errorf("domainClaimFor() = %v, want %q", got, tt.wantDomain)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+// flapDamper suppresses withdrawals of targets that disappeared only
+// moments ago, so load balancer churn (an address vanishing and reappearing
+// within the damping window) does not withdraw and re-add records.
+type flapDamper struct {
+	// window is how long a vanished target is kept published before its
+	// withdrawal is allowed through.
+	window time.Duration
+
+	mu   sync.Mutex
+	seen map[string]map[string]time.Time
+}
+
+func newFlapDamper(window time.Duration) *flapDamper {
+	return &flapDamper{window: window, seen: map[string]map[string]time.Time{}}
+}
+
+// observe records the targets the desired endpoints currently carry, and
+// prunes targets not seen for twice the window.
+func (d *flapDamper) observe(endpoints []*externaldnsendpoint.Endpoint) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	for _, endpoint := range endpoints {
+		key := endpointKey(endpoint)
+		if d.seen[key] == nil {
+			d.seen[key] = map[string]time.Time{}
+		}
+		for _, target := range endpoint.Targets {
+			d.seen[key][target] = now
+		}
+	}
+	for key, targets := range d.seen {
+		for target, seenAt := range targets {
+			if now.Sub(seenAt) > 2*d.window {
+				delete(targets, target)
+			}
+		}
+		if len(targets) == 0 {
+			delete(d.seen, key)
+		}
+	}
+}
+
+// dampen rewrites the changes so targets seen within the window are not
+// withdrawn: updates keep them in the new RRset and deletes of recently seen
+// RRsets are dropped. It returns the number of suppressed withdrawals.
+func (d *flapDamper) dampen(changes *externaldnsplan.Changes) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	suppressed := 0
+
+	for i, updated := range changes.UpdateNew {
+		if i >= len(changes.UpdateOld) {
+			break
+		}
+		key := endpointKey(updated)
+		for _, target := range changes.UpdateOld[i].Targets {
+			if contains(updated.Targets, target) {
+				continue
+			}
+			if seenAt, ok := d.seen[key][target]; ok && time.Since(seenAt) <= d.window {
+				updated.Targets = append(updated.Targets, target)
+				suppressed++
+			}
+		}
+	}
+
+	var deletes []*externaldnsendpoint.Endpoint
+	for _, deleted := range changes.Delete {
+		key := endpointKey(deleted)
+		recent := false
+		for _, target := range deleted.Targets {
+			if seenAt, ok := d.seen[key][target]; ok && time.Since(seenAt) <= d.window {
+				recent = true
+				break
+			}
+		}
+		if recent {
+			suppressed++
+			continue
+		}
+		deletes = append(deletes, deleted)
+	}
+	changes.Delete = deletes
+
+	return suppressed
+}
+
+func endpointKey(endpoint *externaldnsendpoint.Endpoint) string {
+	return endpoint.DNSName + "/" + endpoint.RecordType + "/" + endpoint.SetIdentifier
+}
+
+func contains(targets []string, target string) bool {
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+func TestFlapDamperSuppressesRecentWithdrawals(t *testing.T) {
+	damper := newFlapDamper(time.Minute)
+	damper.observe([]*externaldnsendpoint.Endpoint{
+		externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1", "2.2.2.2"),
+	})
+
+	// the load balancer dropped 2.2.2.2, the plan wants to withdraw it
+	changes := &externaldnsplan.Changes{
+		UpdateOld: []*externaldnsendpoint.Endpoint{
+			externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1", "2.2.2.2"),
+		},
+		UpdateNew: []*externaldnsendpoint.Endpoint{
+			externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1"),
+		},
+		Delete: []*externaldnsendpoint.Endpoint{
+			externaldnsendpoint.NewEndpoint("other.example.com", "A", "3.3.3.3"),
+		},
+	}
+	if got := damper.dampen(changes); got != 1 {
+		t.Errorf("dampen() suppressed %d withdrawals, want 1", got)
+	}
+	if targets := changes.UpdateNew[0].Targets; len(targets) != 2 {
+		t.Errorf("expected the recently seen target to stay published, got %v", targets)
+	}
+	// 3.3.3.3 was never observed, its delete passes through
+	if len(changes.Delete) != 1 {
+		t.Errorf("expected the unobserved delete to pass through, got %v", changes.Delete)
+	}
+
+	damper.observe([]*externaldnsendpoint.Endpoint{
+		externaldnsendpoint.NewEndpoint("other.example.com", "A", "3.3.3.3"),
+	})
+	changes = &externaldnsplan.Changes{
+		Delete: []*externaldnsendpoint.Endpoint{
+			externaldnsendpoint.NewEndpoint("other.example.com", "A", "3.3.3.3"),
+		},
+	}
+	if got := damper.dampen(changes); got != 1 {
+		t.Errorf("dampen() suppressed %d withdrawals, want 1", got)
+	}
+	if len(changes.Delete) != 0 {
+		t.Errorf("expected the recently seen delete to be suppressed, got %v", changes.Delete)
+	}
+}
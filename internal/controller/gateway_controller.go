@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/builder"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/identity"
+)
+
+const (
+	// GatewayRecordLabel is set on DNSRecords created for a Gateway and
+	// carries the gateway name, used to find stale records when listeners
+	// are removed.
+	GatewayRecordLabel = "kuadrant.io/gateway"
+)
+
+// GatewayReconciler creates a DNSRecord per listener hostname of Gateways
+// annotated with kuadrant.io/dns-provider, resolving the hostnames to the
+// gateway addresses. It gives simple installations DNS for Gateways without
+// deploying a policy controller, and is enabled with
+// --enable-gateway-controller.
+type GatewayReconciler struct {
+	client.Client
+	ClusterIdentity identity.ClusterIdentity
+}
+
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+
+func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("Reconciling Gateway")
+
+	gateway := &gatewayapiv1.Gateway{}
+	if err := r.Get(ctx, req.NamespacedName, gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			// owned records are removed by garbage collection
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if gateway.DeletionTimestamp != nil && !gateway.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	providerName := gateway.Annotations[v1alpha1.DNSProviderAnnotation]
+
+	desired := map[string]*v1alpha1.DNSRecord{}
+	if providerName != "" {
+		target := gatewayTarget{gateway: gateway, shortCode: r.ClusterIdentity.ClusterID}
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.Hostname == nil || *listener.Hostname == "" {
+				continue
+			}
+			if len(target.GetAddresses()) == 0 {
+				// the gateway has no addresses yet, wait for a status update
+				logger.V(1).Info("Gateway has no addresses, skipping record creation")
+				break
+			}
+			endpoints, err := builder.NewEndpointsBuilder(target, string(*listener.Hostname)).Build()
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			record, err := builder.NewDNSRecordBuilder(gateway.Namespace, fmt.Sprintf("%s-%s", gateway.Name, listener.Name)).
+				WithRootHost(string(*listener.Hostname)).
+				WithProviderRef(providerName).
+				WithEndpoints(endpoints).
+				Build()
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			desired[record.Name] = record
+		}
+	}
+
+	existing := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, existing, client.InNamespace(gateway.Namespace), client.MatchingLabels{GatewayRecordLabel: gateway.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for name, record := range desired {
+		current := &v1alpha1.DNSRecord{}
+		current.Namespace = record.Namespace
+		current.Name = name
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, current, func() error {
+			if current.Labels == nil {
+				current.Labels = map[string]string{}
+			}
+			current.Labels[GatewayRecordLabel] = gateway.Name
+			current.Spec.RootHost = record.Spec.RootHost
+			current.Spec.ProviderRef = record.Spec.ProviderRef
+			current.Spec.Endpoints = record.Spec.Endpoints
+			return controllerutil.SetControllerReference(gateway, current, r.Scheme())
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if op != controllerutil.OperationResultNone {
+			logger.Info("Reconciled DNSRecord for Gateway listener", "dnsrecord", name, "operation", op)
+		}
+	}
+
+	// remove records of listeners that no longer exist, or all records when
+	// the annotation was removed
+	for i := range existing.Items {
+		record := &existing.Items[i]
+		if _, ok := desired[record.Name]; ok {
+			continue
+		}
+		logger.Info("Deleting DNSRecord for removed Gateway listener", "dnsrecord", record.Name)
+		if err := r.Delete(ctx, record); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayapiv1.Gateway{}).
+		Owns(&v1alpha1.DNSRecord{}).
+		Complete(r)
+}
+
+// gatewayTarget adapts a Gateway to the builder, exposing the addresses
+// from its status.
+type gatewayTarget struct {
+	gateway   *gatewayapiv1.Gateway
+	shortCode string
+}
+
+func (t gatewayTarget) GetName() string {
+	return t.gateway.GetName()
+}
+
+func (t gatewayTarget) GetShortCode() string {
+	return t.shortCode
+}
+
+func (t gatewayTarget) GetAddresses() []builder.TargetAddress {
+	var addresses []builder.TargetAddress
+	for _, address := range t.gateway.Status.Addresses {
+		addressType := builder.HostnameAddressType
+		if address.Type != nil && *address.Type == gatewayapiv1.IPAddressType {
+			addressType = builder.IPAddressType
+		}
+		addresses = append(addresses, builder.TargetAddress{Type: addressType, Value: address.Value})
+	}
+	return addresses
+}
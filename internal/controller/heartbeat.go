@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+const (
+	// heartbeatRecordPrefix prefixes the TXT record written into each zone
+	// per owner id, e.g. kuadrant-heartbeat-abc12345.example.com.
+	heartbeatRecordPrefix = "kuadrant-heartbeat-"
+	// heartbeatTTL is the TTL of heartbeat records.
+	heartbeatTTL = externaldnsendpoint.TTL(60)
+)
+
+// HeartbeatWriter periodically refreshes a heartbeat TXT record per owner id
+// in every zone this cluster publishes records into. Operators in other
+// clusters use the heartbeat age to distinguish owners that are alive from
+// owners whose cluster has died and whose records can be pruned.
+type HeartbeatWriter struct {
+	client.Client
+	ProviderFactory provider.Factory
+
+	// Interval between heartbeat refreshes.
+	Interval time.Duration
+}
+
+var _ manager.Runnable = &HeartbeatWriter{}
+
+// NeedLeaderElection ensures only the leader writes heartbeats.
+func (h *HeartbeatWriter) NeedLeaderElection() bool {
+	return true
+}
+
+func (h *HeartbeatWriter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(h.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			h.beat(ctx)
+		}
+	}
+}
+
+// beat refreshes the heartbeat record of every (provider secret, zone,
+// owner) combination currently in use by DNSRecords in the cluster.
+func (h *HeartbeatWriter) beat(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("heartbeat")
+
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := h.List(ctx, recordList); err != nil {
+		logger.Error(err, "Failed to list DNSRecords, skipping heartbeat")
+		return
+	}
+
+	// heartbeat record names grouped by the provider secret to write through
+	names := map[providerRef]map[string]struct{}{}
+	for i := range recordList.Items {
+		record := &recordList.Items[i]
+		owner := record.Status.OwnerID
+		zone := record.Status.ZoneDomainName
+		if owner == "" || zone == "" {
+			// record has not been published yet
+			continue
+		}
+		ref := providerRef{namespace: record.Namespace, name: record.Spec.ProviderRef.Name}
+		if names[ref] == nil {
+			names[ref] = map[string]struct{}{}
+		}
+		names[ref][heartbeatRecordPrefix+owner+"."+zone] = struct{}{}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for ref, heartbeatNames := range names {
+		if err := h.writeHeartbeats(ctx, ref, heartbeatNames, now); err != nil {
+			logger.Error(err, "Failed to write heartbeats", "secret", ref.namespace+"/"+ref.name)
+		}
+	}
+}
+
+func (h *HeartbeatWriter) writeHeartbeats(ctx context.Context, ref providerRef, names map[string]struct{}, timestamp string) error {
+	dnsProvider, err := h.ProviderFactory.ProviderFor(ctx, ref, provider.Config{})
+	if err != nil {
+		return err
+	}
+
+	records, err := dnsProvider.Records(ctx)
+	if err != nil {
+		return err
+	}
+	existing := map[string]*externaldnsendpoint.Endpoint{}
+	for _, record := range records {
+		if record.RecordType == externaldnsendpoint.RecordTypeTXT && strings.HasPrefix(record.DNSName, heartbeatRecordPrefix) {
+			existing[record.DNSName] = record
+		}
+	}
+
+	changes := &externaldnsplan.Changes{}
+	for name := range names {
+		desired := externaldnsendpoint.NewEndpointWithTTL(name, externaldnsendpoint.RecordTypeTXT, heartbeatTTL, timestamp)
+		if current, ok := existing[name]; ok {
+			changes.UpdateOld = append(changes.UpdateOld, current)
+			changes.UpdateNew = append(changes.UpdateNew, desired)
+		} else {
+			changes.Create = append(changes.Create, desired)
+		}
+	}
+	if !changes.HasChanges() {
+		return nil
+	}
+	return dnsProvider.ApplyChanges(ctx, changes)
+}
+
+// heartbeatsFrom extracts owner heartbeat times from the raw records of a zone.
+func heartbeatsFrom(records []*externaldnsendpoint.Endpoint) map[string]time.Time {
+	heartbeats := map[string]time.Time{}
+	for _, record := range records {
+		if record.RecordType != externaldnsendpoint.RecordTypeTXT || !strings.HasPrefix(record.DNSName, heartbeatRecordPrefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(record.DNSName, heartbeatRecordPrefix)
+		owner, _, found := strings.Cut(rest, ".")
+		if !found || len(record.Targets) == 0 {
+			continue
+		}
+		when, err := time.Parse(time.RFC3339, strings.Trim(record.Targets[0], "\""))
+		if err != nil {
+			continue
+		}
+		if current, ok := heartbeats[owner]; !ok || when.After(current) {
+			heartbeats[owner] = when
+		}
+	}
+	return heartbeats
+}
+
+// providerRef identifies a provider secret by namespace and name and
+// implements provider.ProviderAccessor.
+type providerRef struct {
+	namespace string
+	name      string
+}
+
+func (p providerRef) GetNamespace() string {
+	return p.namespace
+}
+
+func (p providerRef) GetProviderRef() v1alpha1.ProviderRef {
+	return v1alpha1.ProviderRef{Name: p.name}
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindows are recurring windows during which no provider
+// mutations are performed. Reads and status updates continue, so records
+// keep reporting drift while frozen.
+type MaintenanceWindows []maintenanceWindow
+
+// maintenanceWindow is a recurring window, daily or on a fixed weekday.
+type maintenanceWindow struct {
+	// day the window starts on; nil means every day.
+	day *time.Weekday
+	// start and end are minutes since midnight UTC. A window with
+	// end <= start wraps past midnight into the next day.
+	start int
+	end   int
+}
+
+// ParseMaintenanceWindows parses window entries of the form "HH:MM-HH:MM"
+// (daily) or "Mon HH:MM-HH:MM" (weekly), in UTC. A window wrapping past
+// midnight, e.g. "Sat 22:00-04:00", ends on the following day.
+func ParseMaintenanceWindows(entries []string) (MaintenanceWindows, error) {
+	var windows MaintenanceWindows
+	for _, entry := range entries {
+		window, err := parseMaintenanceWindow(entry)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+func parseMaintenanceWindow(entry string) (maintenanceWindow, error) {
+	window := maintenanceWindow{}
+	fields := strings.Fields(entry)
+	span := ""
+	switch len(fields) {
+	case 1:
+		span = fields[0]
+	case 2:
+		day, ok := weekdays[strings.ToLower(fields[0])]
+		if !ok {
+			return window, fmt.Errorf("invalid maintenance window day %q in %q", fields[0], entry)
+		}
+		window.day = &day
+		span = fields[1]
+	default:
+		return window, fmt.Errorf("invalid maintenance window %q, expected \"[Day] HH:MM-HH:MM\"", entry)
+	}
+
+	from, to, found := strings.Cut(span, "-")
+	if !found {
+		return window, fmt.Errorf("invalid maintenance window span %q in %q", span, entry)
+	}
+	var err error
+	if window.start, err = parseMinutes(from); err != nil {
+		return window, fmt.Errorf("invalid maintenance window %q: %w", entry, err)
+	}
+	if window.end, err = parseMinutes(to); err != nil {
+		return window, fmt.Errorf("invalid maintenance window %q: %w", entry, err)
+	}
+	return window, nil
+}
+
+func parseMinutes(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// Active returns true if the given time falls inside any window.
+func (w MaintenanceWindows) Active(now time.Time) bool {
+	now = now.UTC()
+	minutes := now.Hour()*60 + now.Minute()
+	for _, window := range w {
+		if window.contains(now.Weekday(), minutes) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w maintenanceWindow) contains(day time.Weekday, minutes int) bool {
+	if w.end > w.start {
+		return w.matchesDay(day) && minutes >= w.start && minutes < w.end
+	}
+	// the window wraps past midnight
+	if w.matchesDay(day) && minutes >= w.start {
+		return true
+	}
+	return w.matchesDay(day-1) && minutes < w.end
+}
+
+func (w maintenanceWindow) matchesDay(day time.Weekday) bool {
+	if w.day == nil {
+		return true
+	}
+	if day < 0 {
+		day += 7
+	}
+	return *w.day == day
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowsActive(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatalf("bad test time %q: %v", value, err)
+		}
+		return parsed
+	}
+
+	testCases := []struct {
+		name    string
+		entries []string
+		now     time.Time
+		want    bool
+	}{
+		{
+			name:    "inside a daily window",
+			entries: []string{"22:00-23:00"},
+			now:     at("2026-09-01T22:30:00Z"),
+			want:    true,
+		},
+		{
+			name:    "outside a daily window",
+			entries: []string{"22:00-23:00"},
+			now:     at("2026-09-01T21:59:00Z"),
+			want:    false,
+		},
+		{
+			name:    "weekly window on the right day",
+			entries: []string{"Tue 08:00-10:00"},
+			now:     at("2026-09-01T09:00:00Z"), // a Tuesday
+			want:    true,
+		},
+		{
+			name:    "weekly window on another day",
+			entries: []string{"Mon 08:00-10:00"},
+			now:     at("2026-09-01T09:00:00Z"),
+			want:    false,
+		},
+		{
+			name:    "wrapping window before midnight",
+			entries: []string{"Tue 22:00-04:00"},
+			now:     at("2026-09-01T23:00:00Z"),
+			want:    true,
+		},
+		{
+			name:    "wrapping window after midnight on the next day",
+			entries: []string{"Tue 22:00-04:00"},
+			now:     at("2026-09-02T03:00:00Z"), // Wednesday morning
+			want:    true,
+		},
+		{
+			name:    "wrapping window expired",
+			entries: []string{"Tue 22:00-04:00"},
+			now:     at("2026-09-02T05:00:00Z"),
+			want:    false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			windows, err := ParseMaintenanceWindows(testCase.entries)
+			if err != nil {
+				t.Fatalf("ParseMaintenanceWindows() unexpected error: %v", err)
+			}
+			if got := windows.Active(testCase.now); got != testCase.want {
+				t.Errorf("Active(%v) = %v, want %v", testCase.now, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseMaintenanceWindowsRejectsInvalidEntries(t *testing.T) {
+	for _, entry := range []string{"nonsense", "Mon", "Funday 08:00-10:00", "8am-10am"} {
+		if _, err := ParseMaintenanceWindows([]string{entry}); err == nil {
+			t.Errorf("expected %q to be rejected", entry)
+		}
+	}
+}
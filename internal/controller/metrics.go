@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// zoneRecordsGauge reports the number of records observed in each
+	// provider zone the operator publishes into, for alerting on provider
+	// record limit proximity.
+	zoneRecordsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dns_operator_zone_records",
+		Help: "Number of records observed in a provider zone.",
+	}, []string{"zone"})
+
+	// recordProviderRecordsGauge reports how many provider records
+	// (including registry TXT records) each DNSRecord materializes into,
+	// for estimating provider cost and quota usage per application.
+	recordProviderRecordsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dns_operator_record_provider_records",
+		Help: "Number of provider records a DNSRecord materializes into.",
+	}, []string{"namespace", "name"})
+
+	// provider health dashboard gauges, per provider secret, so the health
+	// of each DNS credential in the fleet is visible at a glance.
+	providerLastZoneListGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dns_operator_provider_last_zone_list_timestamp_seconds",
+		Help: "Unix time of the last successful zone list through a provider secret.",
+	}, []string{"provider"})
+	providerLastApplyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dns_operator_provider_last_apply_timestamp_seconds",
+		Help: "Unix time of the last successful apply through a provider secret.",
+	}, []string{"provider"})
+	providerConsecutiveFailuresGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dns_operator_provider_consecutive_failures",
+		Help: "Number of consecutive failed interactions with a provider secret.",
+	}, []string{"provider"})
+
+	// stalledRecordsGauge counts records stalled in a not Ready state past
+	// the threshold, by the reason of their Ready condition.
+	stalledRecordsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dns_operator_stalled_records",
+		Help: "Number of DNSRecords not Ready with the same error past the stalled threshold, by reason.",
+	}, []string{"reason"})
+
+	// suppressedFlapsCounter counts target withdrawals suppressed by the
+	// address flap damping window.
+	suppressedFlapsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "dns_operator_suppressed_flaps_total",
+		Help: "Number of target withdrawals suppressed by address flap damping.",
+	})
+
+	// driftCorrectionsCounter counts repair scan corrections of out-of-band
+	// zone edits, per zone.
+	driftCorrectionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dns_operator_drift_corrections_total",
+		Help: "Number of drift corrections applied to a provider zone by the repair scan.",
+	}, []string{"zone"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(zoneRecordsGauge, recordProviderRecordsGauge, driftCorrectionsCounter,
+		providerLastZoneListGauge, providerLastApplyGauge, providerConsecutiveFailuresGauge, stalledRecordsGauge, suppressedFlapsCounter)
+}
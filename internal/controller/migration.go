@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// migrateOwnership claims the registry entries of the source record named by
+// the migrate-from annotation for this record, returning how many record
+// sets changed hands. The source must still exist and be marked migrating,
+// so an annotation typo cannot silently claim an unrelated records entries.
+func (r *DNSRecordReconciler) migrateOwnership(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) (int, error) {
+	value, _ := dnsRecord.GetMigrationSource()
+	sourceName, err := parseMigrationSource(value)
+	if err != nil {
+		return 0, err
+	}
+
+	source := &v1alpha1.DNSRecord{}
+	if err := r.Get(ctx, sourceName, source); err != nil {
+		return 0, fmt.Errorf("getting migration source %s: %w", value, err)
+	}
+
+	fromOwner, err := migrationOwner(dnsRecord, source)
+	if err != nil {
+		return 0, err
+	}
+	if fromOwner == "" {
+		// the source already publishes under this records owner id,
+		// nothing to claim
+		return 0, nil
+	}
+	return r.takeOverOwnership(ctx, dnsRecord, fromOwner)
+}
+
+// parseMigrationSource parses the "namespace/name" value of the
+// migrate-from annotation.
+func parseMigrationSource(value string) (types.NamespacedName, error) {
+	namespace, name, found := strings.Cut(value, "/")
+	if !found || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("invalid migration source %q, expected \"namespace/name\"", value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// migrationOwner returns the owner id the registry entries must be claimed
+// from, after verifying the source is actually being migrated and serves the
+// same root host. An empty owner id means the entries are already registered
+// to the adopting record.
+func migrationOwner(dnsRecord, source *v1alpha1.DNSRecord) (string, error) {
+	if !source.IsMigrating() {
+		return "", fmt.Errorf("migration source %s/%s is not marked migrating, set the %s annotation on it first",
+			source.Namespace, source.Name, v1alpha1.MigratingAnnotation)
+	}
+	if source.GetRootHost() != dnsRecord.GetRootHost() {
+		return "", fmt.Errorf("migration source %s/%s has rootHost %q, this record has %q",
+			source.Namespace, source.Name, source.GetRootHost(), dnsRecord.GetRootHost())
+	}
+	// the status owner id is what the registry entries were published
+	// under, a never-published source has nothing to claim
+	fromOwner := source.Status.OwnerID
+	if fromOwner == "" {
+		return "", fmt.Errorf("migration source %s/%s has no owner id, it has never been published",
+			source.Namespace, source.Name)
+	}
+	if fromOwner == dnsRecord.Status.OwnerID {
+		return "", nil
+	}
+	return fromOwner, nil
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+func TestParseMigrationSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		wantNamespace string
+		wantName      string
+		wantErr       bool
+	}{
+		{
+			name:          "namespace and name",
+			value:         "old-ns/app-record",
+			wantNamespace: "old-ns",
+			wantName:      "app-record",
+		},
+		{
+			name:    "a bare name is rejected",
+			value:   "app-record",
+			wantErr: true,
+		},
+		{
+			name:    "an empty namespace is rejected",
+			value:   "/app-record",
+			wantErr: true,
+		},
+		{
+			name:    "an empty name is rejected",
+			value:   "old-ns/",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := parseMigrationSource(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMigrationSource() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if source.Namespace != tt.wantNamespace || source.Name != tt.wantName {
+				t.Errorf("parseMigrationSource() = %s/%s, want %s/%s", source.Namespace, source.Name, tt.wantNamespace, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestMigrationOwner(t *testing.T) {
+	record := func(rootHost, ownerID string, migrating bool) *v1alpha1.DNSRecord {
+		r := &v1alpha1.DNSRecord{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "record"},
+			Spec:       v1alpha1.DNSRecordSpec{RootHost: rootHost},
+			Status:     v1alpha1.DNSRecordStatus{OwnerID: ownerID},
+		}
+		if migrating {
+			r.Annotations = map[string]string{v1alpha1.MigratingAnnotation: "true"}
+		}
+		return r
+	}
+
+	tests := []struct {
+		name      string
+		dnsRecord *v1alpha1.DNSRecord
+		source    *v1alpha1.DNSRecord
+		wantOwner string
+		wantErr   string
+	}{
+		{
+			name:      "claims the source owner id",
+			dnsRecord: record("app.example.com", "new-owner", false),
+			source:    record("app.example.com", "old-owner", true),
+			wantOwner: "old-owner",
+		},
+		{
+			name:      "nothing to claim when the owner ids already match",
+			dnsRecord: record("app.example.com", "owner", false),
+			source:    record("app.example.com", "owner", true),
+			wantOwner: "",
+		},
+		{
+			name:      "rejects a source not marked migrating",
+			dnsRecord: record("app.example.com", "new-owner", false),
+			source:    record("app.example.com", "old-owner", false),
+			wantErr:   "not marked migrating",
+		},
+		{
+			name:      "rejects a source with a different root host",
+			dnsRecord: record("app.example.com", "new-owner", false),
+			source:    record("other.example.com", "old-owner", true),
+			wantErr:   "rootHost",
+		},
+		{
+			name:      "rejects a source that was never reconciled",
+			dnsRecord: record("app.example.com", "new-owner", false),
+			source:    record("app.example.com", "", true),
+			wantErr:   "no owner id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, err := migrationOwner(tt.dnsRecord, tt.source)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("migrationOwner() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("migrationOwner() error = %v", err)
+			}
+			if owner != tt.wantOwner {
+				t.Errorf("migrationOwner() = %q, want %q", owner, tt.wantOwner)
+			}
+		})
+	}
+}
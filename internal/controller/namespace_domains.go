@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// NamespaceDomains restricts the domains namespaces may publish under. A
+// namespace with entries may only create DNSRecords whose rootHost is equal
+// to or a subdomain of one of them; namespaces without entries are
+// unrestricted. It stops one tenant hijacking another's hostnames.
+type NamespaceDomains map[string][]string
+
+// ParseNamespaceDomains parses entries of the form
+// "namespace=domain" (one domain per entry, entries may repeat a namespace)
+// as given on the command line.
+func ParseNamespaceDomains(entries []string) (NamespaceDomains, error) {
+	domains := NamespaceDomains{}
+	for _, entry := range entries {
+		namespace, domain, found := strings.Cut(entry, "=")
+		if !found || namespace == "" || domain == "" {
+			return nil, fmt.Errorf("invalid namespace domain entry %q, expected \"namespace=domain\"", entry)
+		}
+		domain = strings.TrimPrefix(strings.ToLower(domain), v1alpha1.WildcardPrefix)
+		domains[namespace] = append(domains[namespace], domain)
+	}
+	return domains, nil
+}
+
+// Validate returns an error if the given namespace is restricted and the
+// rootHost falls outside its allowed domains.
+func (d NamespaceDomains) Validate(namespace, rootHost string) error {
+	allowed, restricted := d[namespace]
+	if !restricted {
+		return nil
+	}
+	rootHost = strings.TrimPrefix(strings.ToLower(rootHost), v1alpha1.WildcardPrefix)
+	for _, domain := range allowed {
+		if rootHost == domain || strings.HasSuffix(rootHost, "."+domain) {
+			return nil
+		}
+	}
+	return fmt.Errorf("rootHost %q is not allowed in namespace %q, allowed domains: %s",
+		rootHost, namespace, strings.Join(allowed, ", "))
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNamespaceDomains(t *testing.T) {
+	domains, err := ParseNamespaceDomains([]string{
+		"team-a=*.team-a.example.com",
+		"team-a=team-a.example.org",
+		"team-b=team-b.example.com",
+	})
+	if err != nil {
+		t.Fatalf("ParseNamespaceDomains() unexpected error: %v", err)
+	}
+	if len(domains["team-a"]) != 2 || domains["team-a"][0] != "team-a.example.com" {
+		t.Errorf("unexpected team-a domains: %v", domains["team-a"])
+	}
+
+	if _, err := ParseNamespaceDomains([]string{"team-a"}); err == nil {
+		t.Errorf("expected an error for an entry without a domain")
+	}
+}
+
+func TestNamespaceDomains_Validate(t *testing.T) {
+	domains, err := ParseNamespaceDomains([]string{"team-a=*.team-a.example.com"})
+	if err != nil {
+		t.Fatalf("ParseNamespaceDomains() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		rootHost  string
+		wantErr   bool
+	}{
+		{name: "allowed subdomain", namespace: "team-a", rootHost: "app.team-a.example.com"},
+		{name: "allowed wildcard", namespace: "team-a", rootHost: "*.team-a.example.com"},
+		{name: "allowed apex", namespace: "team-a", rootHost: "team-a.example.com"},
+		{name: "outside allowed domains", namespace: "team-a", rootHost: "app.team-b.example.com", wantErr: true},
+		{name: "suffix trick rejected", namespace: "team-a", rootHost: "evilteam-a.example.com", wantErr: true},
+		{name: "unrestricted namespace", namespace: "team-c", rootHost: "anything.example.net"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := domains.Validate(tt.namespace, tt.rootHost)
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), "not allowed")) {
+				t.Errorf("Validate() expected a not allowed error, got: %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
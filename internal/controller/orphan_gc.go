@@ -0,0 +1,254 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// orphanGCOwnerID is the owner id the gc registry is created with. It is
+// never used to publish records, only to read the registry state of zones.
+const orphanGCOwnerID = "kuadrant-gc"
+
+// OrphanGC periodically scans the zones of all provider secrets for
+// registry-owned records whose owner no longer exists in the cluster and
+// deletes them, cleaning up after clusters or records that were destroyed
+// without deleting their published endpoints.
+//
+// A record is only deleted once it has been seen orphaned for longer than
+// the grace period, so a DNSRecord that is deleted and quickly recreated, or
+// a cluster that is temporarily unreachable, does not lose its records.
+type OrphanGC struct {
+	client.Client
+	ProviderFactory provider.Factory
+
+	// Interval between zone scans.
+	Interval time.Duration
+	// GracePeriod a record must remain orphaned before it is deleted.
+	GracePeriod time.Duration
+	// RegistryConfig is the default registry format, overridable per
+	// provider secret.
+	RegistryConfig provider.RegistryConfig
+	// HeartbeatThreshold is how stale an owners heartbeat may be before the
+	// owner is considered dead and its records are pruned. Owners with a
+	// fresh heartbeat are never pruned even if they have no DNSRecord in
+	// this cluster. While set, foreign owners with no heartbeat at all are
+	// never pruned either: they are indistinguishable from a live cluster
+	// that does not write heartbeats, so every cluster sharing the
+	// registry must run with --heartbeat-interval before its records are
+	// eligible. Zero disables heartbeat based pruning and falls back to
+	// grace period pruning of any foreign owner, which is only safe when
+	// no other cluster publishes into the zones.
+	HeartbeatThreshold time.Duration
+
+	// firstSeen tracks when an orphaned record was first observed, keyed by
+	// provider secret and record identity.
+	firstSeen map[string]time.Time
+}
+
+var _ manager.Runnable = &OrphanGC{}
+
+// NeedLeaderElection ensures only the leader scans and deletes records.
+func (gc *OrphanGC) NeedLeaderElection() bool {
+	return true
+}
+
+func (gc *OrphanGC) Start(ctx context.Context) error {
+	gc.firstSeen = map[string]time.Time{}
+	ticker := time.NewTicker(gc.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			gc.scan(ctx)
+		}
+	}
+}
+
+// scan checks the zones of every provider secret for orphaned records.
+func (gc *OrphanGC) scan(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("orphan_gc")
+
+	owners, err := gc.liveOwners(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list live owners, skipping scan")
+		return
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := gc.List(ctx, secretList); err != nil {
+		logger.Error(err, "Failed to list provider secrets, skipping scan")
+		return
+	}
+
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if !provider.IsProviderSecret(secret) {
+			continue
+		}
+		if err := gc.scanProvider(ctx, secret, owners); err != nil {
+			logger.Error(err, "Failed to scan provider zones", "secret", secret.Namespace+"/"+secret.Name)
+		}
+	}
+}
+
+// liveOwners returns the owner ids of all DNSRecords currently in the cluster.
+func (gc *OrphanGC) liveOwners(ctx context.Context) (map[string]struct{}, error) {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := gc.List(ctx, recordList); err != nil {
+		return nil, err
+	}
+	owners := map[string]struct{}{}
+	for i := range recordList.Items {
+		owners[recordList.Items[i].GetOwnerID()] = struct{}{}
+		// the published owner id can differ from the derived default, e.g.
+		// with stable owner ids enabled; never treat it as orphaned
+		if published := recordList.Items[i].Status.OwnerID; published != "" {
+			owners[published] = struct{}{}
+		}
+	}
+	return owners, nil
+}
+
+// scanProvider deletes any registry-owned records in the zones of the given
+// provider secret whose owner id has had no DNSRecord for the grace period.
+func (gc *OrphanGC) scanProvider(ctx context.Context, secret *corev1.Secret, owners map[string]struct{}) error {
+	logger := log.FromContext(ctx).WithName("orphan_gc")
+	secretKey := secret.Namespace + "/" + secret.Name
+
+	dnsProvider, err := gc.ProviderFactory.ProviderFor(ctx, secretAccessor{secret}, provider.Config{})
+	if err != nil {
+		return err
+	}
+
+	registry, err := registryForSecret(gc.Client, secret, dnsProvider, orphanGCOwnerID, gc.RegistryConfig)
+	if err != nil {
+		return err
+	}
+
+	records, err := registry.Records(ctx)
+	if err != nil {
+		return err
+	}
+
+	// heartbeats are plain TXT records, read them from the raw zone state
+	rawRecords, err := dnsProvider.Records(ctx)
+	if err != nil {
+		return err
+	}
+	heartbeats := heartbeatsFrom(rawRecords)
+
+	now := time.Now()
+	seen := map[string]struct{}{}
+	deadOwners := map[string]struct{}{}
+	orphaned := map[string][]*externaldnsendpoint.Endpoint{}
+	for _, record := range records {
+		owner := record.Labels[externaldnsendpoint.OwnerLabelKey]
+		if owner == "" {
+			// not a registry owned record (e.g. managed by an external
+			// tool with a different registry prefix), never touch it
+			continue
+		}
+		if _, ok := owners[owner]; ok {
+			continue
+		}
+		if heartbeat, ok := heartbeats[owner]; ok {
+			if gc.HeartbeatThreshold <= 0 || now.Sub(heartbeat) < gc.HeartbeatThreshold {
+				// owner belongs to another live cluster
+				continue
+			}
+			// owner is dead, its heartbeat stopped longer ago than the
+			// threshold, prune without the first-seen grace period
+			orphaned[owner] = append(orphaned[owner], record)
+			deadOwners[owner] = struct{}{}
+			continue
+		}
+		if gc.HeartbeatThreshold > 0 {
+			// with heartbeat based pruning in use, an owner with no
+			// heartbeat is indistinguishable from a live cluster that has
+			// not opted into heartbeats; never prune it
+			continue
+		}
+		key := secretKey + "#" + record.DNSName + "#" + record.SetIdentifier + "#" + owner
+		seen[key] = struct{}{}
+		first, ok := gc.firstSeen[key]
+		if !ok {
+			gc.firstSeen[key] = now
+			continue
+		}
+		if now.Sub(first) >= gc.GracePeriod {
+			orphaned[owner] = append(orphaned[owner], record)
+		}
+	}
+
+	// drop tracking for records that are no longer orphaned
+	for key := range gc.firstSeen {
+		if _, ok := seen[key]; !ok && len(key) > len(secretKey) && key[:len(secretKey)] == secretKey {
+			delete(gc.firstSeen, key)
+		}
+	}
+
+	if len(orphaned) == 0 {
+		return nil
+	}
+
+	// the registry only applies deletes for records of its own owner id, so
+	// deletes go through a registry per orphaned owner; this also removes the
+	// registry TXT records alongside the data records
+	for owner, records := range orphaned {
+		for _, record := range records {
+			logger.Info("Deleting orphaned record", "dnsName", record.DNSName, "owner", owner)
+		}
+		ownerRegistry, err := registryForSecret(gc.Client, secret, dnsProvider, owner, gc.RegistryConfig)
+		if err != nil {
+			return err
+		}
+		if err := ownerRegistry.ApplyChanges(ctx, &externaldnsplan.Changes{Delete: records}); err != nil {
+			return err
+		}
+	}
+
+	// remove the heartbeat records of pruned dead owners so they are not
+	// reconsidered on the next scan
+	var staleHeartbeats []*externaldnsendpoint.Endpoint
+	for _, record := range rawRecords {
+		if record.RecordType != externaldnsendpoint.RecordTypeTXT || !strings.HasPrefix(record.DNSName, heartbeatRecordPrefix) {
+			continue
+		}
+		owner, _, _ := strings.Cut(strings.TrimPrefix(record.DNSName, heartbeatRecordPrefix), ".")
+		if _, ok := deadOwners[owner]; ok {
+			staleHeartbeats = append(staleHeartbeats, record)
+		}
+	}
+	if len(staleHeartbeats) == 0 {
+		return nil
+	}
+	return dnsProvider.ApplyChanges(ctx, &externaldnsplan.Changes{Delete: staleHeartbeats})
+}
+
+// secretAccessor adapts a provider secret so it can be passed directly to a
+// provider factory.
+type secretAccessor struct {
+	secret *corev1.Secret
+}
+
+func (s secretAccessor) GetNamespace() string {
+	return s.secret.Namespace
+}
+
+func (s secretAccessor) GetProviderRef() v1alpha1.ProviderRef {
+	return v1alpha1.ProviderRef{Name: s.secret.Name}
+}
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider/inmemory"
+)
+
+// staticFactory returns the same provider for every secret.
+type staticFactory struct {
+	p provider.Provider
+}
+
+func (f staticFactory) ProviderFor(_ context.Context, _ provider.ProviderAccessor, _ provider.Config) (provider.Provider, error) {
+	return f.p, nil
+}
+
+// orphanGCFixture builds an in-memory provider seeded with a registry owned
+// record for the given owner, and a gc scanning it.
+func orphanGCFixture(t *testing.T, name, owner string) (*OrphanGC, *corev1.Secret, provider.Provider) {
+	t.Helper()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+		Data:       map[string][]byte{inmemory.ZonesKey: []byte("example.com")},
+	}
+	p, err := inmemory.NewProviderFromSecret(context.Background(), secret, provider.Config{})
+	if err != nil {
+		t.Fatalf("NewProviderFromSecret() error = %v", err)
+	}
+
+	registry, err := newTXTRegistry(p, owner, provider.RegistryConfig{})
+	if err != nil {
+		t.Fatalf("newTXTRegistry() error = %v", err)
+	}
+	if _, err := registry.Records(context.Background()); err != nil {
+		t.Fatalf("Records() error = %v", err)
+	}
+	err = registry.ApplyChanges(context.Background(), &externaldnsplan.Changes{
+		Create: []*externaldnsendpoint.Endpoint{
+			externaldnsendpoint.NewEndpoint("app.example.com", externaldnsendpoint.RecordTypeA, "1.2.3.4"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("ApplyChanges() error = %v", err)
+	}
+
+	gc := &OrphanGC{
+		ProviderFactory: staticFactory{p: p},
+		GracePeriod:     time.Hour,
+		firstSeen:       map[string]time.Time{},
+	}
+	return gc, secret, p
+}
+
+// hasRecord returns true if the provider holds a record with the dns name.
+func hasRecord(t *testing.T, p provider.Provider, dnsName string) bool {
+	t.Helper()
+	records, err := p.Records(context.Background())
+	if err != nil {
+		t.Fatalf("Records() error = %v", err)
+	}
+	for _, record := range records {
+		if record.DNSName == dnsName {
+			return true
+		}
+	}
+	return false
+}
+
+// writeHeartbeat writes a heartbeat record for the owner with the given age.
+func writeHeartbeat(t *testing.T, p provider.Provider, owner string, age time.Duration) {
+	t.Helper()
+	timestamp := time.Now().Add(-age).UTC().Format(time.RFC3339)
+	err := p.ApplyChanges(context.Background(), &externaldnsplan.Changes{
+		Create: []*externaldnsendpoint.Endpoint{
+			externaldnsendpoint.NewEndpointWithTTL(heartbeatRecordPrefix+owner+".example.com", externaldnsendpoint.RecordTypeTXT, heartbeatTTL, timestamp),
+		},
+	})
+	if err != nil {
+		t.Fatalf("ApplyChanges() error = %v", err)
+	}
+}
+
+func TestOrphanGC_GracePeriod(t *testing.T) {
+	gc, secret, p := orphanGCFixture(t, "gc-grace", "foreign-owner")
+	owners := map[string]struct{}{}
+
+	// the first scan only starts tracking the orphan
+	if err := gc.scanProvider(context.Background(), secret, owners); err != nil {
+		t.Fatalf("scanProvider() error = %v", err)
+	}
+	if !hasRecord(t, p, "app.example.com") {
+		t.Fatal("scanProvider() deleted a record before the grace period passed")
+	}
+	if len(gc.firstSeen) == 0 {
+		t.Fatal("scanProvider() did not start tracking the orphaned record")
+	}
+
+	// a second scan within the grace period keeps the record
+	if err := gc.scanProvider(context.Background(), secret, owners); err != nil {
+		t.Fatalf("scanProvider() error = %v", err)
+	}
+	if !hasRecord(t, p, "app.example.com") {
+		t.Fatal("scanProvider() deleted a record within the grace period")
+	}
+
+	// once the grace period has passed the record is pruned
+	for key := range gc.firstSeen {
+		gc.firstSeen[key] = time.Now().Add(-2 * time.Hour)
+	}
+	if err := gc.scanProvider(context.Background(), secret, owners); err != nil {
+		t.Fatalf("scanProvider() error = %v", err)
+	}
+	if hasRecord(t, p, "app.example.com") {
+		t.Fatal("scanProvider() did not delete the record after the grace period")
+	}
+}
+
+func TestOrphanGC_LiveOwnerKept(t *testing.T) {
+	gc, secret, p := orphanGCFixture(t, "gc-live", "local-owner")
+	owners := map[string]struct{}{"local-owner": {}}
+
+	for i := 0; i < 2; i++ {
+		if err := gc.scanProvider(context.Background(), secret, owners); err != nil {
+			t.Fatalf("scanProvider() error = %v", err)
+		}
+	}
+	if !hasRecord(t, p, "app.example.com") {
+		t.Fatal("scanProvider() deleted a record whose owner is live in the cluster")
+	}
+	if len(gc.firstSeen) != 0 {
+		t.Errorf("scanProvider() tracked a live owners record as orphaned: %v", gc.firstSeen)
+	}
+}
+
+func TestOrphanGC_LiveHeartbeatKept(t *testing.T) {
+	gc, secret, p := orphanGCFixture(t, "gc-heartbeat-live", "other-cluster")
+	gc.HeartbeatThreshold = time.Hour
+	writeHeartbeat(t, p, "other-cluster", time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if err := gc.scanProvider(context.Background(), secret, map[string]struct{}{}); err != nil {
+			t.Fatalf("scanProvider() error = %v", err)
+		}
+	}
+	if !hasRecord(t, p, "app.example.com") {
+		t.Fatal("scanProvider() deleted a record of an owner with a fresh heartbeat")
+	}
+}
+
+func TestOrphanGC_DeadOwnerPruned(t *testing.T) {
+	gc, secret, p := orphanGCFixture(t, "gc-heartbeat-dead", "dead-cluster")
+	gc.HeartbeatThreshold = time.Hour
+	writeHeartbeat(t, p, "dead-cluster", 2*time.Hour)
+
+	if err := gc.scanProvider(context.Background(), secret, map[string]struct{}{}); err != nil {
+		t.Fatalf("scanProvider() error = %v", err)
+	}
+	if hasRecord(t, p, "app.example.com") {
+		t.Fatal("scanProvider() did not prune the records of an owner with a stale heartbeat")
+	}
+
+	// the dead owners heartbeat record is removed with its records
+	records, err := p.Records(context.Background())
+	if err != nil {
+		t.Fatalf("Records() error = %v", err)
+	}
+	for _, record := range records {
+		if strings.HasPrefix(record.DNSName, heartbeatRecordPrefix) {
+			t.Errorf("scanProvider() left the dead owners heartbeat record %s behind", record.DNSName)
+		}
+	}
+}
+
+func TestOrphanGC_HeartbeatlessOwnerKept(t *testing.T) {
+	gc, secret, p := orphanGCFixture(t, "gc-heartbeatless", "silent-cluster")
+	gc.HeartbeatThreshold = time.Hour
+
+	// repeated scans never prune an owner that has no heartbeat at all, it
+	// may be a live cluster that has not opted into heartbeats
+	for i := 0; i < 3; i++ {
+		if err := gc.scanProvider(context.Background(), secret, map[string]struct{}{}); err != nil {
+			t.Fatalf("scanProvider() error = %v", err)
+		}
+	}
+	if !hasRecord(t, p, "app.example.com") {
+		t.Fatal("scanProvider() pruned a foreign owner without a heartbeat")
+	}
+	if len(gc.firstSeen) != 0 {
+		t.Errorf("scanProvider() tracked a heartbeat-less owner for grace period pruning: %v", gc.firstSeen)
+	}
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultProviderHealthThreshold is how recent a successful provider
+// interaction must be for the provider to count as reachable.
+const DefaultProviderHealthThreshold = 10 * time.Minute
+
+// ProviderHealth tracks the last successful and failed interaction per
+// active provider secret and exposes a readiness check that fails when a
+// provider keeps failing with no recent success, so platform automation
+// detects credential outages quickly.
+type ProviderHealth struct {
+	// Threshold is how recent a success must be to outweigh later
+	// failures. Defaults to DefaultProviderHealthThreshold.
+	Threshold time.Duration
+
+	mu                  sync.Mutex
+	lastSuccess         map[string]time.Time
+	lastFailure         map[string]time.Time
+	consecutiveFailures map[string]int
+}
+
+// NewProviderHealth returns a tracker with the given success threshold.
+func NewProviderHealth(threshold time.Duration) *ProviderHealth {
+	if threshold <= 0 {
+		threshold = DefaultProviderHealthThreshold
+	}
+	return &ProviderHealth{
+		Threshold:           threshold,
+		lastSuccess:         map[string]time.Time{},
+		lastFailure:         map[string]time.Time{},
+		consecutiveFailures: map[string]int{},
+	}
+}
+
+// RecordSuccess notes a successful interaction with the given provider
+// secret (namespace/name).
+func (h *ProviderHealth) RecordSuccess(provider string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastSuccess[provider] = time.Now()
+	delete(h.lastFailure, provider)
+	h.consecutiveFailures[provider] = 0
+	providerConsecutiveFailuresGauge.WithLabelValues(provider).Set(0)
+}
+
+// RecordFailure notes a failed interaction with the given provider secret.
+func (h *ProviderHealth) RecordFailure(provider string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastFailure[provider] = time.Now()
+	h.consecutiveFailures[provider]++
+	providerConsecutiveFailuresGauge.WithLabelValues(provider).Set(float64(h.consecutiveFailures[provider]))
+}
+
+// RecordZoneList notes the outcome of a zone list through the given provider
+// secret, feeding the dashboard gauges.
+func (h *ProviderHealth) RecordZoneList(provider string, err error) {
+	if err != nil {
+		h.RecordFailure(provider)
+		return
+	}
+	h.RecordSuccess(provider)
+	providerLastZoneListGauge.WithLabelValues(provider).SetToCurrentTime()
+}
+
+// RecordApply notes the outcome of a change apply through the given provider
+// secret, feeding the dashboard gauges.
+func (h *ProviderHealth) RecordApply(provider string, err error) {
+	if err != nil {
+		h.RecordFailure(provider)
+		return
+	}
+	h.RecordSuccess(provider)
+	providerLastApplyGauge.WithLabelValues(provider).SetToCurrentTime()
+}
+
+// Check is a healthz.Checker failing when any active provider has a recent
+// failure and no success within the threshold. Providers idle for more than
+// twice the threshold are forgotten, so decommissioned secrets do not keep
+// the instance unready.
+func (h *ProviderHealth) Check(_ *http.Request) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var unreachable []string
+	for provider, failedAt := range h.lastFailure {
+		if time.Since(failedAt) > 2*h.Threshold {
+			delete(h.lastFailure, provider)
+			continue
+		}
+		if succeededAt, ok := h.lastSuccess[provider]; ok && succeededAt.After(failedAt) && time.Since(succeededAt) <= h.Threshold {
+			continue
+		}
+		unreachable = append(unreachable, provider)
+	}
+	for provider, succeededAt := range h.lastSuccess {
+		if time.Since(succeededAt) > 2*h.Threshold {
+			delete(h.lastSuccess, provider)
+			delete(h.consecutiveFailures, provider)
+		}
+	}
+
+	if len(unreachable) > 0 {
+		sort.Strings(unreachable)
+		return fmt.Errorf("providers unreachable: %s", strings.Join(unreachable, ", "))
+	}
+	return nil
+}
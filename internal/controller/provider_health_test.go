@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProviderHealth(t *testing.T) {
+	health := NewProviderHealth(time.Minute)
+
+	// nothing tracked yet, the instance is ready
+	if err := health.Check(nil); err != nil {
+		t.Errorf("Check() unexpected error: %v", err)
+	}
+
+	health.RecordSuccess("ns/aws-credentials")
+	if err := health.Check(nil); err != nil {
+		t.Errorf("Check() unexpected error after success: %v", err)
+	}
+
+	health.RecordFailure("ns/aws-credentials")
+	err := health.Check(nil)
+	if err == nil || !strings.Contains(err.Error(), "ns/aws-credentials") {
+		t.Errorf("Check() expected unreachable provider error, got: %v", err)
+	}
+
+	// a new success clears the failure
+	health.RecordSuccess("ns/aws-credentials")
+	if err := health.Check(nil); err != nil {
+		t.Errorf("Check() unexpected error after recovery: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+const (
+	// ProviderStatusAnnotation reports whether the credentials of a
+	// provider secret could reach the provider, "ready" or "error".
+	ProviderStatusAnnotation = "kuadrant.io/provider-status"
+	// ProviderZonesAnnotation lists the zones discoverable through the
+	// provider secret, so users can see what the operator can target
+	// before creating DNSRecords.
+	ProviderZonesAnnotation = "kuadrant.io/provider-zones"
+	// ProviderErrorAnnotation carries the validation error when the secret
+	// schema is invalid or the provider is unreachable.
+	ProviderErrorAnnotation = "kuadrant.io/provider-error"
+
+	// providerSecretRecheckInterval is how often validated secrets are
+	// re-checked, catching revoked credentials and zone changes.
+	providerSecretRecheckInterval = 10 * time.Minute
+)
+
+// ProviderSecretReconciler validates provider secrets as they are created or
+// updated, instead of only when a DNSRecord first uses them. It checks the
+// secret schema, verifies the credentials can reach the provider and
+// annotates the secret with the outcome and the discovered zones.
+type ProviderSecretReconciler struct {
+	client.Client
+	ProviderFactory provider.Factory
+}
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsproviderzones,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kuadrant.io,resources=dnsproviderzones/status,verbs=get;update;patch
+
+func (r *ProviderSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, req.NamespacedName, secret); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !provider.IsProviderSecret(secret) || (secret.DeletionTimestamp != nil && !secret.DeletionTimestamp.IsZero()) {
+		return ctrl.Result{}, nil
+	}
+
+	status, zones, validationErr := r.validate(ctx, secret)
+
+	annotations := map[string]string{ProviderStatusAnnotation: status}
+	if len(zones) > 0 {
+		annotations[ProviderZonesAnnotation] = strings.Join(zones, ",")
+	}
+	if validationErr != nil {
+		annotations[ProviderErrorAnnotation] = provider.SanitizeError(validationErr).Error()
+	}
+
+	if err := r.reconcileProviderZones(ctx, secret, zones, validationErr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !annotationsEqual(secret.Annotations, annotations) {
+		updated := secret.DeepCopy()
+		if updated.Annotations == nil {
+			updated.Annotations = map[string]string{}
+		}
+		delete(updated.Annotations, ProviderZonesAnnotation)
+		delete(updated.Annotations, ProviderErrorAnnotation)
+		for key, value := range annotations {
+			updated.Annotations[key] = value
+		}
+		if err := r.Update(ctx, updated); err != nil {
+			if apierrors.IsConflict(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		logger.Info("Validated provider secret", "status", status)
+	}
+
+	return ctrl.Result{RequeueAfter: providerSecretRecheckInterval}, nil
+}
+
+// reconcileProviderZones maintains the companion DNSProviderZones resource
+// of the secret, surfacing the discovered zones to users.
+func (r *ProviderSecretReconciler) reconcileProviderZones(ctx context.Context, secret *corev1.Secret, zones []string, validationErr error) error {
+	providerZones := &v1alpha1.DNSProviderZones{}
+	providerZones.Namespace = secret.Namespace
+	providerZones.Name = secret.Name
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, providerZones, func() error {
+		providerZones.Spec.ProviderRef = v1alpha1.ProviderRef{Name: secret.Name}
+		return controllerutil.SetControllerReference(secret, providerZones, r.Scheme())
+	}); err != nil {
+		return err
+	}
+
+	status := v1alpha1.DNSProviderZonesStatus{ObservedAt: metav1.Now()}
+	for _, zone := range zones {
+		status.Zones = append(status.Zones, v1alpha1.ProviderZone{ID: zone, DNSName: zone})
+	}
+	if validationErr != nil {
+		status.Error = provider.SanitizeError(validationErr).Error()
+	}
+	if equality.Semantic.DeepEqual(providerZones.Status.Zones, status.Zones) && providerZones.Status.Error == status.Error {
+		return nil
+	}
+	providerZones.Status = status
+	return r.Status().Update(ctx, providerZones)
+}
+
+// validate checks the secret schema and provider reachability, returning the
+// status, the discovered zones and any validation error.
+func (r *ProviderSecretReconciler) validate(ctx context.Context, secret *corev1.Secret) (string, []string, error) {
+	if _, err := provider.NameForProviderSecret(secret); err != nil {
+		return "error", nil, err
+	}
+
+	dnsProvider, err := r.ProviderFactory.ProviderFor(ctx, secretAccessor{secret: secret}, provider.Config{})
+	if err != nil {
+		return "error", nil, fmt.Errorf("creating provider: %w", err)
+	}
+
+	dnsZones, err := dnsProvider.DNSZones(ctx)
+	if err != nil {
+		return "error", nil, fmt.Errorf("listing zones: %w", err)
+	}
+	zones := make([]string, 0, len(dnsZones))
+	for _, zone := range dnsZones {
+		zones = append(zones, zone.DNSName)
+	}
+	return "ready", zones, nil
+}
+
+// annotationsEqual returns true if all wanted annotations already hold, and
+// the error and zones annotations carry no stale values.
+func annotationsEqual(current, wanted map[string]string) bool {
+	for _, key := range []string{ProviderStatusAnnotation, ProviderZonesAnnotation, ProviderErrorAnnotation} {
+		if current[key] != wanted[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ProviderSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}, ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			secret, ok := object.(*corev1.Secret)
+			return ok && provider.IsProviderSecret(secret)
+		}))).
+		Owns(&v1alpha1.DNSProviderZones{}).
+		Complete(r)
+}
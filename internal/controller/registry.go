@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+	externaldnsregistry "sigs.k8s.io/external-dns/registry"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/registry"
+)
+
+// newTXTRegistry returns a TXT registry for the given provider and owner id
+// using the given registry format.
+func newTXTRegistry(dnsProvider provider.Provider, ownerID string, cfg provider.RegistryConfig) (externaldnsregistry.Registry, error) {
+	if cfg.TXTTTL > 0 {
+		dnsProvider = &registryTTLProvider{Provider: dnsProvider, ttl: externaldnsendpoint.TTL(cfg.TXTTTL)}
+	}
+	return externaldnsregistry.NewTXTRegistry(dnsProvider, cfg.TXTPrefix, cfg.TXTSuffix, ownerID,
+		0, cfg.TXTWildcardReplacement, managedDNSRecordTypes, nil, cfg.TXTEncryptEnabled, cfg.TXTEncryptAESKey)
+}
+
+// registryTTLProvider sits between the TXT registry and the provider and
+// applies the configured TTL to the registry TXT records the registry
+// generates, which otherwise carry no TTL and fall back to a provider
+// default that may differ from the data records and read as drift. Registry
+// TXT records are identified by the owned-record label the registry stamps
+// on them; data TXT records pass through untouched.
+type registryTTLProvider struct {
+	provider.Provider
+	ttl externaldnsendpoint.TTL
+}
+
+// Unwrap exposes the decorated provider to capability lookups.
+func (p *registryTTLProvider) Unwrap() provider.Provider {
+	return p.Provider
+}
+
+func (p *registryTTLProvider) ApplyChanges(ctx context.Context, changes *externaldnsplan.Changes) error {
+	// stamp the configured TTL on the registry TXT records of all four
+	// change lists, the old sides and deletes too, so the submitted batch
+	// matches the zone state and a TTL-only difference never churns
+	for _, endpoints := range [][]*externaldnsendpoint.Endpoint{changes.Create, changes.UpdateNew, changes.UpdateOld, changes.Delete} {
+		for _, ep := range endpoints {
+			if ep.RecordType != externaldnsendpoint.RecordTypeTXT {
+				continue
+			}
+			if _, owned := ep.Labels[externaldnsendpoint.OwnedRecordLabelKey]; owned {
+				ep.RecordTTL = p.ttl
+			}
+		}
+	}
+	return p.Provider.ApplyChanges(ctx, changes)
+}
+
+// registryForSecret returns the registry for the given provider secret and
+// owner id, selecting the backend and format from the defaults with any
+// secret overrides applied.
+func registryForSecret(c client.Client, secret *corev1.Secret, dnsProvider provider.Provider, ownerID string, defaults provider.RegistryConfig) (externaldnsregistry.Registry, error) {
+	cfg := defaults.WithSecretOverrides(secret)
+	switch cfg.Backend {
+	case provider.RegistryBackendCRD:
+		return registry.NewCRDRegistry(c, secret.Namespace, secret.Name, dnsProvider, ownerID), nil
+	case provider.RegistryBackendTXT, "":
+		return newTXTRegistry(dnsProvider, ownerID, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported registry backend %q", cfg.Backend)
+	}
+}
+
+// registryFor returns the registry for the provider secret referenced by pa.
+func registryFor(ctx context.Context, c client.Client, pa provider.ProviderAccessor, dnsProvider provider.Provider, ownerID string, defaults provider.RegistryConfig) (externaldnsregistry.Registry, error) {
+	providerSecret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: pa.GetProviderRef().Name, Namespace: pa.GetNamespace()}, providerSecret); err != nil {
+		return nil, err
+	}
+	return registryForSecret(c, providerSecret, dnsProvider, ownerID, defaults)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// capturingProvider records the changes applied through it.
+type capturingProvider struct {
+	provider.Provider
+	applied *externaldnsplan.Changes
+}
+
+func (p *capturingProvider) ApplyChanges(_ context.Context, changes *externaldnsplan.Changes) error {
+	p.applied = changes
+	return nil
+}
+
+func TestRegistryTTLProvider(t *testing.T) {
+	captured := &capturingProvider{}
+	ttlProvider := &registryTTLProvider{Provider: captured, ttl: externaldnsendpoint.TTL(300)}
+
+	registryTXT := &externaldnsendpoint.Endpoint{
+		DNSName:    "kuadrant-a-app.example.com",
+		RecordType: externaldnsendpoint.RecordTypeTXT,
+		Labels:     map[string]string{externaldnsendpoint.OwnedRecordLabelKey: "app.example.com"},
+	}
+	dataTXT := &externaldnsendpoint.Endpoint{
+		DNSName:    "txt.app.example.com",
+		RecordType: externaldnsendpoint.RecordTypeTXT,
+		RecordTTL:  externaldnsendpoint.TTL(60),
+	}
+	dataA := &externaldnsendpoint.Endpoint{
+		DNSName:    "app.example.com",
+		RecordType: externaldnsendpoint.RecordTypeA,
+	}
+
+	if err := ttlProvider.ApplyChanges(context.Background(), &externaldnsplan.Changes{
+		Create: []*externaldnsendpoint.Endpoint{registryTXT, dataTXT, dataA},
+	}); err != nil {
+		t.Fatalf("ApplyChanges() error = %v", err)
+	}
+	if captured.applied == nil {
+		t.Fatal("changes were not passed through to the provider")
+	}
+	if registryTXT.RecordTTL != 300 {
+		t.Errorf("registry TXT TTL = %d, want 300", registryTXT.RecordTTL)
+	}
+	if dataTXT.RecordTTL != 60 {
+		t.Errorf("data TXT TTL = %d, want it untouched at 60", dataTXT.RecordTTL)
+	}
+	if dataA.RecordTTL != 0 {
+		t.Errorf("data A TTL = %d, want it untouched at 0", dataA.RecordTTL)
+	}
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// RegistryWatcher periodically reads the registry TXT state of every
+// provider secrets zones and triggers reconciles of the records publishing
+// through a secret when that state changes out of band. Without it a record
+// waiting on a conflict resolved by a remote owner stays not Ready until
+// its periodic requeue fires; the cheap TXT poll recovers it promptly.
+type RegistryWatcher struct {
+	client.Client
+	ProviderFactory provider.Factory
+
+	// Interval between zone polls.
+	Interval time.Duration
+
+	events chan event.GenericEvent
+	// state holds the last observed registry hash per provider secret.
+	state map[string]uint64
+}
+
+var _ manager.Runnable = &RegistryWatcher{}
+
+// NewRegistryWatcher returns a watcher polling provider zones at the given
+// interval.
+func NewRegistryWatcher(c client.Client, factory provider.Factory, interval time.Duration) *RegistryWatcher {
+	return &RegistryWatcher{
+		Client:          c,
+		ProviderFactory: factory,
+		Interval:        interval,
+		events:          make(chan event.GenericEvent),
+		state:           map[string]uint64{},
+	}
+}
+
+// Events is the source of reconcile triggers for records whose registry
+// state changed.
+func (w *RegistryWatcher) Events() <-chan event.GenericEvent {
+	return w.events
+}
+
+// NeedLeaderElection ensures only the leader polls provider zones, a poll
+// per replica would multiply provider API usage.
+func (w *RegistryWatcher) NeedLeaderElection() bool {
+	return true
+}
+
+func (w *RegistryWatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// poll hashes the registry TXT records of every provider secrets zones and
+// enqueues the records of secrets whose hash changed since the last poll.
+func (w *RegistryWatcher) poll(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("registry_watch")
+
+	secretList := &corev1.SecretList{}
+	if err := w.List(ctx, secretList); err != nil {
+		logger.Error(err, "Failed to list provider secrets, skipping poll")
+		return
+	}
+
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if !provider.IsProviderSecret(secret) {
+			continue
+		}
+		secretKey := secret.Namespace + "/" + secret.Name
+
+		current, err := w.registryHash(ctx, secret)
+		if err != nil {
+			logger.Error(err, "Failed to read registry state", "secret", secretKey)
+			continue
+		}
+
+		previous, seen := w.state[secretKey]
+		w.state[secretKey] = current
+		// the first observation establishes the baseline, records already
+		// reconcile on startup
+		if !seen || previous == current {
+			continue
+		}
+		logger.V(1).Info("Registry state changed, triggering reconciles", "secret", secretKey)
+		w.enqueueRecords(ctx, secret)
+	}
+}
+
+// registryHash returns a hash over the registry TXT records in the zones of
+// the given provider secret, the cheapest observable signal that any owner
+// changed what it publishes there.
+func (w *RegistryWatcher) registryHash(ctx context.Context, secret *corev1.Secret) (uint64, error) {
+	dnsProvider, err := w.ProviderFactory.ProviderFor(ctx, secretAccessor{secret}, provider.Config{})
+	if err != nil {
+		return 0, err
+	}
+	rawRecords, err := dnsProvider.Records(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var entries []string
+	for _, record := range rawRecords {
+		if record.RecordType != externaldnsendpoint.RecordTypeTXT {
+			continue
+		}
+		values := strings.Join(record.Targets, ",")
+		if !strings.Contains(values, heritageValue) {
+			continue
+		}
+		entries = append(entries, record.DNSName+"="+values)
+	}
+	sort.Strings(entries)
+
+	digest := fnv.New64a()
+	for _, entry := range entries {
+		_, _ = digest.Write([]byte(entry))
+	}
+	return digest.Sum64(), nil
+}
+
+// enqueueRecords triggers a reconcile of every record publishing through the
+// given provider secret.
+func (w *RegistryWatcher) enqueueRecords(ctx context.Context, secret *corev1.Secret) {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := w.List(ctx, recordList,
+		client.InNamespace(secret.Namespace),
+		client.MatchingFields{ProviderRefNameIndex: secret.Name}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list DNSRecords for provider secret", "secret", secret.Namespace+"/"+secret.Name)
+		return
+	}
+	for i := range recordList.Items {
+		record := &recordList.Items[i]
+		select {
+		case w.events <- event.GenericEvent{Object: &v1alpha1.DNSRecord{
+			ObjectMeta: metav1.ObjectMeta{Namespace: record.Namespace, Name: record.Name},
+		}}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
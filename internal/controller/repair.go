@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// RepairScan re-applies every published DNSRecord against its provider zone,
+// correcting drift caused by out-of-band edits (a weight changed in the
+// console, a target removed by hand). It can run once on startup and on a
+// periodic interval, and counts corrections per zone so drift is visible.
+type RepairScan struct {
+	Reconciler *DNSRecordReconciler
+
+	// OnStart runs a scan as soon as this instance becomes leader.
+	OnStart bool
+	// Interval between periodic scans. Zero disables periodic scanning.
+	Interval time.Duration
+}
+
+var _ manager.Runnable = &RepairScan{}
+
+// NeedLeaderElection ensures only the leader repairs zones.
+func (s *RepairScan) NeedLeaderElection() bool {
+	return true
+}
+
+func (s *RepairScan) Start(ctx context.Context) error {
+	if s.OnStart {
+		s.scan(ctx)
+	}
+	if s.Interval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.scan(ctx)
+		}
+	}
+}
+
+// scan applies the current spec of every published record. A record whose
+// spec has not changed only produces changes when the zone drifted from the
+// last applied state.
+func (s *RepairScan) scan(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("repair")
+	logger.Info("Scanning zones for drift")
+
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := s.Reconciler.List(ctx, recordList); err != nil {
+		logger.Error(err, "Failed to list DNSRecords, skipping repair scan")
+		return
+	}
+
+	for i := range recordList.Items {
+		record := recordList.Items[i].DeepCopy()
+		if record.IsPaused() || record.Status.ZoneDomainName == "" {
+			// never published, or deliberately hands-off
+			continue
+		}
+		if record.DeletionTimestamp != nil && !record.DeletionTimestamp.IsZero() {
+			continue
+		}
+		hadChanges, err := s.Reconciler.applyChanges(ctx, record, false)
+		if err != nil {
+			logger.Error(err, "Failed to repair record", "dnsrecord", record.Namespace+"/"+record.Name)
+			continue
+		}
+		if hadChanges {
+			driftCorrectionsCounter.WithLabelValues(record.Status.ZoneDomainName).Inc()
+			logger.Info("Corrected zone drift", "dnsrecord", record.Namespace+"/"+record.Name, "zone", record.Status.ZoneDomainName)
+		}
+	}
+}
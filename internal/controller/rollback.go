@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// applyRollback substitutes the spec endpoints with a known-good set from
+// the applied-change history when the rollback annotation is set. The swap
+// is in-memory only, the stored spec stays intact; the RolledBack condition
+// flags that published DNS intentionally diverges from it until the spec is
+// corrected and the annotation removed.
+func (r *DNSRecordReconciler) applyRollback(dnsRecord *v1alpha1.DNSRecord) {
+	target, requested := dnsRecord.GetRollbackTarget()
+	if !requested {
+		meta.RemoveStatusCondition(&dnsRecord.Status.Conditions, v1alpha1.ConditionTypeRolledBack)
+		return
+	}
+
+	entry := rollbackEntry(dnsRecord, target)
+	if entry == nil {
+		// keep publishing the current spec, the condition names the problem
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeRolledBack, metav1.ConditionFalse, v1alpha1.ConditionReasonRollbackFailed,
+			fmt.Sprintf("No applied-change history entry matches the rollback target %q", target))
+		return
+	}
+
+	dnsRecord.Spec.Endpoints = entry.Endpoints
+	dnsRecord.Spec.EndpointsFrom = nil
+	dnsRecord.Spec.TargetsFrom = nil
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeRolledBack, metav1.ConditionTrue, v1alpha1.ConditionReasonRollbackActive,
+		fmt.Sprintf("Publishing the endpoints applied at %s for generation %d instead of the current spec; correct the spec and remove the %s annotation",
+			entry.Time.UTC().Format(time.RFC3339), entry.Generation, v1alpha1.RollbackAnnotation))
+}
+
+// rollbackEntry selects the history entry the rollback target names: a
+// generation number selects the set applied for that generation, anything
+// else the newest set applied for a generation older than the current one.
+func rollbackEntry(dnsRecord *v1alpha1.DNSRecord, target string) *v1alpha1.AppliedChange {
+	history := dnsRecord.Status.AppliedChanges
+	if generation, err := strconv.ParseInt(target, 10, 64); err == nil {
+		for i := range history {
+			if history[i].Generation == generation && len(history[i].Endpoints) > 0 {
+				return &history[i]
+			}
+		}
+		return nil
+	}
+	for i := range history {
+		if history[i].Generation < dnsRecord.Generation && len(history[i].Endpoints) > 0 {
+			return &history[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+func TestRollbackEntry(t *testing.T) {
+	endpoints := func(target string) []*externaldnsendpoint.Endpoint {
+		return []*externaldnsendpoint.Endpoint{{DNSName: "app.example.com", RecordType: "A", Targets: []string{target}}}
+	}
+	record := &v1alpha1.DNSRecord{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Status: v1alpha1.DNSRecordStatus{
+			AppliedChanges: []v1alpha1.AppliedChange{
+				{Generation: 3, Endpoints: endpoints("3.3.3.3")},
+				{Generation: 2, Endpoints: endpoints("2.2.2.2")},
+				{Generation: 1, Endpoints: endpoints("1.1.1.1")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantTarget string
+		wantNil    bool
+	}{
+		{
+			name:       "previous selects the newest earlier generation",
+			target:     "previous",
+			wantTarget: "2.2.2.2",
+		},
+		{
+			name:       "true behaves like previous",
+			target:     "true",
+			wantTarget: "2.2.2.2",
+		},
+		{
+			name:       "a generation number selects that generation",
+			target:     "1",
+			wantTarget: "1.1.1.1",
+		},
+		{
+			name:    "an unknown generation matches nothing",
+			target:  "7",
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := rollbackEntry(record, tt.target)
+			if tt.wantNil {
+				if entry != nil {
+					t.Fatalf("rollbackEntry() = generation %d, want nil", entry.Generation)
+				}
+				return
+			}
+			if entry == nil {
+				t.Fatal("rollbackEntry() = nil, want an entry")
+			}
+			if got := entry.Endpoints[0].Targets[0]; got != tt.wantTarget {
+				t.Errorf("rollbackEntry() selected target %s, want %s", got, tt.wantTarget)
+			}
+		})
+	}
+
+	t.Run("no history matches before the current generation", func(t *testing.T) {
+		first := &v1alpha1.DNSRecord{
+			ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			Status: v1alpha1.DNSRecordStatus{
+				AppliedChanges: []v1alpha1.AppliedChange{{Generation: 1, Endpoints: endpoints("1.1.1.1")}},
+			},
+		}
+		if entry := rollbackEntry(first, "previous"); entry != nil {
+			t.Errorf("rollbackEntry() = generation %d, want nil", entry.Generation)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"hash/fnv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ShardConfig assigns DNSRecords to operator replicas by a consistent hash
+// of their namespace/name, allowing very large fleets to spread
+// reconciliation over multiple replicas instead of a single leader doing all
+// the work. Replicas run with the same count and distinct indexes.
+type ShardConfig struct {
+	// Index of this replica, in [0, Count).
+	Index int
+	// Count of replicas sharing the work. Zero or one disables sharding.
+	Count int
+}
+
+// Enabled returns true if reconciliation is sharded over multiple replicas.
+func (c ShardConfig) Enabled() bool {
+	return c.Count > 1
+}
+
+// Owns returns true if the given object is assigned to this replica.
+func (c ShardConfig) Owns(obj client.Object) bool {
+	if !c.Enabled() {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(obj.GetNamespace() + "/" + obj.GetName()))
+	return int(h.Sum32())%c.Count == c.Index
+}
+
+// Predicate filters watch events down to the objects assigned to this
+// replica.
+func (c ShardConfig) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(c.Owns)
+}
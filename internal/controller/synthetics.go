@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// syntheticTimeout bounds the whole synthetic check, resolution and GET.
+const syntheticTimeout = 10 * time.Second
+
+// runSyntheticCheck performs the post-publish HTTP GET against the rootHost
+// when the record requests one, surfacing the outcome as the Reachable
+// condition. The rootHost address is resolved through the zones
+// authoritative nameservers, so the check observes what the provider
+// actually serves rather than a cached answer.
+func (r *DNSRecordReconciler) runSyntheticCheck(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) {
+	check := dnsRecord.Spec.SyntheticCheck
+	if check == nil {
+		meta.RemoveStatusCondition(&dnsRecord.Status.Conditions, v1alpha1.ConditionTypeReachable)
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, syntheticTimeout)
+	defer cancel()
+
+	rootHost := dnsRecord.GetRootHost()
+	address, err := authoritativeAddress(ctx, rootHost, dnsRecord.Status.ZoneDomainName)
+	if err != nil {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReachable, metav1.ConditionFalse, v1alpha1.ConditionReasonHostUnreachable,
+			fmt.Sprintf("Resolving %s through the authoritative nameservers failed: %v", rootHost, err))
+		return
+	}
+
+	scheme, port := "https", 443
+	if check.Protocol == v1alpha1.HttpProtocol {
+		scheme, port = "http", 80
+	}
+	if check.Port > 0 {
+		port = check.Port
+	}
+	path := check.Path
+	if path == "" {
+		path = "/"
+	}
+
+	status, err := syntheticGet(ctx, scheme, rootHost, address, port, path)
+	if err != nil {
+		logger.V(1).Info("Synthetic check failed", "rootHost", rootHost, "address", address, "error", err)
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReachable, metav1.ConditionFalse, v1alpha1.ConditionReasonHostUnreachable,
+			fmt.Sprintf("GET %s://%s%s against %s failed: %v", scheme, rootHost, path, address, err))
+		return
+	}
+	if status >= 400 {
+		setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReachable, metav1.ConditionFalse, v1alpha1.ConditionReasonHostUnreachable,
+			fmt.Sprintf("GET %s://%s%s against %s returned status %d", scheme, rootHost, path, address, status))
+		return
+	}
+	setDNSRecordCondition(dnsRecord, v1alpha1.ConditionTypeReachable, metav1.ConditionTrue, v1alpha1.ConditionReasonHostReachable,
+		fmt.Sprintf("GET %s://%s%s returned status %d", scheme, rootHost, path, status))
+}
+
+// authoritativeAddress resolves the rootHost to an address by querying an
+// authoritative nameserver of the zone directly. CNAME chains leaving the
+// zone are followed through the system resolver, the authoritative server
+// does not recurse.
+func authoritativeAddress(ctx context.Context, rootHost, zone string) (string, error) {
+	if zone == "" {
+		return "", fmt.Errorf("the record has no zone domain in status yet")
+	}
+	config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil {
+		return "", fmt.Errorf("reading resolver configuration: %w", err)
+	}
+	if len(config.Servers) == 0 {
+		return "", fmt.Errorf("no resolvers configured")
+	}
+	resolver := config.Servers[0] + ":" + config.Port
+	dnsClient := &dns.Client{Timeout: syntheticTimeout}
+
+	nsQuery := new(dns.Msg)
+	nsQuery.SetQuestion(dns.Fqdn(zone), dns.TypeNS)
+	nsResponse, _, err := dnsClient.ExchangeContext(ctx, nsQuery, resolver)
+	if err != nil {
+		return "", fmt.Errorf("querying the nameservers of %s: %w", zone, err)
+	}
+	var server string
+	for _, rr := range nsResponse.Answer {
+		if ns, ok := rr.(*dns.NS); ok {
+			server = net.JoinHostPort(strings.TrimSuffix(ns.Ns, "."), "53")
+			break
+		}
+	}
+	if server == "" {
+		return "", fmt.Errorf("no nameservers found for %s", zone)
+	}
+
+	aQuery := new(dns.Msg)
+	aQuery.SetQuestion(dns.Fqdn(rootHost), dns.TypeA)
+	aResponse, _, err := dnsClient.ExchangeContext(ctx, aQuery, server)
+	if err != nil {
+		return "", fmt.Errorf("querying %s on %s: %w", rootHost, server, err)
+	}
+	cname := ""
+	for _, rr := range aResponse.Answer {
+		switch record := rr.(type) {
+		case *dns.A:
+			return record.A.String(), nil
+		case *dns.CNAME:
+			cname = strings.TrimSuffix(record.Target, ".")
+		}
+	}
+	if cname != "" {
+		// the chain leaves the zone, resolve the rest recursively
+		addresses, err := net.DefaultResolver.LookupHost(ctx, cname)
+		if err != nil {
+			return "", fmt.Errorf("resolving the CNAME target %s: %w", cname, err)
+		}
+		return addresses[0], nil
+	}
+	return "", fmt.Errorf("the authoritative nameserver %s has no address for %s", server, rootHost)
+}
+
+// syntheticGet performs the GET against the resolved address while
+// requesting, and TLS verifying, the rootHost.
+func syntheticGet(ctx context.Context, scheme, rootHost, address string, port int, path string) (int, error) {
+	hostPort := net.JoinHostPort(rootHost, strconv.Itoa(port))
+	httpClient := &http.Client{
+		Timeout: syntheticTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{ServerName: rootHost},
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				if addr == hostPort {
+					addr = net.JoinHostPort(address, strconv.Itoa(port))
+				}
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			},
+		},
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s%s", scheme, hostPort, path), nil)
+	if err != nil {
+		return 0, err
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	return response.StatusCode, nil
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// takeOverOwnership claims the registry entries under the records rootHost
+// that are currently registered to the owner id named by the take-over
+// annotation, returning how many record sets changed hands. The owner labels
+// are rewritten through the registry in a single change batch, so the
+// entries move atomically instead of being deleted by one owner and
+// recreated by the other with a visibility gap in between.
+func (r *DNSRecordReconciler) takeOverOwnership(ctx context.Context, dnsRecord *v1alpha1.DNSRecord, fromOwner string) (int, error) {
+	rootHost := dnsRecord.GetRootHost()
+
+	dnsProvider, err := r.ProviderFactory.ProviderFor(ctx, dnsRecord, provider.Config{
+		DomainFilter: externaldnsendpoint.NewDomainFilter([]string{rootHost}),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	registry, err := registryFor(ctx, r.Client, dnsRecord, dnsProvider, dnsRecord.Status.OwnerID, r.RegistryConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := registry.Records(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	changes := &externaldnsplan.Changes{}
+	for _, record := range records {
+		underRoot := record.DNSName == rootHost || strings.HasSuffix(record.DNSName, "."+rootHost)
+		if !underRoot || record.Labels[externaldnsendpoint.OwnerLabelKey] != fromOwner {
+			continue
+		}
+		claimed := record.DeepCopy()
+		claimed.Labels[externaldnsendpoint.OwnerLabelKey] = dnsRecord.Status.OwnerID
+		changes.UpdateOld = append(changes.UpdateOld, record)
+		changes.UpdateNew = append(changes.UpdateNew, claimed)
+	}
+	if len(changes.UpdateNew) == 0 {
+		return 0, nil
+	}
+
+	if err := registry.ApplyChanges(ctx, changes); err != nil {
+		return 0, fmt.Errorf("claiming %d record sets from owner %q: %w", len(changes.UpdateNew), fromOwner, err)
+	}
+	return len(changes.UpdateNew), nil
+}
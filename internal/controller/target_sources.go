@@ -0,0 +1,257 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// defaultTargetsTTL is the TTL of endpoints synthesized from a target
+// source, kept short because the addresses behind them churn with the
+// cluster.
+const defaultTargetsTTL = externaldnsendpoint.TTL(60)
+
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+
+// resolveTargets synthesizes the rootHost address endpoints of a record with
+// a target source, replacing any endpoints for the rootHost in the in-memory
+// spec. The spec in the cluster is never modified.
+func (r *DNSRecordReconciler) resolveTargets(ctx context.Context, dnsRecord *v1alpha1.DNSRecord) error {
+	source := dnsRecord.Spec.TargetsFrom
+	if source == nil {
+		return nil
+	}
+
+	var targets []string
+	var err error
+	switch {
+	case source.ServiceRef != nil:
+		targets, err = r.serviceTargets(ctx, dnsRecord.Namespace, source.ServiceRef.Name)
+	case len(source.EndpointSliceSelector) > 0:
+		targets, err = r.endpointSliceTargets(ctx, dnsRecord.Namespace, source.EndpointSliceSelector)
+	case source.Nodes != nil:
+		targets, err = r.nodeTargets(ctx, source.Nodes)
+	}
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("target source resolved no addresses")
+	}
+
+	rootHost := dnsRecord.GetRootHost()
+	endpoints := make([]*externaldnsendpoint.Endpoint, 0, len(dnsRecord.Spec.Endpoints)+2)
+	for _, endpoint := range dnsRecord.Spec.Endpoints {
+		if endpoint.DNSName != rootHost {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	dnsRecord.Spec.Endpoints = append(endpoints, targetEndpoints(rootHost, targets)...)
+	return nil
+}
+
+// serviceTargets returns the published addresses of a Service: the load
+// balancer ingress addresses, or the externalIPs when there are none.
+func (r *DNSRecordReconciler) serviceTargets(ctx context.Context, namespace, name string) ([]string, error) {
+	service := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, service); err != nil {
+		return nil, fmt.Errorf("loading Service %q: %w", name, err)
+	}
+	var targets []string
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			targets = append(targets, ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			targets = append(targets, ingress.Hostname)
+		}
+	}
+	if len(targets) == 0 {
+		targets = append(targets, service.Spec.ExternalIPs...)
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+// endpointSliceTargets returns the ready addresses of the EndpointSlices
+// matching the selector.
+func (r *DNSRecordReconciler) endpointSliceTargets(ctx context.Context, namespace string, selector map[string]string) ([]string, error) {
+	sliceList := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, sliceList, client.InNamespace(namespace), client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("listing EndpointSlices: %w", err)
+	}
+	seen := map[string]struct{}{}
+	var targets []string
+	for _, slice := range sliceList.Items {
+		for _, sliceEndpoint := range slice.Endpoints {
+			if sliceEndpoint.Conditions.Ready != nil && !*sliceEndpoint.Conditions.Ready {
+				continue
+			}
+			for _, address := range sliceEndpoint.Addresses {
+				if _, ok := seen[address]; ok {
+					continue
+				}
+				seen[address] = struct{}{}
+				targets = append(targets, address)
+			}
+		}
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+// nodeTargets returns one address per node matching the selector, preferring
+// the configured address type and falling back to InternalIP on nodes
+// without an external address, the common bare-metal case.
+func (r *DNSRecordReconciler) nodeTargets(ctx context.Context, source *v1alpha1.NodeTargetSource) ([]string, error) {
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList, client.MatchingLabels(source.Selector)); err != nil {
+		return nil, fmt.Errorf("listing Nodes: %w", err)
+	}
+	preferred := corev1.NodeAddressType(source.AddressType)
+	if preferred == "" {
+		preferred = corev1.NodeExternalIP
+	}
+
+	var targets []string
+	for _, node := range nodeList.Items {
+		if address := nodeAddress(node, preferred); address != "" {
+			targets = append(targets, address)
+		} else if preferred == corev1.NodeExternalIP {
+			if address := nodeAddress(node, corev1.NodeInternalIP); address != "" {
+				targets = append(targets, address)
+			}
+		}
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+// nodeAddress returns the first node address of the given type.
+func nodeAddress(node corev1.Node, addressType corev1.NodeAddressType) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == addressType {
+			return address.Address
+		}
+	}
+	return ""
+}
+
+// targetEndpoints groups the targets into A/AAAA/CNAME endpoints for the
+// given dnsName. Hostname targets only become a CNAME when there are no IP
+// targets, a CNAME can not coexist with address records at the same name.
+func targetEndpoints(dnsName string, targets []string) []*externaldnsendpoint.Endpoint {
+	var ipv4, ipv6, hostnames []string
+	for _, target := range targets {
+		ip := net.ParseIP(target)
+		switch {
+		case ip == nil:
+			hostnames = append(hostnames, target)
+		case ip.To4() != nil:
+			ipv4 = append(ipv4, target)
+		default:
+			ipv6 = append(ipv6, target)
+		}
+	}
+
+	var endpoints []*externaldnsendpoint.Endpoint
+	if len(ipv4) > 0 {
+		endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(dnsName, externaldnsendpoint.RecordTypeA, defaultTargetsTTL, ipv4...))
+	}
+	if len(ipv6) > 0 {
+		endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(dnsName, externaldnsendpoint.RecordTypeAAAA, defaultTargetsTTL, ipv6...))
+	}
+	if len(endpoints) == 0 && len(hostnames) > 0 {
+		endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(dnsName, externaldnsendpoint.RecordTypeCNAME, defaultTargetsTTL, hostnames...))
+	}
+	return endpoints
+}
+
+// TargetServiceNameIndex indexes DNSRecords by the name of the Service their
+// targetsFrom references.
+const TargetServiceNameIndex = "spec.targetsFrom.serviceRef.name"
+
+// recordsForTargetService maps a Service event to the DNSRecords in its
+// namespace sourcing targets from it.
+func (r *DNSRecordReconciler) recordsForTargetService(ctx context.Context, obj client.Object) []reconcile.Request {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, recordList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{TargetServiceNameIndex: obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list DNSRecords for target Service", "service", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(recordList.Items))
+	for i := range recordList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&recordList.Items[i])})
+	}
+	return requests
+}
+
+// recordsForNode maps a Node event to the DNSRecords across all namespaces
+// sourcing targets from nodes, so records follow nodes being added, removed
+// or readdressed.
+func (r *DNSRecordReconciler) recordsForNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, recordList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list DNSRecords for Node", "node", obj.GetName())
+		return nil
+	}
+	labels := obj.GetLabels()
+	var requests []reconcile.Request
+	for i := range recordList.Items {
+		source := recordList.Items[i].Spec.TargetsFrom
+		if source == nil || source.Nodes == nil {
+			continue
+		}
+		if matchesLabels(labels, source.Nodes.Selector) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&recordList.Items[i])})
+		}
+	}
+	return requests
+}
+
+// recordsForEndpointSlice maps an EndpointSlice event to the DNSRecords in
+// its namespace whose selector matches the slice labels.
+func (r *DNSRecordReconciler) recordsForEndpointSlice(ctx context.Context, obj client.Object) []reconcile.Request {
+	recordList := &v1alpha1.DNSRecordList{}
+	if err := r.List(ctx, recordList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list DNSRecords for EndpointSlice", "endpointslice", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+	labels := obj.GetLabels()
+	var requests []reconcile.Request
+	for i := range recordList.Items {
+		source := recordList.Items[i].Spec.TargetsFrom
+		if source == nil || len(source.EndpointSliceSelector) == 0 {
+			continue
+		}
+		if matchesLabels(labels, source.EndpointSliceSelector) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&recordList.Items[i])})
+		}
+	}
+	return requests
+}
+
+// matchesLabels reports whether the labels carry every selector entry.
+func matchesLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestTargetEndpoints(t *testing.T) {
+	testCases := []struct {
+		name    string
+		targets []string
+		want    []*externaldnsendpoint.Endpoint
+	}{
+		{
+			name:    "mixed address families",
+			targets: []string{"1.1.1.1", "2.2.2.2", "2001:db8::1"},
+			want: []*externaldnsendpoint.Endpoint{
+				externaldnsendpoint.NewEndpointWithTTL("app.example.com", externaldnsendpoint.RecordTypeA, defaultTargetsTTL, "1.1.1.1", "2.2.2.2"),
+				externaldnsendpoint.NewEndpointWithTTL("app.example.com", externaldnsendpoint.RecordTypeAAAA, defaultTargetsTTL, "2001:db8::1"),
+			},
+		},
+		{
+			name:    "hostname only becomes a cname",
+			targets: []string{"lb.provider.example.org"},
+			want: []*externaldnsendpoint.Endpoint{
+				externaldnsendpoint.NewEndpointWithTTL("app.example.com", externaldnsendpoint.RecordTypeCNAME, defaultTargetsTTL, "lb.provider.example.org"),
+			},
+		},
+		{
+			name:    "hostnames are dropped when ips are present",
+			targets: []string{"1.1.1.1", "lb.provider.example.org"},
+			want: []*externaldnsendpoint.Endpoint{
+				externaldnsendpoint.NewEndpointWithTTL("app.example.com", externaldnsendpoint.RecordTypeA, defaultTargetsTTL, "1.1.1.1"),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := targetEndpoints("app.example.com", testCase.targets)
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("targetEndpoints() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
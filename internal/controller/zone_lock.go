@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/common/hash"
+)
+
+const (
+	// zoneLockTTL is how long a zone write lock is considered held without
+	// renewal, bounding the wait after a replica dies mid-apply.
+	zoneLockTTL = 30 * time.Second
+	// zoneLockPrefix prefixes the lease name per zone.
+	zoneLockPrefix = "kuadrant-zone-"
+)
+
+// errZoneLocked is returned when another replica currently holds the write
+// lock of a zone; the apply is retried shortly.
+var errZoneLocked = fmt.Errorf("zone is locked by another replica")
+
+// zoneLock serializes provider zone writes across sharded replicas with a
+// coordination Lease per zone, ensuring only one replica writes to a given
+// zone at a time even though records in it may be assigned to different
+// shards.
+type zoneLock struct {
+	client    client.Client
+	namespace string
+	holder    string
+}
+
+// newZoneLock returns a zone lock held under the given holder identity,
+// creating leases in the operator namespace (POD_NAMESPACE, defaulting to
+// "default").
+func newZoneLock(c client.Client, holder string) *zoneLock {
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		namespace = "default"
+	}
+	return &zoneLock{client: c, namespace: namespace, holder: holder}
+}
+
+// lockName returns the lease name of a zone.
+func (l *zoneLock) lockName(zoneID string) string {
+	return zoneLockPrefix + hash.ToBase36HashLen(zoneID, 8)
+}
+
+// acquire takes the write lock of a zone, returning errZoneLocked if another
+// replica holds it and has renewed it within the ttl.
+func (l *zoneLock) acquire(ctx context.Context, zoneID string) error {
+	name := l.lockName(zoneID)
+	now := metav1.NewMicroTime(time.Now())
+
+	lease := &coordinationv1.Lease{}
+	err := l.client.Get(ctx, types.NamespacedName{Namespace: l.namespace, Name: name}, lease)
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: l.namespace, Name: name},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(l.holder),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+				LeaseDurationSeconds: ptr.To(int32(zoneLockTTL.Seconds())),
+			},
+		}
+		if err := l.client.Create(ctx, lease); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return errZoneLocked
+			}
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	expired := lease.Spec.RenewTime == nil || time.Since(lease.Spec.RenewTime.Time) > zoneLockTTL
+	if holder != l.holder && holder != "" && !expired {
+		return errZoneLocked
+	}
+
+	lease.Spec.HolderIdentity = ptr.To(l.holder)
+	lease.Spec.RenewTime = &now
+	if holder != l.holder {
+		lease.Spec.AcquireTime = &now
+	}
+	if err := l.client.Update(ctx, lease); err != nil {
+		if apierrors.IsConflict(err) {
+			return errZoneLocked
+		}
+		return err
+	}
+	return nil
+}
+
+// release gives up the write lock of a zone. Failures are ignored, the lease
+// expires on its own.
+func (l *zoneLock) release(ctx context.Context, zoneID string) {
+	lease := &coordinationv1.Lease{}
+	if err := l.client.Get(ctx, types.NamespacedName{Namespace: l.namespace, Name: l.lockName(zoneID)}, lease); err != nil {
+		return
+	}
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != l.holder {
+		return
+	}
+	lease.Spec.HolderIdentity = ptr.To("")
+	_ = l.client.Update(ctx, lease)
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+// protectedChange reports whether a change must never reach the provider:
+// SOA records are owned by the provider, and NS records at the zone apex
+// hold the delegation of the zone itself. The webhook rejects such
+// endpoints at admission; this is the last line of defence for records
+// admitted before the webhook was installed or zones resolved differently
+// than expected.
+func protectedChange(endpoint *externaldnsendpoint.Endpoint, zoneDNSName string) bool {
+	if endpoint.RecordType == "SOA" {
+		return true
+	}
+	return endpoint.RecordType == "NS" && endpoint.DNSName == zoneDNSName
+}
+
+// filterProtectedChanges removes protected changes from the plan in place
+// and returns how many were dropped.
+func filterProtectedChanges(changes *externaldnsplan.Changes, zoneDNSName string) int {
+	dropped := 0
+	filter := func(endpoints []*externaldnsendpoint.Endpoint) []*externaldnsendpoint.Endpoint {
+		kept := endpoints[:0]
+		for _, endpoint := range endpoints {
+			if protectedChange(endpoint, zoneDNSName) {
+				dropped++
+				continue
+			}
+			kept = append(kept, endpoint)
+		}
+		return kept
+	}
+	changes.Create = filter(changes.Create)
+	changes.UpdateOld = filter(changes.UpdateOld)
+	changes.UpdateNew = filter(changes.UpdateNew)
+	changes.Delete = filter(changes.Delete)
+	return dropped
+}
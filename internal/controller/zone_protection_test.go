@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+func TestFilterProtectedChanges(t *testing.T) {
+	endpoint := func(dnsName, recordType string) *externaldnsendpoint.Endpoint {
+		return &externaldnsendpoint.Endpoint{DNSName: dnsName, RecordType: recordType, Targets: []string{"target"}}
+	}
+	changes := &externaldnsplan.Changes{
+		Create: []*externaldnsendpoint.Endpoint{
+			endpoint("app.example.com", externaldnsendpoint.RecordTypeA),
+			endpoint("example.com", "SOA"),
+		},
+		UpdateOld: []*externaldnsendpoint.Endpoint{endpoint("example.com", "NS")},
+		UpdateNew: []*externaldnsendpoint.Endpoint{endpoint("example.com", "NS")},
+		Delete: []*externaldnsendpoint.Endpoint{
+			// a delegation below the apex is legitimate and stays
+			endpoint("sub.example.com", "NS"),
+		},
+	}
+
+	if dropped := filterProtectedChanges(changes, "example.com"); dropped != 3 {
+		t.Errorf("filterProtectedChanges() dropped %d changes, want 3", dropped)
+	}
+	if len(changes.Create) != 1 || changes.Create[0].DNSName != "app.example.com" {
+		t.Errorf("unexpected creates after filtering: %v", changes.Create)
+	}
+	if len(changes.UpdateOld) != 0 || len(changes.UpdateNew) != 0 {
+		t.Errorf("expected apex NS updates to be dropped, got %v / %v", changes.UpdateOld, changes.UpdateNew)
+	}
+	if len(changes.Delete) != 1 || changes.Delete[0].DNSName != "sub.example.com" {
+		t.Errorf("unexpected deletes after filtering: %v", changes.Delete)
+	}
+}
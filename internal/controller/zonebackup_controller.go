@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+	externaldnsregistry "sigs.k8s.io/external-dns/registry"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+const defaultBackupInterval = time.Hour
+
+// ZoneBackupReconciler reconciles a ZoneBackup object
+type ZoneBackupReconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	ProviderFactory provider.Factory
+	// RegistryConfig is the default registry format, overridable per
+	// provider secret.
+	RegistryConfig provider.RegistryConfig
+}
+
+//+kubebuilder:rbac:groups=kuadrant.io,resources=zonebackups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kuadrant.io,resources=zonebackups/status,verbs=get;update;patch
+
+func (r *ZoneBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	previous := &v1alpha1.ZoneBackup{}
+	if err := r.Get(ctx, req.NamespacedName, previous); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	backup := previous.DeepCopy()
+
+	if backup.DeletionTimestamp != nil && !backup.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if _, restoreRequested := backup.Annotations[v1alpha1.RestoreAnnotation]; restoreRequested {
+		logger.Info("Restoring zone from snapshot", "rootDomain", backup.Spec.RootDomain)
+		if err := r.restoreSnapshot(ctx, backup); err != nil {
+			setZoneBackupCondition(backup, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonProviderError, fmt.Sprintf("Failed to restore snapshot: %v", provider.SanitizeError(err)))
+			if updateErr := r.updateStatus(ctx, previous, backup); updateErr != nil {
+				return ctrl.Result{}, updateErr
+			}
+			return ctrl.Result{}, err
+		}
+		backup.Status.LastRestoreTime = &metav1.Time{Time: time.Now()}
+		delete(backup.Annotations, v1alpha1.RestoreAnnotation)
+		if err := r.Update(ctx, backup); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.updateStatus(ctx, previous, backup)
+	}
+
+	if backup.Spec.Suspend {
+		setZoneBackupCondition(backup, v1alpha1.ConditionTypeReady, metav1.ConditionUnknown, v1alpha1.ConditionReasonPaused, "Snapshots are suspended")
+		return ctrl.Result{}, r.updateStatus(ctx, previous, backup)
+	}
+
+	interval := backup.Spec.Interval.Duration
+	if interval <= 0 {
+		interval = defaultBackupInterval
+	}
+	if backup.Status.LastBackupTime != nil {
+		if remaining := interval - time.Since(backup.Status.LastBackupTime.Time); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	if err := r.takeSnapshot(ctx, backup); err != nil {
+		setZoneBackupCondition(backup, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, v1alpha1.ConditionReasonProviderError, fmt.Sprintf("Failed to snapshot zone: %v", provider.SanitizeError(err)))
+		if updateErr := r.updateStatus(ctx, previous, backup); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	setZoneBackupCondition(backup, v1alpha1.ConditionTypeReady, metav1.ConditionTrue, v1alpha1.ConditionReasonProviderSuccess, fmt.Sprintf("Snapshot taken with %d records", backup.Status.RecordCount))
+	if err := r.updateStatus(ctx, previous, backup); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// takeSnapshot reads all operator owned records under the backups rootDomain
+// from the provider zone into the backups status.
+func (r *ZoneBackupReconciler) takeSnapshot(ctx context.Context, backup *v1alpha1.ZoneBackup) error {
+	registry, err := r.registryFor(ctx, backup)
+	if err != nil {
+		return err
+	}
+
+	records, err := registry.Records(ctx)
+	if err != nil {
+		return err
+	}
+
+	var snapshot []*externaldnsendpoint.Endpoint
+	for _, record := range records {
+		if record.Labels[externaldnsendpoint.OwnerLabelKey] == "" {
+			// only operator owned records are snapshotted, the zone may
+			// contain unrelated manually managed records
+			continue
+		}
+		if record.DNSName != backup.Spec.RootDomain && !strings.HasSuffix(record.DNSName, "."+backup.Spec.RootDomain) {
+			continue
+		}
+		snapshot = append(snapshot, record)
+	}
+
+	backup.Status.Endpoints = snapshot
+	backup.Status.RecordCount = len(snapshot)
+	backup.Status.LastBackupTime = &metav1.Time{Time: time.Now()}
+	return nil
+}
+
+// restoreSnapshot replays the snapshot in the backups status into the
+// provider zone. Records are grouped by their original owner and restored
+// through a registry with that owner id so ownership metadata is recreated
+// exactly as it was.
+func (r *ZoneBackupReconciler) restoreSnapshot(ctx context.Context, backup *v1alpha1.ZoneBackup) error {
+	if len(backup.Status.Endpoints) == 0 {
+		return fmt.Errorf("no snapshot to restore")
+	}
+
+	byOwner := map[string][]*externaldnsendpoint.Endpoint{}
+	for _, record := range backup.Status.Endpoints {
+		owner := record.Labels[externaldnsendpoint.OwnerLabelKey]
+		byOwner[owner] = append(byOwner[owner], record)
+	}
+
+	dnsProvider, err := r.providerFor(ctx, backup)
+	if err != nil {
+		return err
+	}
+
+	for owner, records := range byOwner {
+		registry, err := registryFor(ctx, r.Client, backup, dnsProvider, owner, r.RegistryConfig)
+		if err != nil {
+			return err
+		}
+		current, err := registry.Records(ctx)
+		if err != nil {
+			return err
+		}
+		// only create records that are actually missing from the zone
+		existing := map[string]struct{}{}
+		for _, record := range current {
+			existing[record.DNSName+"#"+record.SetIdentifier+"#"+record.RecordType] = struct{}{}
+		}
+		var missing []*externaldnsendpoint.Endpoint
+		for _, record := range records {
+			if _, ok := existing[record.DNSName+"#"+record.SetIdentifier+"#"+record.RecordType]; !ok {
+				missing = append(missing, record)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		if err := registry.ApplyChanges(ctx, &externaldnsplan.Changes{Create: missing}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ZoneBackupReconciler) providerFor(ctx context.Context, backup *v1alpha1.ZoneBackup) (provider.Provider, error) {
+	return r.ProviderFactory.ProviderFor(ctx, backup, provider.Config{
+		DomainFilter: externaldnsendpoint.NewDomainFilter([]string{backup.Spec.RootDomain}),
+	})
+}
+
+func (r *ZoneBackupReconciler) registryFor(ctx context.Context, backup *v1alpha1.ZoneBackup) (externaldnsregistry.Registry, error) {
+	dnsProvider, err := r.providerFor(ctx, backup)
+	if err != nil {
+		return nil, err
+	}
+	return registryFor(ctx, r.Client, backup, dnsProvider, orphanGCOwnerID, r.RegistryConfig)
+}
+
+func (r *ZoneBackupReconciler) updateStatus(ctx context.Context, previous, current *v1alpha1.ZoneBackup) error {
+	if !equality.Semantic.DeepEqual(previous.Status, current.Status) {
+		return r.Status().Update(ctx, current)
+	}
+	return nil
+}
+
+// setZoneBackupCondition adds or updates a given condition in the ZoneBackup status.
+func setZoneBackupCondition(backup *v1alpha1.ZoneBackup, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	cond := metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: backup.Generation,
+	}
+	meta.SetStatusCondition(&backup.Status.Conditions, cond)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ZoneBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ZoneBackup{}).
+		Complete(r)
+}
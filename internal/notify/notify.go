@@ -0,0 +1,125 @@
+// Package notify delivers DNSRecord state transition notifications to an
+// external HTTP sink, so release pipelines can gate on DNS publication
+// without polling the cluster.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types delivered to the sink.
+const (
+	EventCreated   = "created"
+	EventReady     = "ready"
+	EventFailed    = "failed"
+	EventApplied   = "applied"
+	EventConflict  = "conflict"
+	EventDeleted   = "deleted"
+	EventHealthy   = "healthy"
+	EventUnhealthy = "unhealthy"
+)
+
+// Event describes a DNSRecord state transition.
+type Event struct {
+	// Type is one of the Event constants.
+	Type string `json:"type"`
+	// Record is the namespace/name of the DNSRecord.
+	Record string `json:"record"`
+	// RootHost is the rootHost the record publishes.
+	RootHost string `json:"rootHost"`
+	// Reason and Message mirror the Ready condition of the transition.
+	Reason  string    `json:"reason,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// defaultTimeout bounds a single delivery attempt.
+const defaultTimeout = 5 * time.Second
+
+// Notifier posts events to a webhook URL, either as plain JSON or wrapped
+// in a CloudEvents 1.0 structured envelope. Delivery is best effort: the
+// sink is an observer, never a gate on reconciliation.
+type Notifier struct {
+	url         string
+	cloudEvents bool
+	client      *http.Client
+}
+
+// NewNotifier returns a notifier posting plain JSON events to the given
+// webhook URL.
+func NewNotifier(url string) *Notifier {
+	return &Notifier{
+		url:    url,
+		client: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// NewCloudEventsNotifier returns a notifier posting events to the given HTTP
+// sink in the CloudEvents 1.0 structured content mode.
+func NewCloudEventsNotifier(url string) *Notifier {
+	notifier := NewNotifier(url)
+	notifier.cloudEvents = true
+	return notifier
+}
+
+// eventSource identifies this operator as the CloudEvents source.
+const eventSource = "/io/kuadrant/dns-operator"
+
+// cloudEvent is the CloudEvents 1.0 structured envelope around an Event.
+type cloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	Data            Event  `json:"data"`
+}
+
+// Send delivers the event to the sink.
+func (n *Notifier) Send(ctx context.Context, event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	contentType := "application/json"
+	var payload any = event
+	if n.cloudEvents {
+		contentType = "application/cloudevents+json"
+		payload = cloudEvent{
+			SpecVersion:     "1.0",
+			ID:              uuid.NewString(),
+			Source:          eventSource,
+			Type:            "io.kuadrant.dns.record." + event.Type,
+			Subject:         event.Record,
+			Time:            event.Time.UTC().Format(time.RFC3339),
+			DataContentType: "application/json",
+			Data:            event,
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", contentType)
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("notification sink returned %s", response.Status)
+	}
+	return nil
+}
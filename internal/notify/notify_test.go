@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotifierSend(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("unexpected content type %s", contentType)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decoding event: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(server.URL)
+	err := notifier.Send(context.Background(), Event{
+		Type:     EventReady,
+		Record:   "test/record",
+		RootHost: "app.example.com",
+		Reason:   "ProviderSuccess",
+	})
+	if err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+	if received.Type != EventReady || received.Record != "test/record" || received.RootHost != "app.example.com" {
+		t.Errorf("unexpected event received: %+v", received)
+	}
+	if received.Time.IsZero() {
+		t.Error("expected a default event time to be set")
+	}
+}
+
+func TestNotifierSendFailureStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	if err := NewNotifier(server.URL).Send(context.Background(), Event{Type: EventFailed}); err == nil {
+		t.Fatal("expected an error for a non-2xx sink response")
+	}
+}
+
+func TestCloudEventsNotifierSend(t *testing.T) {
+	var envelope map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/cloudevents+json" {
+			t.Errorf("unexpected content type %s", contentType)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+			t.Errorf("decoding envelope: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	notifier := NewCloudEventsNotifier(server.URL)
+	err := notifier.Send(context.Background(), Event{
+		Type:   EventApplied,
+		Record: "test/record",
+	})
+	if err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+	if envelope["specversion"] != "1.0" {
+		t.Errorf("unexpected specversion %v", envelope["specversion"])
+	}
+	if envelope["type"] != "io.kuadrant.dns.record.applied" {
+		t.Errorf("unexpected type %v", envelope["type"])
+	}
+	if envelope["subject"] != "test/record" {
+		t.Errorf("unexpected subject %v", envelope["subject"])
+	}
+	if envelope["id"] == "" || envelope["id"] == nil {
+		t.Error("expected a unique event id")
+	}
+	data, ok := envelope["data"].(map[string]any)
+	if !ok || data["record"] != "test/record" {
+		t.Errorf("unexpected data payload %v", envelope["data"])
+	}
+}
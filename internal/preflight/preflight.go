@@ -0,0 +1,192 @@
+// Package preflight checks the public DNS configuration around a rootHost
+// before records are published into it, catching parent zone problems the
+// provider cannot see: restrictive CAA records that would block certificate
+// issuance for the host, and DS records in the parent that do not match the
+// signed zone.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const queryTimeout = 5 * time.Second
+
+// Finding is a single pre-flight problem. Findings never block publishing;
+// they are surfaced as record conditions.
+type Finding struct {
+	// Check names the check that produced the finding, e.g. "CAA".
+	Check string
+	// Message describes the problem.
+	Message string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Check, f.Message)
+}
+
+// Checker runs pre-flight checks against the public DNS tree.
+type Checker struct {
+	// ACMEIssuers are the issuer domains certificates are expected from,
+	// e.g. "letsencrypt.org". When set, CAA records covering the rootHost
+	// must permit at least one of them.
+	ACMEIssuers []string
+
+	server string
+	client *dns.Client
+}
+
+// NewChecker returns a checker querying through the system resolver.
+func NewChecker(acmeIssuers []string) (*Checker, error) {
+	config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil {
+		return nil, fmt.Errorf("reading resolver configuration: %w", err)
+	}
+	if len(config.Servers) == 0 {
+		return nil, fmt.Errorf("no resolvers configured")
+	}
+	return &Checker{
+		ACMEIssuers: acmeIssuers,
+		server:      config.Servers[0] + ":" + config.Port,
+		client:      &dns.Client{Timeout: queryTimeout},
+	}, nil
+}
+
+// Check runs all pre-flight checks for a rootHost about to be published into
+// the zone zoneDomain. dsRecords are the delegation signer records of the
+// zone when it is signed, matched against the DS set in the parent.
+func (c *Checker) Check(ctx context.Context, rootHost, zoneDomain string, dsRecords []string) ([]Finding, error) {
+	var findings []Finding
+
+	caaFindings, err := c.checkCAA(ctx, rootHost)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, caaFindings...)
+
+	dsFindings, err := c.checkDS(ctx, zoneDomain, dsRecords)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, dsFindings...)
+
+	return findings, nil
+}
+
+// checkCAA walks from the rootHost towards the root and evaluates the first
+// CAA record set found, per RFC 8659 the set governing issuance for the host.
+func (c *Checker) checkCAA(ctx context.Context, rootHost string) ([]Finding, error) {
+	if len(c.ACMEIssuers) == 0 {
+		return nil, nil
+	}
+	name := strings.TrimSuffix(rootHost, ".")
+	for name != "" && strings.Contains(name, ".") {
+		rrs, err := c.query(ctx, name, dns.TypeCAA)
+		if err != nil {
+			return nil, err
+		}
+		var issuers []string
+		for _, rr := range rrs {
+			caa, ok := rr.(*dns.CAA)
+			if !ok {
+				continue
+			}
+			if caa.Tag == "issue" || caa.Tag == "issuewild" {
+				issuers = append(issuers, caa.Value)
+			}
+		}
+		if len(issuers) > 0 {
+			if !CAAAllowsAny(issuers, c.ACMEIssuers) {
+				return []Finding{{
+					Check: "CAA",
+					Message: fmt.Sprintf("CAA records on %s only permit issuance by %s, blocking the expected issuers %s",
+						name, strings.Join(issuers, ", "), strings.Join(c.ACMEIssuers, ", ")),
+				}}, nil
+			}
+			return nil, nil
+		}
+		_, name, _ = strings.Cut(name, ".")
+	}
+	return nil, nil
+}
+
+// checkDS compares the DS set the parent zone publishes for zoneDomain with
+// the DS records of the signed zone. A mismatch in either direction breaks
+// resolution for validating resolvers.
+func (c *Checker) checkDS(ctx context.Context, zoneDomain string, dsRecords []string) ([]Finding, error) {
+	if zoneDomain == "" {
+		return nil, nil
+	}
+	rrs, err := c.query(ctx, zoneDomain, dns.TypeDS)
+	if err != nil {
+		return nil, err
+	}
+	parent := map[string]bool{}
+	for _, rr := range rrs {
+		ds, ok := rr.(*dns.DS)
+		if !ok {
+			continue
+		}
+		parent[normalizeDS(fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest))] = true
+	}
+
+	if len(parent) > 0 && len(dsRecords) == 0 {
+		return []Finding{{
+			Check:   "DNSSEC",
+			Message: fmt.Sprintf("the parent zone publishes DS records for %s but the zone is not signed", zoneDomain),
+		}}, nil
+	}
+	for _, ds := range dsRecords {
+		if parent[normalizeDS(ds)] {
+			return nil, nil
+		}
+	}
+	if len(dsRecords) > 0 {
+		return []Finding{{
+			Check:   "DNSSEC",
+			Message: fmt.Sprintf("none of the DS records of the signed zone %s are present in the parent zone", zoneDomain),
+		}}, nil
+	}
+	return nil, nil
+}
+
+func (c *Checker) query(ctx context.Context, name string, qtype uint16) ([]dns.RR, error) {
+	message := new(dns.Msg)
+	message.SetQuestion(dns.Fqdn(name), qtype)
+	message.SetEdns0(4096, true)
+	response, _, err := c.client.ExchangeContext(ctx, message, c.server)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s %s: %w", name, dns.TypeToString[qtype], err)
+	}
+	return response.Answer, nil
+}
+
+// CAAAllowsAny returns true if any of the expected issuer domains is
+// permitted by the given CAA issue values. A value of ";" permits nobody.
+func CAAAllowsAny(caaValues, issuers []string) bool {
+	for _, value := range caaValues {
+		// the issuer domain is the value up to the first parameter separator
+		domain := strings.TrimSpace(value)
+		if before, _, found := strings.Cut(domain, ";"); found {
+			domain = strings.TrimSpace(before)
+		}
+		if domain == "" {
+			continue
+		}
+		for _, issuer := range issuers {
+			if strings.EqualFold(domain, issuer) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeDS canonicalizes a presentation format DS record for comparison.
+func normalizeDS(ds string) string {
+	return strings.ToLower(strings.Join(strings.Fields(ds), " "))
+}
@@ -0,0 +1,58 @@
+package preflight
+
+import "testing"
+
+func TestCAAAllowsAny(t *testing.T) {
+	testCases := []struct {
+		name      string
+		caaValues []string
+		issuers   []string
+		want      bool
+	}{
+		{
+			name:      "matching issuer is permitted",
+			caaValues: []string{"letsencrypt.org"},
+			issuers:   []string{"letsencrypt.org"},
+			want:      true,
+		},
+		{
+			name:      "issuer casing is ignored",
+			caaValues: []string{"LetsEncrypt.org"},
+			issuers:   []string{"letsencrypt.org"},
+			want:      true,
+		},
+		{
+			name:      "parameters after the issuer domain are ignored",
+			caaValues: []string{"letsencrypt.org; validationmethods=dns-01"},
+			issuers:   []string{"letsencrypt.org"},
+			want:      true,
+		},
+		{
+			name:      "other issuers are blocked",
+			caaValues: []string{"pki.example.com"},
+			issuers:   []string{"letsencrypt.org"},
+			want:      false,
+		},
+		{
+			name:      "issue nobody blocks everyone",
+			caaValues: []string{";"},
+			issuers:   []string{"letsencrypt.org"},
+			want:      false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := CAAAllowsAny(testCase.caaValues, testCase.issuers); got != testCase.want {
+				t.Errorf("CAAAllowsAny(%v, %v) = %v, want %v", testCase.caaValues, testCase.issuers, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeDS(t *testing.T) {
+	a := normalizeDS("12345 13 2 ABCDEF")
+	b := normalizeDS("12345  13 2 abcdef")
+	if a != b {
+		t.Errorf("expected %q and %q to normalize equally", a, b)
+	}
+}
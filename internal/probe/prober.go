@@ -0,0 +1,165 @@
+// Package probe runs periodic HTTP health probes against record targets and
+// tracks their recent success ratio, feeding adaptive weighting of degraded
+// clusters.
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// windowSize is how many recent probe results the success ratio is computed
+// over.
+const windowSize = 10
+
+// probeTimeout bounds a single probe request.
+const probeTimeout = 5 * time.Second
+
+// Check describes a probe of one target address.
+type Check struct {
+	// Host is the hostname probed, sent as the request Host header so
+	// virtual-hosted targets route correctly.
+	Host string
+	// Address is the IP or hostname connected to.
+	Address string
+	// Path, Port and Scheme form the probed URL.
+	Path   string
+	Port   int
+	Scheme string
+	// Interval between probes.
+	Interval time.Duration
+}
+
+// key identifies a check regardless of which record requested it.
+func (c Check) key() string {
+	return fmt.Sprintf("%s|%s://%s:%d%s", c.Host, c.Scheme, c.Address, c.Port, c.Path)
+}
+
+// url returns the probed URL, connecting to the address directly.
+func (c Check) url() string {
+	return fmt.Sprintf("%s://%s%s", c.Scheme, net.JoinHostPort(c.Address, fmt.Sprintf("%d", c.Port)), c.Path)
+}
+
+// target tracks the recent results of one check.
+type target struct {
+	results       []bool
+	next          int
+	filled        int
+	lastRequested time.Time
+	cancel        context.CancelFunc
+}
+
+// ratio returns the success ratio over the recorded window, and 1 while no
+// results have been recorded yet so new targets start at full weight.
+func (t *target) ratio() float64 {
+	if t.filled == 0 {
+		return 1
+	}
+	successes := 0
+	for i := 0; i < t.filled; i++ {
+		if t.results[i] {
+			successes++
+		}
+	}
+	return float64(successes) / float64(t.filled)
+}
+
+// record stores one probe outcome in the ring.
+func (t *target) record(success bool) {
+	t.results[t.next] = success
+	t.next = (t.next + 1) % len(t.results)
+	if t.filled < len(t.results) {
+		t.filled++
+	}
+}
+
+// Prober probes targets in the background and reports their recent success
+// ratio. Targets are registered implicitly on first use and forgotten when
+// no record has asked about them for a while.
+type Prober struct {
+	mu      sync.Mutex
+	targets map[string]*target
+	client  *http.Client
+}
+
+// NewProber returns a prober with its own HTTP client. Certificate
+// validation is skipped: probes connect to addresses directly, which rarely
+// match the certificate of the host they serve.
+func NewProber() *Prober {
+	return &Prober{
+		targets: map[string]*target{},
+		client: &http.Client{
+			Timeout: probeTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+}
+
+// SuccessRatio returns the recent probe success ratio of the check in the
+// range [0, 1], registering it for background probing on first use.
+func (p *Prober) SuccessRatio(check Check) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := check.key()
+	probed, ok := p.targets[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		probed = &target{results: make([]bool, windowSize), cancel: cancel}
+		p.targets[key] = probed
+		go p.run(ctx, check, key)
+	}
+	probed.lastRequested = time.Now()
+	return probed.ratio()
+}
+
+// run probes the check on its interval until it is forgotten.
+func (p *Prober) run(ctx context.Context, check Check, key string) {
+	ticker := time.NewTicker(check.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			success := p.probe(ctx, check)
+			p.mu.Lock()
+			probed, ok := p.targets[key]
+			if !ok {
+				p.mu.Unlock()
+				return
+			}
+			probed.record(success)
+			// forget targets no record has asked about recently
+			if time.Since(probed.lastRequested) > 10*check.Interval {
+				probed.cancel()
+				delete(p.targets, key)
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// probe performs a single probe, any 2xx or 3xx response is a success.
+func (p *Prober) probe(ctx context.Context, check Check) bool {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, check.url(), nil)
+	if err != nil {
+		return false
+	}
+	request.Host = check.Host
+	response, err := p.client.Do(request)
+	if err != nil {
+		return false
+	}
+	defer response.Body.Close()
+	return response.StatusCode < 400
+}
@@ -0,0 +1,94 @@
+package probe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func testCheck(t *testing.T, serverURL string) Check {
+	parsed, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatalf("parsing test server url: %v", err)
+	}
+	port, err := strconv.Atoi(parsed.Port())
+	if err != nil {
+		t.Fatalf("parsing test server port: %v", err)
+	}
+	return Check{
+		Host:     "app.example.com",
+		Address:  parsed.Hostname(),
+		Path:     "/healthz",
+		Port:     port,
+		Scheme:   "http",
+		Interval: time.Minute,
+	}
+}
+
+func TestTargetRatio(t *testing.T) {
+	probed := &target{results: make([]bool, windowSize)}
+	if got := probed.ratio(); got != 1 {
+		t.Errorf("ratio() with no results = %v, want 1", got)
+	}
+	probed.record(true)
+	probed.record(true)
+	probed.record(false)
+	probed.record(false)
+	if got := probed.ratio(); got != 0.5 {
+		t.Errorf("ratio() = %v, want 0.5", got)
+	}
+	// fill past the window, the oldest results rotate out
+	for i := 0; i < windowSize; i++ {
+		probed.record(true)
+	}
+	if got := probed.ratio(); got != 1 {
+		t.Errorf("ratio() after a full healthy window = %v, want 1", got)
+	}
+}
+
+func TestProbeOutcomes(t *testing.T) {
+	var status int
+	var gotHost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	prober := NewProber()
+	check := testCheck(t, server.URL)
+
+	status = http.StatusOK
+	if !prober.probe(context.Background(), check) {
+		t.Error("expected a 200 probe to succeed")
+	}
+	if gotHost != "app.example.com" {
+		t.Errorf("expected the probed host as Host header, got %q", gotHost)
+	}
+	status = http.StatusServiceUnavailable
+	if prober.probe(context.Background(), check) {
+		t.Error("expected a 503 probe to fail")
+	}
+}
+
+func TestSuccessRatioRegistersTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	prober := NewProber()
+	if got := prober.SuccessRatio(testCheck(t, server.URL)); got != 1 {
+		t.Errorf("SuccessRatio() of a new target = %v, want 1", got)
+	}
+	prober.mu.Lock()
+	registered := len(prober.targets)
+	prober.mu.Unlock()
+	if registered != 1 {
+		t.Errorf("expected 1 registered target, got %d", registered)
+	}
+}
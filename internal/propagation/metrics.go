@@ -0,0 +1,28 @@
+package propagation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// propagationHistogram reports how long applied changes took to become
+	// observable per provider and vantage ("authoritative" or a resolver
+	// address), for comparing providers and alerting on regressions.
+	propagationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "dns_operator_propagation_seconds",
+		Help:    "Time from a change apply until it is observable at a vantage.",
+		Buckets: []float64{15, 30, 60, 120, 300, 600},
+	}, []string{"provider", "vantage"})
+
+	// propagationTimeoutsCounter counts changes that never became observable
+	// at a vantage before the watch expired.
+	propagationTimeoutsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dns_operator_propagation_timeouts_total",
+		Help: "Number of applied changes not observable at a vantage before the propagation timeout.",
+	}, []string{"provider", "vantage"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(propagationHistogram, propagationTimeoutsCounter)
+}
@@ -0,0 +1,254 @@
+// Package propagation measures how long applied DNS changes take to become
+// observable, from the operators vantage point, on the zones authoritative
+// servers and on a configurable set of public resolvers. The resulting per
+// provider latency metrics make providers comparable and surface propagation
+// regressions.
+package propagation
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// queryTimeout bounds a single DNS query against one vantage.
+	queryTimeout = 5 * time.Second
+	// checkTimeout is how long a change is watched before the unsatisfied
+	// vantages are counted as propagation timeouts.
+	checkTimeout = 10 * time.Minute
+	// vantageAuthoritative is the metric label of the authoritative server
+	// vantage, the public resolvers are labelled with their address.
+	vantageAuthoritative = "authoritative"
+)
+
+// Check is a single applied change to watch until it is observable.
+type Check struct {
+	// Provider is the provider secret key (namespace/name) the change was
+	// applied through, used as the metric label.
+	Provider string
+	// Zone is the zone domain the change was applied into, used to find
+	// the authoritative servers.
+	Zone string
+	// DNSName, RecordType and Targets describe the expected answer.
+	DNSName    string
+	RecordType string
+	Targets    []string
+	// Applied is when the provider accepted the change.
+	Applied time.Time
+}
+
+// key dedupes checks: a re-apply of the same RRset replaces the pending
+// check rather than watching both.
+func (c Check) key() string {
+	return c.Provider + "|" + c.DNSName + "|" + c.RecordType
+}
+
+// pendingCheck tracks which vantages have not observed the change yet.
+type pendingCheck struct {
+	Check
+	remaining map[string]bool
+}
+
+// Monitor watches applied changes in the background and exports propagation
+// latency metrics. The zero value is not usable, use NewMonitor.
+type Monitor struct {
+	resolvers []string
+	interval  time.Duration
+	client    *dns.Client
+
+	mu      sync.Mutex
+	pending map[string]*pendingCheck
+	started bool
+}
+
+// NewMonitor returns a monitor polling the given public resolvers
+// (host:port) every interval.
+func NewMonitor(resolvers []string, interval time.Duration) *Monitor {
+	return &Monitor{
+		resolvers: resolvers,
+		interval:  interval,
+		client:    &dns.Client{Timeout: queryTimeout},
+		pending:   map[string]*pendingCheck{},
+	}
+}
+
+// Observe registers an applied change to watch. The polling loop is started
+// on first use.
+func (m *Monitor) Observe(ctx context.Context, check Check) {
+	if m == nil || len(check.Targets) == 0 {
+		return
+	}
+	remaining := map[string]bool{vantageAuthoritative: true}
+	for _, resolver := range m.resolvers {
+		remaining[resolver] = true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending[check.key()] = &pendingCheck{Check: check, remaining: remaining}
+	if !m.started {
+		m.started = true
+		go m.run(context.WithoutCancel(ctx))
+	}
+}
+
+// run polls all pending checks until the monitor context ends.
+func (m *Monitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.poll(ctx)
+		}
+	}
+}
+
+// poll queries every unsatisfied vantage of every pending check once.
+func (m *Monitor) poll(ctx context.Context) {
+	m.mu.Lock()
+	checks := make([]*pendingCheck, 0, len(m.pending))
+	for _, check := range m.pending {
+		checks = append(checks, check)
+	}
+	m.mu.Unlock()
+
+	for _, check := range checks {
+		m.pollCheck(ctx, check)
+	}
+}
+
+func (m *Monitor) pollCheck(ctx context.Context, check *pendingCheck) {
+	logger := log.FromContext(ctx).WithName("propagation")
+
+	for vantage := range check.remaining {
+		if m.observable(ctx, check.Check, vantage) {
+			latency := time.Since(check.Applied)
+			propagationHistogram.WithLabelValues(check.Provider, vantage).Observe(latency.Seconds())
+			logger.V(1).Info("Change propagated", "dnsName", check.DNSName, "vantage", vantage, "latency", latency)
+			delete(check.remaining, vantage)
+		}
+	}
+
+	expired := time.Since(check.Applied) > checkTimeout
+	if expired {
+		for vantage := range check.remaining {
+			propagationTimeoutsCounter.WithLabelValues(check.Provider, vantage).Inc()
+			logger.Info("Change not observable before the propagation timeout", "dnsName", check.DNSName, "vantage", vantage)
+		}
+	}
+	if expired || len(check.remaining) == 0 {
+		m.mu.Lock()
+		// a re-apply may have replaced the check while it was polled
+		if m.pending[check.key()] == check {
+			delete(m.pending, check.key())
+		}
+		m.mu.Unlock()
+	}
+}
+
+// observable returns true when the vantage answers the check with all
+// expected targets. The authoritative vantage requires every nameserver of
+// the zone to answer, a resolver vantage only itself.
+func (m *Monitor) observable(ctx context.Context, check Check, vantage string) bool {
+	if vantage != vantageAuthoritative {
+		return m.answered(ctx, check, vantage)
+	}
+	servers, err := m.authoritativeServers(ctx, check.Zone)
+	if err != nil || len(servers) == 0 {
+		return false
+	}
+	for _, server := range servers {
+		if !m.answered(ctx, check, server) {
+			return false
+		}
+	}
+	return true
+}
+
+// authoritativeServers resolves the NS set of the zone through the first
+// configured resolver, or the system resolver when none are configured.
+func (m *Monitor) authoritativeServers(ctx context.Context, zone string) ([]string, error) {
+	server := ""
+	if len(m.resolvers) > 0 {
+		server = m.resolvers[0]
+	} else {
+		config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+		if err != nil {
+			return nil, err
+		}
+		server = config.Servers[0] + ":" + config.Port
+	}
+	answer, err := m.query(ctx, server, zone, dns.TypeNS)
+	if err != nil {
+		return nil, err
+	}
+	var servers []string
+	for _, rr := range answer {
+		if ns, ok := rr.(*dns.NS); ok {
+			servers = append(servers, strings.TrimSuffix(ns.Ns, ".")+":53")
+		}
+	}
+	return servers, nil
+}
+
+// answered returns true when the server answers the check with all expected
+// targets present.
+func (m *Monitor) answered(ctx context.Context, check Check, server string) bool {
+	qtype, ok := dns.StringToType[check.RecordType]
+	if !ok {
+		return false
+	}
+	answer, err := m.query(ctx, server, check.DNSName, qtype)
+	if err != nil {
+		return false
+	}
+	published := map[string]bool{}
+	for _, rr := range answer {
+		if target, ok := targetOf(rr); ok {
+			published[target] = true
+		}
+	}
+	for _, target := range check.Targets {
+		if !published[strings.ToLower(strings.TrimSuffix(target, "."))] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Monitor) query(ctx context.Context, server, name string, qtype uint16) ([]dns.RR, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+	message := new(dns.Msg)
+	message.SetQuestion(dns.Fqdn(name), qtype)
+	response, _, err := m.client.ExchangeContext(ctx, message, server)
+	if err != nil {
+		return nil, err
+	}
+	return response.Answer, nil
+}
+
+// targetOf extracts the comparable target value of an answer record.
+func targetOf(rr dns.RR) (string, bool) {
+	switch record := rr.(type) {
+	case *dns.A:
+		return record.A.String(), true
+	case *dns.AAAA:
+		return record.AAAA.String(), true
+	case *dns.CNAME:
+		return strings.ToLower(strings.TrimSuffix(record.Target, ".")), true
+	case *dns.TXT:
+		return strings.Join(record.Txt, ""), true
+	case *dns.NS:
+		return strings.ToLower(strings.TrimSuffix(record.Ns, ".")), true
+	}
+	return "", false
+}
@@ -0,0 +1,140 @@
+// Package aws provides the AWS specific pieces of the operator that do not
+// live in the provider implementation itself, currently the Route53 change
+// event source consuming EventBridge events from an SQS queue.
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// ChangeEventsQueueURLKey is the aws provider secret data key naming an SQS
+// queue subscribed (via EventBridge) to Route53 ChangeResourceRecordSets
+// events. When set, zone changes trigger prompt reconciles of affected
+// records instead of waiting for periodic polling.
+const ChangeEventsQueueURLKey = "CHANGE_EVENTS_QUEUE_URL"
+
+// aws provider secret data keys holding the credentials, shared with the
+// provider implementation.
+const (
+	accessKeyIDKey     = "AWS_ACCESS_KEY_ID"
+	secretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+	regionKey          = "AWS_REGION"
+)
+
+// receiveWaitSeconds is the SQS long poll duration per receive.
+const receiveWaitSeconds = 20
+
+// sqsSource reads Route53 change events from an SQS queue.
+type sqsSource struct {
+	client   *sqs.SQS
+	queueURL string
+}
+
+// newChangeEventSource returns an SQS backed change event source for the
+// given aws provider secret, or nil when the secret does not name a queue.
+func newChangeEventSource(_ context.Context, s *corev1.Secret) (provider.ChangeEventSource, error) {
+	queueURL := strings.TrimSpace(string(s.Data[ChangeEventsQueueURLKey]))
+	if queueURL == "" {
+		return nil, nil
+	}
+
+	config := aws.NewConfig()
+	if region := strings.TrimSpace(string(s.Data[regionKey])); region != "" {
+		config = config.WithRegion(region)
+	}
+	if accessKeyID := string(s.Data[accessKeyIDKey]); accessKeyID != "" {
+		config = config.WithCredentials(credentials.NewStaticCredentials(accessKeyID, string(s.Data[secretAccessKeyKey]), ""))
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating aws session for change events: %w", err)
+	}
+	return &sqsSource{client: sqs.New(sess), queueURL: queueURL}, nil
+}
+
+// Receive long polls the queue until messages arrive, returning the dns
+// names their change batches touched. Messages are deleted once parsed; a
+// message that does not parse still counts as a change with unknown names.
+func (s *sqsSource) Receive(ctx context.Context) ([]string, error) {
+	for {
+		out, err := s.client.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(s.queueURL),
+			MaxNumberOfMessages: aws.Int64(10),
+			WaitTimeSeconds:     aws.Int64(receiveWaitSeconds),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(out.Messages) == 0 {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		names := []string{}
+		for _, message := range out.Messages {
+			if message.Body != nil {
+				names = append(names, changedNames(*message.Body)...)
+			}
+			_, _ = s.client.DeleteMessageWithContext(ctx, &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(s.queueURL),
+				ReceiptHandle: message.ReceiptHandle,
+			})
+		}
+		return names, nil
+	}
+}
+
+// route53Event is the subset of the EventBridge envelope for Route53 API
+// calls recorded by CloudTrail that names the changed record sets.
+type route53Event struct {
+	// Message wraps the event when the queue is fed through an SNS topic.
+	Message string `json:"Message"`
+	Detail  struct {
+		RequestParameters struct {
+			ChangeBatch struct {
+				Changes []struct {
+					ResourceRecordSet struct {
+						Name string `json:"name"`
+					} `json:"resourceRecordSet"`
+				} `json:"changes"`
+			} `json:"changeBatch"`
+		} `json:"requestParameters"`
+	} `json:"detail"`
+}
+
+// changedNames extracts the record set names a Route53 change event touched.
+func changedNames(body string) []string {
+	var event route53Event
+	if err := json.Unmarshal([]byte(body), &event); err != nil {
+		return nil
+	}
+	if event.Message != "" && len(event.Detail.RequestParameters.ChangeBatch.Changes) == 0 {
+		if err := json.Unmarshal([]byte(event.Message), &event); err != nil {
+			return nil
+		}
+	}
+	var names []string
+	for _, change := range event.Detail.RequestParameters.ChangeBatch.Changes {
+		if name := strings.TrimSuffix(change.ResourceRecordSet.Name, "."); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func init() {
+	provider.RegisterChangeEventSource("aws", newChangeEventSource)
+}
@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedNames(t *testing.T) {
+	eventBody := `{"source":"aws.route53","detail":{"eventName":"ChangeResourceRecordSets","requestParameters":{"hostedZoneId":"Z123","changeBatch":{"changes":[{"action":"UPSERT","resourceRecordSet":{"name":"app.example.com.","type":"A"}},{"action":"DELETE","resourceRecordSet":{"name":"old.example.com.","type":"CNAME"}}]}}}}`
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "a change event yields the touched names without trailing dots",
+			body: eventBody,
+			want: []string{"app.example.com", "old.example.com"},
+		},
+		{
+			name: "an sns wrapped event is unwrapped",
+			body: `{"Type":"Notification","Message":"{\"detail\":{\"requestParameters\":{\"changeBatch\":{\"changes\":[{\"resourceRecordSet\":{\"name\":\"app.example.com.\"}}]}}}}"}`,
+			want: []string{"app.example.com"},
+		},
+		{
+			name: "an unrelated event yields no names",
+			body: `{"source":"aws.route53","detail":{"eventName":"CreateHostedZone"}}`,
+			want: nil,
+		},
+		{
+			name: "an unparseable body yields no names",
+			body: "not json",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := changedNames(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changedNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
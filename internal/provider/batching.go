@@ -0,0 +1,97 @@
+package provider
+
+import (
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+// BatchLimits caps the size of a single change batch submitted to a
+// provider. Zero valued fields mean no limit of that kind.
+type BatchLimits struct {
+	// MaxChanges is the maximum number of record set changes per batch. An
+	// update pair counts as one change.
+	MaxChanges int
+	// MaxValueBytes is the maximum combined length of all target values in
+	// a batch, e.g. the 32000 character Route53 request limit.
+	MaxValueBytes int
+}
+
+// Limited returns true when at least one limit is set.
+func (l BatchLimits) Limited() bool {
+	return l.MaxChanges > 0 || l.MaxValueBytes > 0
+}
+
+// BatchLimiter is implemented by providers whose change API rejects batches
+// above a certain size, e.g. Route53 with its 1000 changes and 32000 value
+// characters per request. Plans exceeding the limits are split and applied
+// as multiple sequential batches.
+type BatchLimiter interface {
+	BatchLimits() BatchLimits
+}
+
+// BatchLimitsOf returns the batch limits of a provider, or unlimited limits
+// when it does not report any.
+func BatchLimitsOf(p Provider) BatchLimits {
+	if limiter, ok := As[BatchLimiter](p); ok {
+		return limiter.BatchLimits()
+	}
+	return BatchLimits{}
+}
+
+// SplitChanges splits a change set into batches that each respect the given
+// limits, preserving the order of changes and keeping update pairs together.
+// A record set that alone exceeds the value limit still gets its own batch,
+// an RRset can not be split. With no limits set the changes are returned as
+// a single batch.
+func SplitChanges(changes *externaldnsplan.Changes, limits BatchLimits) []*externaldnsplan.Changes {
+	if !limits.Limited() {
+		return []*externaldnsplan.Changes{changes}
+	}
+
+	var batches []*externaldnsplan.Changes
+	current := &externaldnsplan.Changes{}
+	count, bytes := 0, 0
+
+	add := func(valueBytes int, appendTo func(*externaldnsplan.Changes)) {
+		if count > 0 && ((limits.MaxChanges > 0 && count+1 > limits.MaxChanges) ||
+			(limits.MaxValueBytes > 0 && bytes+valueBytes > limits.MaxValueBytes)) {
+			batches = append(batches, current)
+			current = &externaldnsplan.Changes{}
+			count, bytes = 0, 0
+		}
+		appendTo(current)
+		count++
+		bytes += valueBytes
+	}
+
+	// deletes go first so a batch creating a record set never races the
+	// batch removing its predecessor
+	for _, ep := range changes.Delete {
+		ep := ep
+		add(targetBytes(ep), func(b *externaldnsplan.Changes) { b.Delete = append(b.Delete, ep) })
+	}
+	for i := range changes.UpdateNew {
+		oldEndpoint, newEndpoint := changes.UpdateOld[i], changes.UpdateNew[i]
+		add(targetBytes(newEndpoint), func(b *externaldnsplan.Changes) {
+			b.UpdateOld = append(b.UpdateOld, oldEndpoint)
+			b.UpdateNew = append(b.UpdateNew, newEndpoint)
+		})
+	}
+	for _, ep := range changes.Create {
+		ep := ep
+		add(targetBytes(ep), func(b *externaldnsplan.Changes) { b.Create = append(b.Create, ep) })
+	}
+
+	if count > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+func targetBytes(ep *externaldnsendpoint.Endpoint) int {
+	bytes := 0
+	for _, target := range ep.Targets {
+		bytes += len(target)
+	}
+	return bytes
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+func TestSplitChanges(t *testing.T) {
+	endpoints := func(count int, target string) []*externaldnsendpoint.Endpoint {
+		eps := make([]*externaldnsendpoint.Endpoint, count)
+		for i := range eps {
+			eps[i] = &externaldnsendpoint.Endpoint{
+				DNSName:    fmt.Sprintf("host%d.example.com", i),
+				RecordType: externaldnsendpoint.RecordTypeA,
+				Targets:    []string{target},
+			}
+		}
+		return eps
+	}
+
+	t.Run("no limits yields a single batch", func(t *testing.T) {
+		changes := &externaldnsplan.Changes{Create: endpoints(5, "1.1.1.1")}
+		batches := SplitChanges(changes, BatchLimits{})
+		if len(batches) != 1 || batches[0] != changes {
+			t.Errorf("SplitChanges() = %d batches, want the original changes unsplit", len(batches))
+		}
+	})
+
+	t.Run("change count limit splits and keeps deletes first", func(t *testing.T) {
+		changes := &externaldnsplan.Changes{
+			Create:    endpoints(2, "1.1.1.1"),
+			UpdateOld: endpoints(1, "2.2.2.2"),
+			UpdateNew: endpoints(1, "3.3.3.3"),
+			Delete:    endpoints(2, "4.4.4.4"),
+		}
+		batches := SplitChanges(changes, BatchLimits{MaxChanges: 2})
+		if len(batches) != 3 {
+			t.Fatalf("SplitChanges() = %d batches, want 3", len(batches))
+		}
+		if len(batches[0].Delete) != 2 {
+			t.Errorf("first batch has %d deletes, want 2", len(batches[0].Delete))
+		}
+		if len(batches[1].UpdateOld) != 1 || len(batches[1].UpdateNew) != 1 || len(batches[1].Create) != 1 {
+			t.Errorf("second batch should hold the update pair and one create, got %+v", batches[1])
+		}
+		if len(batches[2].Create) != 1 {
+			t.Errorf("third batch has %d creates, want 1", len(batches[2].Create))
+		}
+	})
+
+	t.Run("value byte limit splits oversized batches", func(t *testing.T) {
+		changes := &externaldnsplan.Changes{Create: endpoints(3, "1.1.1.1")}
+		batches := SplitChanges(changes, BatchLimits{MaxValueBytes: 15})
+		if len(batches) != 2 {
+			t.Fatalf("SplitChanges() = %d batches, want 2", len(batches))
+		}
+		if len(batches[0].Create) != 2 || len(batches[1].Create) != 1 {
+			t.Errorf("batches hold %d and %d creates, want 2 and 1", len(batches[0].Create), len(batches[1].Create))
+		}
+	})
+
+	t.Run("a record set over the value limit still gets a batch", func(t *testing.T) {
+		changes := &externaldnsplan.Changes{Create: endpoints(1, "a-very-long-target-value.example.com")}
+		batches := SplitChanges(changes, BatchLimits{MaxValueBytes: 10})
+		if len(batches) != 1 || len(batches[0].Create) != 1 {
+			t.Errorf("SplitChanges() = %d batches, want the oversized record set alone in one", len(batches))
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// GeoGranularity is how fine-grained a providers geo routing is.
+type GeoGranularity string
+
+const (
+	// GeoGranularityNone means the provider has no geo routing.
+	GeoGranularityNone GeoGranularity = "None"
+	// GeoGranularityContinent routes by continent only.
+	GeoGranularityContinent GeoGranularity = "Continent"
+	// GeoGranularityCountry routes by continent and country.
+	GeoGranularityCountry GeoGranularity = "Country"
+	// GeoGranularitySubdivision routes down to country subdivisions.
+	GeoGranularitySubdivision GeoGranularity = "Subdivision"
+)
+
+// Capabilities is the feature matrix of a provider implementation, used to
+// reject specs the selected provider can not satisfy before they are
+// applied.
+type Capabilities struct {
+	// RecordTypes the provider can publish.
+	RecordTypes []string
+	// GeoGranularity of the providers geo routing.
+	GeoGranularity GeoGranularity
+	// Weighted is true when the provider supports weighted responses.
+	Weighted bool
+	// Alias is true when the provider supports alias/flattened records at
+	// a zone apex.
+	Alias bool
+	// MaxTargetsPerRRset caps the targets of a single RRset. Zero means no
+	// limit.
+	MaxTargetsPerRRset int
+}
+
+// CapabilityReporter is implemented by providers reporting their feature
+// matrix.
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// DefaultCapabilities is the conservative matrix assumed for providers that
+// do not report one: plain record hosting without routing features.
+var DefaultCapabilities = Capabilities{
+	RecordTypes:    []string{externaldnsendpoint.RecordTypeA, externaldnsendpoint.RecordTypeAAAA, externaldnsendpoint.RecordTypeCNAME, externaldnsendpoint.RecordTypeTXT},
+	GeoGranularity: GeoGranularityNone,
+}
+
+// CapabilitiesOf returns the capability matrix of a provider, falling back
+// to DefaultCapabilities when it does not report one.
+func CapabilitiesOf(p Provider) Capabilities {
+	if reporter, ok := As[CapabilityReporter](p); ok {
+		return reporter.Capabilities()
+	}
+	return DefaultCapabilities
+}
+
+// geo routing provider specific properties, as produced by the endpoints
+// builder.
+var geoProperties = []string{"geo-code", "geoproximity-region"}
+
+// Check returns an error describing the first endpoint the capabilities can
+// not satisfy, or nil when the whole spec is supported.
+func (c Capabilities) Check(endpoints []*externaldnsendpoint.Endpoint) error {
+	for _, endpoint := range endpoints {
+		if !contains(c.RecordTypes, endpoint.RecordType) {
+			return fmt.Errorf("endpoint %q: the provider does not support %s records", endpoint.DNSName, endpoint.RecordType)
+		}
+		if c.MaxTargetsPerRRset > 0 && len(endpoint.Targets) > c.MaxTargetsPerRRset {
+			return fmt.Errorf("endpoint %q: %d targets exceed the provider limit of %d per RRset", endpoint.DNSName, len(endpoint.Targets), c.MaxTargetsPerRRset)
+		}
+		if !c.Weighted {
+			if _, ok := endpoint.GetProviderSpecificProperty("weight"); ok {
+				return fmt.Errorf("endpoint %q: the provider does not support weighted records", endpoint.DNSName)
+			}
+		}
+		if c.GeoGranularity == GeoGranularityNone || c.GeoGranularity == "" {
+			for _, property := range geoProperties {
+				if _, ok := endpoint.GetProviderSpecificProperty(property); ok {
+					return fmt.Errorf("endpoint %q: the provider does not support geo routed records", endpoint.DNSName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// String renders the matrix for status messages.
+func (c Capabilities) String() string {
+	return fmt.Sprintf("recordTypes=%v geo=%s weighted=%t alias=%t maxTargetsPerRRset=%d",
+		c.RecordTypes, c.GeoGranularity, c.Weighted, c.Alias, c.MaxTargetsPerRRset)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestCapabilitiesCheck(t *testing.T) {
+	weighted := &externaldnsendpoint.Endpoint{
+		DNSName:    "lb.example.com",
+		RecordType: externaldnsendpoint.RecordTypeCNAME,
+		Targets:    []string{"cluster.example.com"},
+		ProviderSpecific: externaldnsendpoint.ProviderSpecific{
+			{Name: "weight", Value: "100"},
+		},
+	}
+	geo := &externaldnsendpoint.Endpoint{
+		DNSName:    "geo.example.com",
+		RecordType: externaldnsendpoint.RecordTypeCNAME,
+		Targets:    []string{"cluster.example.com"},
+		ProviderSpecific: externaldnsendpoint.ProviderSpecific{
+			{Name: "geo-code", Value: "EU"},
+		},
+	}
+	plain := &externaldnsendpoint.Endpoint{
+		DNSName:    "app.example.com",
+		RecordType: externaldnsendpoint.RecordTypeA,
+		Targets:    []string{"1.1.1.1", "2.2.2.2"},
+	}
+
+	full := Capabilities{
+		RecordTypes:    []string{externaldnsendpoint.RecordTypeA, externaldnsendpoint.RecordTypeCNAME},
+		GeoGranularity: GeoGranularityCountry,
+		Weighted:       true,
+	}
+	if err := full.Check([]*externaldnsendpoint.Endpoint{weighted, geo, plain}); err != nil {
+		t.Errorf("unexpected error from a fully capable provider: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		capabilities Capabilities
+		endpoint     *externaldnsendpoint.Endpoint
+		wantContains string
+	}{
+		{
+			name:         "unsupported record type",
+			capabilities: Capabilities{RecordTypes: []string{externaldnsendpoint.RecordTypeCNAME}},
+			endpoint:     plain,
+			wantContains: "does not support A records",
+		},
+		{
+			name:         "weight without weighted support",
+			capabilities: DefaultCapabilities,
+			endpoint:     weighted,
+			wantContains: "does not support weighted records",
+		},
+		{
+			name:         "geo without geo support",
+			capabilities: DefaultCapabilities,
+			endpoint:     geo,
+			wantContains: "does not support geo routed records",
+		},
+		{
+			name: "too many targets",
+			capabilities: Capabilities{
+				RecordTypes:        []string{externaldnsendpoint.RecordTypeA},
+				MaxTargetsPerRRset: 1,
+			},
+			endpoint:     plain,
+			wantContains: "exceed the provider limit",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.capabilities.Check([]*externaldnsendpoint.Endpoint{testCase.endpoint})
+			if err == nil || !strings.Contains(err.Error(), testCase.wantContains) {
+				t.Errorf("Check() = %v, want error containing %q", err, testCase.wantContains)
+			}
+		})
+	}
+}
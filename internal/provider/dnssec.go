@@ -0,0 +1,25 @@
+package provider
+
+import "context"
+
+// DNSSECManager is implemented by providers able to manage DNSSEC signing of
+// their zones (e.g. Route53 KSKs, Cloudflare, Google Cloud DNS). Providers
+// without signing support simply do not implement it.
+type DNSSECManager interface {
+	// EnsureDNSSEC turns on signing for the zone if it is not already
+	// enabled and returns the current signing state.
+	EnsureDNSSEC(ctx context.Context, zone *DNSZone) (*DNSSECStatus, error)
+}
+
+// DNSSECStatus is the signing state of a zone.
+type DNSSECStatus struct {
+	// Enabled is true once the provider is signing the zone.
+	Enabled bool
+	// DSRecords are the delegation signer records that must be placed in
+	// the parent zone for the chain of trust to validate.
+	DSRecords []string
+	// Healthy is true while the provider reports no signing problems.
+	Healthy bool
+	// Message carries provider detail about an unhealthy signing state.
+	Message string
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ChangeEventSource delivers the DNS names changed out of band in a
+// providers zones, read from a provider native notification channel (e.g.
+// Route53 events via EventBridge/SQS). An empty batch means changes
+// happened but the names could not be determined.
+type ChangeEventSource interface {
+	// Receive blocks until a batch of changed dns names arrives or the
+	// context is done.
+	Receive(ctx context.Context) ([]string, error)
+}
+
+// ChangeEventSourceConstructor builds a change event source for a provider
+// secret, returning nil when the secret does not configure one.
+type ChangeEventSourceConstructor func(ctx context.Context, s *corev1.Secret) (ChangeEventSource, error)
+
+var (
+	eventSourceConstructors     = make(map[string]ChangeEventSourceConstructor)
+	eventSourceConstructorsLock sync.RWMutex
+)
+
+// RegisterChangeEventSource registers a change event source constructor for
+// the named provider, mirroring RegisterProvider.
+func RegisterChangeEventSource(name string, c ChangeEventSourceConstructor) {
+	eventSourceConstructorsLock.Lock()
+	defer eventSourceConstructorsLock.Unlock()
+	eventSourceConstructors[name] = c
+}
+
+// ChangeEventSourceFor returns the change event source the given provider
+// secret configures, or nil when its provider has no registered source or
+// the secret does not configure one.
+func ChangeEventSourceFor(ctx context.Context, secret *corev1.Secret) (ChangeEventSource, error) {
+	providerName, err := NameForProviderSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	eventSourceConstructorsLock.RLock()
+	constructor, ok := eventSourceConstructors[providerName]
+	eventSourceConstructorsLock.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	return constructor(ctx, secret)
+}
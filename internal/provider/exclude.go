@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// ExcludeDomainFilterKey is the provider secret data key listing domains
+// (comma separated) whose subtrees must never be managed through that
+// secret, even when they match the include domain filter. It protects
+// sensitive sub-zones living under an otherwise managed domain.
+const ExcludeDomainFilterKey = "EXCLUDE_DOMAIN_FILTER"
+
+// HostExcluded returns true if the host equals or lives under one of the
+// excluded domains. A wildcard prefix on the host is ignored, `*.internal.example.com`
+// is covered by an exclusion of `internal.example.com`.
+func HostExcluded(host string, exclusions []string) bool {
+	host = strings.TrimPrefix(host, v1alpha1.WildcardPrefix)
+	for _, domain := range exclusions {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterExcludedZones drops zones that equal or live under an excluded
+// domain, so zone assignment can never select them.
+func FilterExcludedZones(zones []DNSZone, exclusions []string) []DNSZone {
+	if len(exclusions) == 0 {
+		return zones
+	}
+	var filtered []DNSZone
+	for _, zone := range zones {
+		if !HostExcluded(zone.DNSName, exclusions) {
+			filtered = append(filtered, zone)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHostExcluded(t *testing.T) {
+	exclusions := []string{"internal.example.com"}
+
+	testCases := []struct {
+		host string
+		want bool
+	}{
+		{host: "internal.example.com", want: true},
+		{host: "app.internal.example.com", want: true},
+		{host: "*.internal.example.com", want: true},
+		{host: "app.example.com", want: false},
+		{host: "notinternal.example.com", want: false},
+	}
+	for _, testCase := range testCases {
+		if got := HostExcluded(testCase.host, exclusions); got != testCase.want {
+			t.Errorf("HostExcluded(%q) = %v, want %v", testCase.host, got, testCase.want)
+		}
+	}
+}
+
+func TestFilterExcludedZones(t *testing.T) {
+	zones := []DNSZone{
+		{ID: "1", DNSName: "example.com"},
+		{ID: "2", DNSName: "internal.example.com"},
+		{ID: "3", DNSName: "other.com"},
+	}
+
+	got := FilterExcludedZones(zones, []string{"internal.example.com"})
+	want := []DNSZone{zones[0], zones[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterExcludedZones() = %v, want %v", got, want)
+	}
+
+	if got := FilterExcludedZones(zones, nil); !reflect.DeepEqual(got, zones) {
+		t.Errorf("expected no exclusions to keep all zones, got %v", got)
+	}
+}
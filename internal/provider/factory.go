@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/external-dns/endpoint"
+	externaldnsprovider "sigs.k8s.io/external-dns/provider"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// provider secret data keys selecting the domains manageable through the
+// secret by regular expression, for naming schemes suffix based filters
+// cannot express. A regex filter replaces the suffix based domain filter.
+const (
+	RegexDomainFilterKey    = "REGEX_DOMAIN_FILTER"
+	RegexDomainExclusionKey = "REGEX_DOMAIN_EXCLUSION"
+)
+
+// provider secret types, the secret type determines the provider implementation used
+const (
+	SecretTypePrefix                          = "kuadrant.io/"
+	SecretTypeKuadrantAWS   corev1.SecretType = SecretTypePrefix + "aws"
+	SecretTypeKuadrantGCP   corev1.SecretType = SecretTypePrefix + "gcp"
+	SecretTypeKuadrantAzure corev1.SecretType = SecretTypePrefix + "azure"
+	SecretTypeKuadrantInMem corev1.SecretType = SecretTypePrefix + "inmemory"
+)
+
+// ProviderConstructor constructs a provider given a Secret resource and a Config.
+// An error will be returned if the appropriate provider is not registered.
+type ProviderConstructor func(ctx context.Context, s *corev1.Secret, c Config) (Provider, error)
+
+var (
+	constructors     = make(map[string]ProviderConstructor)
+	constructorsLock sync.RWMutex
+)
+
+// RegisterProvider will register a provider constructor, so it can be used
+// within the application. 'name' should be unique, and should be used to
+// identify this provider.
+func RegisterProvider(name string, c ProviderConstructor) {
+	constructorsLock.Lock()
+	defer constructorsLock.Unlock()
+	constructors[name] = c
+}
+
+// RegisteredProviders returns the names of all currently registered provider
+// implementations.
+func RegisteredProviders() []string {
+	constructorsLock.RLock()
+	defer constructorsLock.RUnlock()
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	return names
+}
+
+// ProviderAccessor is implemented by resources that reference a provider secret.
+type ProviderAccessor interface {
+	GetNamespace() string
+	GetProviderRef() v1alpha1.ProviderRef
+}
+
+// Factory creates providers for resources that reference a provider secret.
+type Factory interface {
+	ProviderFor(ctx context.Context, pa ProviderAccessor, c Config) (Provider, error)
+}
+
+// factory is the default Factory implementation, reading provider secrets
+// with the controller-runtime client.
+type factory struct {
+	client.Client
+	providers     []string
+	retryDefaults RetryDefaults
+}
+
+// NewFactory returns a new provider factory with the given client, list of
+// enabled providers and API retry defaults. An error is returned if any of
+// the given provider names are not registered.
+func NewFactory(c client.Client, providers []string, retryDefaults RetryDefaults) (Factory, error) {
+	var err error
+	registered := RegisteredProviders()
+	for _, p := range providers {
+		if !contains(registered, p) {
+			err = errors.Join(err, fmt.Errorf("provider %q is not registered", p))
+		}
+	}
+	return &factory{Client: c, providers: providers, retryDefaults: retryDefaults}, err
+}
+
+// ProviderFor will return a Provider interface for the given ProviderAccessor
+// secret. If the referenced secret does not exist, or is of a type that is
+// not enabled, an error will be returned.
+func (f *factory) ProviderFor(ctx context.Context, pa ProviderAccessor, c Config) (Provider, error) {
+	logger := log.FromContext(ctx)
+
+	providerSecret := &corev1.Secret{}
+	if err := f.Get(ctx, types.NamespacedName{Name: pa.GetProviderRef().Name, Namespace: pa.GetNamespace()}, providerSecret); err != nil {
+		return nil, err
+	}
+
+	providerName, err := NameForProviderSecret(providerSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	if !contains(f.providers, providerName) {
+		return nil, fmt.Errorf("provider %q is not enabled", providerName)
+	}
+
+	if v := strings.TrimSpace(string(providerSecret.Data[ZoneTagFilterKey])); v != "" {
+		c.ZoneTagFilter = externaldnsprovider.NewZoneTagFilter(strings.Split(v, ","))
+	}
+
+	for _, key := range []string{ZoneProjectsKey, AzureSubscriptionsKey, AzureResourceGroupsKey} {
+		for _, project := range strings.Split(string(providerSecret.Data[key]), ",") {
+			if project = strings.TrimSpace(project); project != "" {
+				c.ZoneProjects = append(c.ZoneProjects, project)
+			}
+		}
+	}
+
+	c, err = applyDomainFilters(providerSecret, c)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := f.retryDefaults
+	if v := strings.TrimSpace(string(providerSecret.Data[APITimeoutKey])); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", APITimeoutKey, err)
+		}
+		retry.Timeout = timeout
+	}
+	if v := strings.TrimSpace(string(providerSecret.Data[APIRetriesKey])); v != "" {
+		retries, err := strconv.Atoi(v)
+		if err != nil || retries < 0 {
+			return nil, fmt.Errorf("invalid %s: %q", APIRetriesKey, v)
+		}
+		retry.Retries = retries
+	}
+
+	constructorsLock.RLock()
+	defer constructorsLock.RUnlock()
+	if constructor, ok := constructors[providerName]; ok {
+		logger.V(1).Info(fmt.Sprintf("found constructor for provider secret: %s", providerSecret.Name))
+		p, err := constructor(ctx, providerSecret, c)
+		if err != nil {
+			return nil, err
+		}
+		if retry.Timeout > 0 || retry.Retries > 0 {
+			p = &retryProvider{Provider: p, timeout: retry.Timeout, retries: retry.Retries}
+		}
+		return p, nil
+	}
+
+	return nil, fmt.Errorf("provider %q not registered", providerName)
+}
+
+// applyDomainFilters applies the domain filter keys of a provider secret to
+// the config: a regex filter replaces the suffix based domain filter
+// entirely, and excluded subtrees are folded into whichever filter is in
+// place so the underlying provider also skips them.
+func applyDomainFilters(providerSecret *corev1.Secret, c Config) (Config, error) {
+	if v := strings.TrimSpace(string(providerSecret.Data[ExcludeDomainFilterKey])); v != "" {
+		for _, domain := range strings.Split(v, ",") {
+			if domain = strings.TrimSpace(domain); domain != "" {
+				c.ExcludeDomains = append(c.ExcludeDomains, domain)
+			}
+		}
+	}
+
+	if v := strings.TrimSpace(string(providerSecret.Data[RegexDomainFilterKey])); v != "" {
+		include, err := regexp.Compile(v)
+		if err != nil {
+			return c, fmt.Errorf("invalid %s: %w", RegexDomainFilterKey, err)
+		}
+		var exclude *regexp.Regexp
+		if e := strings.TrimSpace(string(providerSecret.Data[RegexDomainExclusionKey])); e != "" {
+			if exclude, err = regexp.Compile(e); err != nil {
+				return c, fmt.Errorf("invalid %s: %w", RegexDomainExclusionKey, err)
+			}
+		}
+		// a regex filter replaces the suffix based domain filter entirely
+		// and must not be rebuilt from its (empty) suffix list; excluded
+		// subtrees stay enforced through ExcludeDomains during zone
+		// discovery and host exclusion
+		c.DomainFilter = endpoint.NewRegexDomainFilter(include, exclude)
+	} else if len(c.ExcludeDomains) > 0 {
+		// rebuild the suffix filter so the underlying provider also skips
+		// the excluded subtrees
+		c.DomainFilter = endpoint.NewDomainFilterWithExclusions(c.DomainFilter.Filters, c.ExcludeDomains)
+	}
+
+	return c, nil
+}
+
+// NameForProviderSecret returns the provider implementation name for the
+// given provider secret based on its type.
+func NameForProviderSecret(secret *corev1.Secret) (string, error) {
+	switch secret.Type {
+	case SecretTypeKuadrantAWS:
+		return "aws", nil
+	case SecretTypeKuadrantGCP:
+		return "google", nil
+	case SecretTypeKuadrantAzure:
+		return "azure", nil
+	case SecretTypeKuadrantInMem:
+		return "inmemory", nil
+	}
+	return "", fmt.Errorf("unsupported provider secret type: %s", secret.Type)
+}
+
+// IsProviderSecret returns true if the given secret has a kuadrant provider type.
+func IsProviderSecret(secret *corev1.Secret) bool {
+	return strings.HasPrefix(string(secret.Type), SecretTypePrefix)
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
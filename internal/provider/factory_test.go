@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestApplyDomainFilters(t *testing.T) {
+	secret := func(data map[string]string) *corev1.Secret {
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		for k, v := range data {
+			s.Data[k] = []byte(v)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name         string
+		data         map[string]string
+		config       Config
+		wantMatch    []string
+		wantNoMatch  []string
+		wantExcludes []string
+		wantErr      bool
+	}{
+		{
+			name:        "regex filter replaces the suffix filter",
+			data:        map[string]string{RegexDomainFilterKey: `example\.com$`},
+			config:      Config{DomainFilter: endpoint.NewDomainFilter([]string{"other.com"})},
+			wantMatch:   []string{"app.example.com"},
+			wantNoMatch: []string{"app.other.com"},
+		},
+		{
+			name: "excluded subtrees narrow the suffix filter",
+			data: map[string]string{ExcludeDomainFilterKey: "internal.example.com"},
+			config: Config{
+				DomainFilter: endpoint.NewDomainFilter([]string{"example.com"}),
+			},
+			wantMatch:    []string{"app.example.com"},
+			wantNoMatch:  []string{"app.internal.example.com", "internal.example.com"},
+			wantExcludes: []string{"internal.example.com"},
+		},
+		{
+			name: "excluded subtrees do not drop a regex filter",
+			data: map[string]string{
+				RegexDomainFilterKey:   `example\.com$`,
+				ExcludeDomainFilterKey: "internal.example.com",
+			},
+			wantMatch:    []string{"app.example.com"},
+			wantNoMatch:  []string{"app.other.com"},
+			wantExcludes: []string{"internal.example.com"},
+		},
+		{
+			name:    "an invalid regex filter is rejected",
+			data:    map[string]string{RegexDomainFilterKey: `(`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := applyDomainFilters(secret(tt.data), tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyDomainFilters() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, domain := range tt.wantMatch {
+				if !c.DomainFilter.Match(domain) {
+					t.Errorf("DomainFilter.Match(%q) = false, want true", domain)
+				}
+			}
+			for _, domain := range tt.wantNoMatch {
+				if c.DomainFilter.Match(domain) {
+					t.Errorf("DomainFilter.Match(%q) = true, want false", domain)
+				}
+			}
+			if len(tt.wantExcludes) > 0 && len(c.ExcludeDomains) != len(tt.wantExcludes) {
+				t.Errorf("ExcludeDomains = %v, want %v", c.ExcludeDomains, tt.wantExcludes)
+			}
+			for _, domain := range tt.wantExcludes {
+				if !HostExcluded("app."+domain, c.ExcludeDomains) {
+					t.Errorf("HostExcluded(app.%s) = false, want the excluded subtree enforced", domain)
+				}
+			}
+		})
+	}
+}
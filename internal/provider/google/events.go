@@ -0,0 +1,206 @@
+// Package google provides the Google Cloud specific pieces of the operator
+// that do not live in the provider implementation itself, currently the
+// Cloud DNS change event source consuming audit log events from a Pub/Sub
+// subscription.
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+	oauth2google "golang.org/x/oauth2/google"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// ChangeEventsSubscriptionKey is the gcp provider secret data key naming a
+// Pub/Sub subscription receiving Cloud DNS audit log events (a log sink on
+// dns.googleapis.com activity). When set, out-of-band zone changes trigger
+// prompt reconciles of affected records instead of waiting for periodic
+// polling. Either a full "projects/<p>/subscriptions/<s>" path or a bare
+// subscription name within the secrets project is accepted.
+const ChangeEventsSubscriptionKey = "CHANGE_EVENTS_SUBSCRIPTION"
+
+// gcp provider secret data keys holding the credentials, shared with the
+// provider implementation.
+const (
+	credentialsKey = "GOOGLE"
+	projectIDKey   = "PROJECT_ID"
+)
+
+const (
+	pubsubEndpoint = "https://pubsub.googleapis.com/v1/"
+	pubsubScope    = "https://www.googleapis.com/auth/pubsub"
+	pullBatchSize  = 10
+)
+
+// pubsubSource reads Cloud DNS audit log events from a Pub/Sub
+// subscription. The Pub/Sub REST API is used directly, pulling and
+// acknowledging small batches needs no SDK.
+type pubsubSource struct {
+	client       *http.Client
+	subscription string
+}
+
+// newChangeEventSource returns a Pub/Sub backed change event source for the
+// given gcp provider secret, or nil when the secret does not name a
+// subscription.
+func newChangeEventSource(ctx context.Context, s *corev1.Secret) (provider.ChangeEventSource, error) {
+	subscription := strings.TrimSpace(string(s.Data[ChangeEventsSubscriptionKey]))
+	if subscription == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(subscription, "projects/") {
+		projectID := strings.TrimSpace(string(s.Data[projectIDKey]))
+		if projectID == "" {
+			return nil, fmt.Errorf("%s names a bare subscription but %s is not set", ChangeEventsSubscriptionKey, projectIDKey)
+		}
+		subscription = "projects/" + projectID + "/subscriptions/" + subscription
+	}
+
+	credentials, err := oauth2google.CredentialsFromJSON(ctx, s.Data[credentialsKey], pubsubScope)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s credentials for change events: %w", credentialsKey, err)
+	}
+	return &pubsubSource{
+		client:       oauth2.NewClient(ctx, credentials.TokenSource),
+		subscription: subscription,
+	}, nil
+}
+
+// Receive pulls the subscription until messages arrive, returning the dns
+// names their change requests touched. Messages are acknowledged once
+// parsed; a message that does not parse still counts as a change with
+// unknown names.
+func (s *pubsubSource) Receive(ctx context.Context) ([]string, error) {
+	for {
+		received, err := s.pull(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(received) == 0 {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		names := []string{}
+		ackIDs := make([]string, 0, len(received))
+		for _, message := range received {
+			ackIDs = append(ackIDs, message.AckID)
+			data, err := base64.StdEncoding.DecodeString(message.Message.Data)
+			if err != nil {
+				continue
+			}
+			names = append(names, changedNames(data)...)
+		}
+		if err := s.acknowledge(ctx, ackIDs); err != nil {
+			return nil, err
+		}
+		return names, nil
+	}
+}
+
+type receivedMessage struct {
+	AckID   string `json:"ackId"`
+	Message struct {
+		Data string `json:"data"`
+	} `json:"message"`
+}
+
+func (s *pubsubSource) pull(ctx context.Context) ([]receivedMessage, error) {
+	var response struct {
+		ReceivedMessages []receivedMessage `json:"receivedMessages"`
+	}
+	if err := s.post(ctx, "pull", map[string]any{"maxMessages": pullBatchSize}, &response); err != nil {
+		return nil, err
+	}
+	return response.ReceivedMessages, nil
+}
+
+func (s *pubsubSource) acknowledge(ctx context.Context, ackIDs []string) error {
+	if len(ackIDs) == 0 {
+		return nil
+	}
+	return s.post(ctx, "acknowledge", map[string]any{"ackIds": ackIDs}, nil)
+}
+
+func (s *pubsubSource) post(ctx context.Context, verb string, body any, response any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, pubsubEndpoint+s.subscription+":"+verb, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("pubsub %s of %s failed: %s: %s", verb, s.subscription, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// rrset is the subset of a Cloud DNS ResourceRecordSet in audit log change
+// requests.
+type rrset struct {
+	Name string `json:"name"`
+}
+
+// auditLogEntry is the subset of a Cloud DNS audit LogEntry naming the
+// record sets a dns.changes.create request touched. Depending on the sink
+// the change may appear inline in the request or nested under "change".
+type auditLogEntry struct {
+	ProtoPayload struct {
+		Request struct {
+			Additions []rrset `json:"additions"`
+			Deletions []rrset `json:"deletions"`
+			Change    struct {
+				Additions []rrset `json:"additions"`
+				Deletions []rrset `json:"deletions"`
+			} `json:"change"`
+		} `json:"request"`
+	} `json:"protoPayload"`
+}
+
+// changedNames extracts the record set names a Cloud DNS audit log event
+// touched.
+func changedNames(data []byte) []string {
+	var entry auditLogEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	request := entry.ProtoPayload.Request
+	var names []string
+	for _, sets := range [][]rrset{request.Additions, request.Deletions, request.Change.Additions, request.Change.Deletions} {
+		for _, set := range sets {
+			if name := strings.TrimSuffix(set.Name, "."); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func init() {
+	provider.RegisterChangeEventSource("google", newChangeEventSource)
+}
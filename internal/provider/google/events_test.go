@@ -0,0 +1,42 @@
+package google
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedNames(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "an inline change request yields the touched names without trailing dots",
+			data: `{"protoPayload":{"methodName":"dns.changes.create","request":{"additions":[{"name":"app.example.com.","type":"A"}],"deletions":[{"name":"old.example.com.","type":"CNAME"}]}}}`,
+			want: []string{"app.example.com", "old.example.com"},
+		},
+		{
+			name: "a nested change yields the touched names",
+			data: `{"protoPayload":{"request":{"change":{"additions":[{"name":"app.example.com."}]}}}}`,
+			want: []string{"app.example.com"},
+		},
+		{
+			name: "an unrelated event yields no names",
+			data: `{"protoPayload":{"methodName":"dns.managedZones.create"}}`,
+			want: nil,
+		},
+		{
+			name: "an unparseable entry yields no names",
+			data: "not json",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := changedNames([]byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changedNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+func testProvider(t *testing.T, name string, zones string) *InMemoryDNSProvider {
+	t.Helper()
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+		Data:       map[string][]byte{ZonesKey: []byte(zones)},
+	}
+	p, err := NewProviderFromSecret(context.Background(), s, provider.Config{})
+	if err != nil {
+		t.Fatalf("NewProviderFromSecret() error = %v", err)
+	}
+	return p.(*InMemoryDNSProvider)
+}
+
+func TestInMemoryDNSProvider_EnsureDNSSEC(t *testing.T) {
+	p := testProvider(t, "dnssec", "example.com")
+
+	signing, err := p.EnsureDNSSEC(context.Background(), &provider.DNSZone{ID: "example.com", DNSName: "example.com"})
+	if err != nil {
+		t.Fatalf("EnsureDNSSEC() error = %v", err)
+	}
+	if !signing.Enabled || !signing.Healthy {
+		t.Errorf("EnsureDNSSEC() = enabled %t healthy %t, want a healthy signed zone", signing.Enabled, signing.Healthy)
+	}
+	if len(signing.DSRecords) != 1 {
+		t.Fatalf("EnsureDNSSEC() returned %d DS records, want 1", len(signing.DSRecords))
+	}
+
+	// the signing state is stable across calls
+	again, err := p.EnsureDNSSEC(context.Background(), &provider.DNSZone{ID: "example.com", DNSName: "example.com"})
+	if err != nil {
+		t.Fatalf("EnsureDNSSEC() second call error = %v", err)
+	}
+	if again.DSRecords[0] != signing.DSRecords[0] {
+		t.Errorf("EnsureDNSSEC() DS record changed between calls: %q != %q", again.DSRecords[0], signing.DSRecords[0])
+	}
+
+	if _, err := p.EnsureDNSSEC(context.Background(), &provider.DNSZone{ID: "other.com", DNSName: "other.com"}); err == nil {
+		t.Error("EnsureDNSSEC() expected an error for a zone the provider does not manage")
+	}
+}
+
+func TestInMemoryDNSProvider_ImplementsDNSSECManager(t *testing.T) {
+	var p provider.Provider = testProvider(t, "dnssec-as", "example.com")
+	if _, ok := provider.As[provider.DNSSECManager](p); !ok {
+		t.Error("As() did not find the DNSSECManager on the in-memory provider")
+	}
+}
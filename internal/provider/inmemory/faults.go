@@ -0,0 +1,173 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+// Fault injection keys of the provider secret. They let controller tests
+// exercise backoff, throttling and partial failure paths deterministically
+// without a real cloud account.
+const (
+	// FaultLatencyKey is a duration added to every provider call.
+	FaultLatencyKey = "FAULT_LATENCY"
+	// FaultThrottleEveryKey makes every Nth apply fail with a throttling
+	// error.
+	FaultThrottleEveryKey = "FAULT_THROTTLE_EVERY"
+	// FaultFailZonesKey is a comma separated list of zones whose applies
+	// fail after the creates have been written, simulating a partial apply
+	// failure.
+	FaultFailZonesKey = "FAULT_FAIL_ZONES"
+	// FaultPropagationDelayKey is a duration between a successful apply and
+	// its changes becoming visible in Records, simulating provider
+	// propagation so AwaitingValidation paths can be tested
+	// deterministically.
+	FaultPropagationDelayKey = "FAULT_PROPAGATION_DELAY"
+)
+
+// faults holds the fault injection configuration of a provider instance.
+type faults struct {
+	latency          time.Duration
+	throttleEvery    int
+	failZones        []string
+	propagationDelay time.Duration
+
+	mu      sync.Mutex
+	applies int
+	pending []pendingChanges
+}
+
+// pendingChanges is an applied change batch not yet visible in Records.
+type pendingChanges struct {
+	visibleAt time.Time
+	changes   *externaldnsplan.Changes
+}
+
+// faultsFromSecret parses the fault injection keys of a provider secret.
+func faultsFromSecret(s *corev1.Secret) (*faults, error) {
+	f := &faults{}
+	if v := string(s.Data[FaultLatencyKey]); v != "" {
+		latency, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", FaultLatencyKey, err)
+		}
+		f.latency = latency
+	}
+	if v := string(s.Data[FaultThrottleEveryKey]); v != "" {
+		every, err := strconv.Atoi(v)
+		if err != nil || every < 1 {
+			return nil, fmt.Errorf("invalid %s: %q", FaultThrottleEveryKey, v)
+		}
+		f.throttleEvery = every
+	}
+	if v := string(s.Data[FaultFailZonesKey]); v != "" {
+		f.failZones = strings.Split(v, ",")
+	}
+	if v := string(s.Data[FaultPropagationDelayKey]); v != "" {
+		delay, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", FaultPropagationDelayKey, err)
+		}
+		f.propagationDelay = delay
+	}
+	return f, nil
+}
+
+// Records applies the configured latency, flushes propagated pending
+// changes, and lists the visible records.
+func (p *InMemoryDNSProvider) Records(ctx context.Context) ([]*externaldnsendpoint.Endpoint, error) {
+	p.injectLatency()
+	if err := p.flushPropagated(ctx); err != nil {
+		return nil, err
+	}
+	return p.InMemoryProvider.Records(ctx)
+}
+
+// ApplyChanges applies the configured faults around record changes.
+func (p *InMemoryDNSProvider) ApplyChanges(ctx context.Context, changes *externaldnsplan.Changes) error {
+	p.injectLatency()
+
+	if p.faults.throttleEvery > 0 {
+		p.faults.applies++
+		if p.faults.applies%p.faults.throttleEvery == 0 {
+			return fmt.Errorf("injected fault: request throttled")
+		}
+	}
+
+	if p.faults.propagationDelay > 0 {
+		// accept the batch but only make it visible once the simulated
+		// propagation delay has elapsed
+		p.faults.mu.Lock()
+		defer p.faults.mu.Unlock()
+		p.faults.pending = append(p.faults.pending, pendingChanges{
+			visibleAt: time.Now().Add(p.faults.propagationDelay),
+			changes:   changes,
+		})
+		return nil
+	}
+
+	if zone := p.failingZone(changes); zone != "" {
+		// write the creates before failing so the failure is partial
+		if len(changes.Create) > 0 {
+			if err := p.InMemoryProvider.ApplyChanges(ctx, &externaldnsplan.Changes{Create: changes.Create}); err != nil {
+				return err
+			}
+		}
+		return fmt.Errorf("injected fault: apply failed for zone %s after creates", zone)
+	}
+
+	return p.InMemoryProvider.ApplyChanges(ctx, changes)
+}
+
+// flushPropagated applies pending change batches whose propagation delay has
+// elapsed.
+func (p *InMemoryDNSProvider) flushPropagated(ctx context.Context) error {
+	p.faults.mu.Lock()
+	defer p.faults.mu.Unlock()
+	remaining := p.faults.pending[:0]
+	for _, pending := range p.faults.pending {
+		if time.Now().Before(pending.visibleAt) {
+			remaining = append(remaining, pending)
+			continue
+		}
+		if err := p.InMemoryProvider.ApplyChanges(ctx, pending.changes); err != nil {
+			return err
+		}
+	}
+	p.faults.pending = remaining
+	return nil
+}
+
+func (p *InMemoryDNSProvider) injectLatency() {
+	if p.faults.latency > 0 {
+		time.Sleep(p.faults.latency)
+	}
+}
+
+// failingZone returns the first configured failure zone the change batch
+// touches.
+func (p *InMemoryDNSProvider) failingZone(changes *externaldnsplan.Changes) string {
+	touches := func(zone string, endpoints []*externaldnsendpoint.Endpoint) bool {
+		for _, endpoint := range endpoints {
+			if endpoint.DNSName == zone || strings.HasSuffix(endpoint.DNSName, "."+zone) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, zone := range p.faults.failZones {
+		if touches(zone, changes.Create) || touches(zone, changes.UpdateNew) || touches(zone, changes.Delete) {
+			return zone
+		}
+	}
+	return ""
+}
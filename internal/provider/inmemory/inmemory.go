@@ -0,0 +1,176 @@
+// Package inmemory implements a DNS provider backed by the external-dns
+// in-memory provider. It is used for testing and local development, state is
+// shared between all reconciles of the same provider secret but is lost on
+// restart.
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	externaldnsprovider "sigs.k8s.io/external-dns/provider"
+	externaldnsinmemory "sigs.k8s.io/external-dns/provider/inmemory"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// ZonesKey is the provider secret data key listing the zones (comma
+// separated domain names) the in-memory provider manages. An entry may be
+// prefixed with a fake provider side scope ("project:zone"), so
+// cross-project zone discovery can be exercised without a real cloud
+// account.
+const ZonesKey = "ZONES"
+
+// ZoneTagsKey is the provider secret data key assigning fake tags to the
+// zones (comma separated "zone/key=value" entries), so tag based zone
+// selection can be exercised without a real cloud account.
+const ZoneTagsKey = "ZONE_TAGS"
+
+type InMemoryDNSProvider struct {
+	*externaldnsinmemory.InMemoryProvider
+	zones          []string
+	zoneProjects   map[string]string
+	zoneTags       map[string]map[string]string
+	zoneTagFilter  externaldnsprovider.ZoneTagFilter
+	projectFilter  []string
+	excludeDomains []string
+	faults         *faults
+
+	// signed tracks the zones DNSSEC signing has been enabled for.
+	signedLock sync.Mutex
+	signed     map[string]bool
+
+	// redirects tracks the apex redirect constructs by apex host.
+	redirectsLock sync.Mutex
+	redirects     map[string]apexRedirect
+}
+
+var _ provider.Provider = &InMemoryDNSProvider{}
+
+var (
+	// providers are shared between reconciles keyed by the provider secret
+	// namespace/name so that applied records persist for the lifetime of the
+	// process.
+	providers     = map[string]*InMemoryDNSProvider{}
+	providersLock sync.Mutex
+)
+
+// NewProviderFromSecret returns an in-memory provider for the given provider
+// secret, creating and initializing it with the secrets zone list on first use.
+func NewProviderFromSecret(_ context.Context, s *corev1.Secret, c provider.Config) (provider.Provider, error) {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+
+	key := s.Namespace + "/" + s.Name
+	if p, ok := providers[key]; ok {
+		return p, nil
+	}
+
+	var zones []string
+	zoneProjects := map[string]string{}
+	if z := string(s.Data[ZonesKey]); z != "" {
+		for _, entry := range strings.Split(z, ",") {
+			project, zone, found := strings.Cut(entry, ":")
+			if !found {
+				zone = entry
+			} else {
+				zoneProjects[zone] = project
+			}
+			zones = append(zones, zone)
+		}
+	}
+
+	zoneTags, err := zoneTagsFromSecret(s)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := faultsFromSecret(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &InMemoryDNSProvider{
+		InMemoryProvider: externaldnsinmemory.NewInMemoryProvider(
+			externaldnsinmemory.InMemoryInitZones(zones),
+			externaldnsinmemory.InMemoryWithDomain(c.DomainFilter),
+			externaldnsinmemory.InMemoryWithLogging(),
+		),
+		zones:          zones,
+		zoneProjects:   zoneProjects,
+		zoneTags:       zoneTags,
+		zoneTagFilter:  c.ZoneTagFilter,
+		projectFilter:  c.ZoneProjects,
+		excludeDomains: c.ExcludeDomains,
+		faults:         f,
+		signed:         map[string]bool{},
+		redirects:      map[string]apexRedirect{},
+	}
+	providers[key] = p
+	return p, nil
+}
+
+// DNSZones returns the zones the provider was initialized with, minus any
+// excluded subtrees and zones not matching the configured tag and project
+// filters.
+func (p *InMemoryDNSProvider) DNSZones(_ context.Context) ([]provider.DNSZone, error) {
+	zones := make([]provider.DNSZone, 0, len(p.zones))
+	for _, z := range p.zones {
+		zones = append(zones, provider.DNSZone{ID: z, DNSName: z, Tags: p.zoneTags[z], Project: p.zoneProjects[z]})
+	}
+	zones = provider.FilterZonesByTags(zones, p.zoneTagFilter)
+	zones = provider.FilterZonesByProject(zones, p.projectFilter)
+	return provider.FilterExcludedZones(zones, p.excludeDomains), nil
+}
+
+// zoneTagsFromSecret parses the fake zone tags of a provider secret.
+func zoneTagsFromSecret(s *corev1.Secret) (map[string]map[string]string, error) {
+	tags := map[string]map[string]string{}
+	v := strings.TrimSpace(string(s.Data[ZoneTagsKey]))
+	if v == "" {
+		return tags, nil
+	}
+	for _, entry := range strings.Split(v, ",") {
+		zone, tag, found := strings.Cut(strings.TrimSpace(entry), "/")
+		if !found || zone == "" || tag == "" {
+			return nil, fmt.Errorf("invalid %s entry %q, expected \"zone/key=value\"", ZoneTagsKey, entry)
+		}
+		key, value, _ := strings.Cut(tag, "=")
+		if tags[zone] == nil {
+			tags[zone] = map[string]string{}
+		}
+		tags[zone][key] = value
+	}
+	return tags, nil
+}
+
+// DNSZoneForHost returns the zone with the longest matching suffix for the given host.
+func (p *InMemoryDNSProvider) DNSZoneForHost(ctx context.Context, host string) (*provider.DNSZone, error) {
+	if provider.HostExcluded(host, p.excludeDomains) {
+		return nil, fmt.Errorf("host %s is excluded by the provider domain filter", host)
+	}
+	zones, err := p.DNSZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.FindDNSZoneForHost(host, zones, true)
+}
+
+// Capabilities reports the feature matrix of the in-memory provider, which
+// stores any routing property it is given.
+func (p *InMemoryDNSProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes:    []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT},
+		GeoGranularity: provider.GeoGranularityCountry,
+		Weighted:       true,
+	}
+}
+
+func init() {
+	provider.RegisterProvider("inmemory", NewProviderFromSecret)
+}
@@ -0,0 +1,96 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	externaldnsprovider "sigs.k8s.io/external-dns/provider"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+func TestInMemoryDNSProvider_ZoneTagFilter(t *testing.T) {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "zone-tags"},
+		Data: map[string][]byte{
+			ZonesKey:    []byte("payments.example.com,platform.example.com"),
+			ZoneTagsKey: []byte("payments.example.com/team=payments,payments.example.com/env=prod"),
+		},
+	}
+	p, err := NewProviderFromSecret(context.Background(), s, provider.Config{
+		ZoneTagFilter: externaldnsprovider.NewZoneTagFilter([]string{"team=payments"}),
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFromSecret() error = %v", err)
+	}
+
+	zones, err := p.DNSZones(context.Background())
+	if err != nil {
+		t.Fatalf("DNSZones() error = %v", err)
+	}
+	if len(zones) != 1 || zones[0].DNSName != "payments.example.com" {
+		t.Fatalf("DNSZones() = %v, want only payments.example.com", zones)
+	}
+	if zones[0].Tags["env"] != "prod" {
+		t.Errorf("DNSZones() tags = %v, want env=prod carried through", zones[0].Tags)
+	}
+
+	if _, err := p.DNSZoneForHost(context.Background(), "app.payments.example.com"); err != nil {
+		t.Errorf("DNSZoneForHost() error = %v for a host in the tagged zone", err)
+	}
+	if _, err := p.DNSZoneForHost(context.Background(), "app.platform.example.com"); err == nil {
+		t.Error("DNSZoneForHost() expected an error for a host in a zone the tag filter drops")
+	}
+}
+
+func TestInMemoryDNSProvider_ZoneProjects(t *testing.T) {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "zone-projects"},
+		Data: map[string][]byte{
+			ZonesKey: []byte("proj-a:a.example.com,proj-b:b.example.com,c.example.com"),
+		},
+	}
+	p, err := NewProviderFromSecret(context.Background(), s, provider.Config{
+		ZoneProjects: []string{"proj-b"},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFromSecret() error = %v", err)
+	}
+
+	zones, err := p.DNSZones(context.Background())
+	if err != nil {
+		t.Fatalf("DNSZones() error = %v", err)
+	}
+	if len(zones) != 1 || zones[0].DNSName != "b.example.com" {
+		t.Fatalf("DNSZones() = %v, want only b.example.com", zones)
+	}
+	if zones[0].Project != "proj-b" {
+		t.Errorf("DNSZones() project = %q, want proj-b", zones[0].Project)
+	}
+
+	zone, err := p.DNSZoneForHost(context.Background(), "app.b.example.com")
+	if err != nil {
+		t.Fatalf("DNSZoneForHost() error = %v for a host in the scoped zone", err)
+	}
+	if zone.Project != "proj-b" {
+		t.Errorf("DNSZoneForHost() project = %q, want proj-b", zone.Project)
+	}
+	if _, err := p.DNSZoneForHost(context.Background(), "app.a.example.com"); err == nil {
+		t.Error("DNSZoneForHost() expected an error for a host in a zone outside the searched projects")
+	}
+}
+
+func TestZoneTagsFromSecret_Invalid(t *testing.T) {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "zone-tags-invalid"},
+		Data: map[string][]byte{
+			ZonesKey:    []byte("example.com"),
+			ZoneTagsKey: []byte("team=payments"),
+		},
+	}
+	if _, err := NewProviderFromSecret(context.Background(), s, provider.Config{}); err == nil {
+		t.Error("NewProviderFromSecret() expected an error for a zone tag entry without a zone")
+	}
+}
@@ -0,0 +1,40 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+var _ provider.ApexRedirector = &InMemoryDNSProvider{}
+
+// apexRedirect is a redirect construct held by the in-memory provider.
+type apexRedirect struct {
+	targetHost string
+	permanent  bool
+}
+
+// EnsureApexRedirect records the redirect from apexHost to targetHost,
+// standing in for the provider-native redirect construct so controller apex
+// redirect paths can be exercised without a real provider. Redirects are
+// kept for the lifetime of the provider instance.
+func (p *InMemoryDNSProvider) EnsureApexRedirect(_ context.Context, zone *provider.DNSZone, apexHost, targetHost string, permanent bool) error {
+	if !p.managesZone(zone.DNSName) {
+		return fmt.Errorf("zone %s is not managed by this provider", zone.DNSName)
+	}
+
+	p.redirectsLock.Lock()
+	defer p.redirectsLock.Unlock()
+	p.redirects[apexHost] = apexRedirect{targetHost: targetHost, permanent: permanent}
+	return nil
+}
+
+// ApexRedirect returns the redirect held for apexHost, if any, so tests can
+// assert on the constructs the controller requested.
+func (p *InMemoryDNSProvider) ApexRedirect(apexHost string) (targetHost string, permanent, ok bool) {
+	p.redirectsLock.Lock()
+	defer p.redirectsLock.Unlock()
+	redirect, ok := p.redirects[apexHost]
+	return redirect.targetHost, redirect.permanent, ok
+}
@@ -0,0 +1,43 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+func TestInMemoryDNSProvider_EnsureApexRedirect(t *testing.T) {
+	p := testProvider(t, "redirect", "example.com")
+	zone := &provider.DNSZone{ID: "example.com", DNSName: "example.com"}
+
+	if err := p.EnsureApexRedirect(context.Background(), zone, "example.com", "www.example.com", true); err != nil {
+		t.Fatalf("EnsureApexRedirect() error = %v", err)
+	}
+	target, permanent, ok := p.ApexRedirect("example.com")
+	if !ok {
+		t.Fatal("ApexRedirect() did not find the ensured redirect")
+	}
+	if target != "www.example.com" || !permanent {
+		t.Errorf("ApexRedirect() = %q permanent %t, want www.example.com permanent", target, permanent)
+	}
+
+	// ensuring again replaces the construct
+	if err := p.EnsureApexRedirect(context.Background(), zone, "example.com", "app.example.com", false); err != nil {
+		t.Fatalf("EnsureApexRedirect() second call error = %v", err)
+	}
+	if target, permanent, _ = p.ApexRedirect("example.com"); target != "app.example.com" || permanent {
+		t.Errorf("ApexRedirect() after update = %q permanent %t, want app.example.com temporary", target, permanent)
+	}
+
+	if err := p.EnsureApexRedirect(context.Background(), &provider.DNSZone{ID: "other.com", DNSName: "other.com"}, "other.com", "www.other.com", true); err == nil {
+		t.Error("EnsureApexRedirect() expected an error for a zone the provider does not manage")
+	}
+}
+
+func TestInMemoryDNSProvider_ImplementsApexRedirector(t *testing.T) {
+	var p provider.Provider = testProvider(t, "redirect-as", "example.com")
+	if _, ok := provider.As[provider.ApexRedirector](p); !ok {
+		t.Error("As() did not find the ApexRedirector on the in-memory provider")
+	}
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// OperationPendingError is returned from ApplyChanges by providers that
+// submit long-running operations (e.g. GCP) instead of applying
+// synchronously. The controller records the operation id and polls it to
+// completion across reconciles rather than blocking a worker.
+type OperationPendingError struct {
+	// OperationID identifies the submitted operation with the provider.
+	OperationID string
+}
+
+func (e *OperationPendingError) Error() string {
+	return fmt.Sprintf("provider operation %s is pending", e.OperationID)
+}
+
+// IsOperationPending returns the operation id when err reports a pending
+// provider operation.
+func IsOperationPending(err error) (string, bool) {
+	pending := &OperationPendingError{}
+	if errors.As(err, &pending) {
+		return pending.OperationID, true
+	}
+	return "", false
+}
+
+// OperationTracker is implemented by providers whose changes complete
+// asynchronously, allowing the controller to poll submitted operations.
+type OperationTracker interface {
+	// OperationDone reports whether the operation has completed. A nil
+	// error with done false means the operation is still running.
+	OperationDone(ctx context.Context, operationID string) (bool, error)
+}
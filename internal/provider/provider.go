@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsprovider "sigs.k8s.io/external-dns/provider"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// Provider knows how to manage DNS zones and the records within them.
+// It extends the external-dns provider interface with zone discovery,
+// allowing the controller to find the most suitable zone for a records
+// rootHost before planning changes into it.
+type Provider interface {
+	externaldnsprovider.Provider
+
+	// DNSZones returns a list of dns zones accessible from this provider.
+	DNSZones(ctx context.Context) ([]DNSZone, error)
+
+	// DNSZoneForHost returns the DNSZone that best matches the given host
+	// in the providers list of zones, returning the longest matching suffix
+	// zone if more than one match.
+	DNSZoneForHost(ctx context.Context, host string) (*DNSZone, error)
+}
+
+// DNSZone is a zone that a provider can manage records in.
+type DNSZone struct {
+	ID      string
+	DNSName string
+	// Tags are the provider side tags on the zone, for providers with
+	// taggable zones (e.g. Route53).
+	Tags map[string]string
+	// Project is the provider side scope the zone lives in, when the
+	// provider searches several: a GCP project, or an Azure
+	// "subscription/resourceGroup" pair.
+	Project string
+}
+
+// Config is the base configuration applied to all providers created by a factory.
+type Config struct {
+	// only consider hosted zones managing domains ending in this suffix
+	DomainFilter externaldnsendpoint.DomainFilter
+	// filter for zones based on visibility
+	ZoneTypeFilter externaldnsprovider.ZoneTypeFilter
+	// only consider hosted zones matching these ids
+	ZoneIDFilter externaldnsprovider.ZoneIDFilter
+	// never consider hosted zones or hosts under these domains, even when
+	// they match the domain filter
+	ExcludeDomains []string
+	// only consider hosted zones carrying these provider tags
+	ZoneTagFilter externaldnsprovider.ZoneTagFilter
+	// only consider hosted zones living in these provider side scopes
+	// (GCP projects)
+	ZoneProjects []string
+}
+
+// SanitizeError removes provider specific request identifiers from errors so
+// they can be safely written to resource status without causing endless
+// status update loops.
+func SanitizeError(err error) error {
+	return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), "\n", " "))
+}
+
+// FindDNSZoneForHost returns the zone from the given list with the longest
+// suffix match for the given host. A zone matching the host exactly is only
+// considered if apexAllowed is true. A wildcard prefix on the host is ignored
+// for matching: `*.apps.example.com` lives in the same zone as
+// `apps.example.com`.
+func FindDNSZoneForHost(host string, zones []DNSZone, apexAllowed bool) (*DNSZone, error) {
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("no zones available")
+	}
+	host = strings.TrimPrefix(host, v1alpha1.WildcardPrefix)
+	var match *DNSZone
+	for i := range zones {
+		z := zones[i]
+		if host == z.DNSName {
+			if !apexAllowed {
+				continue
+			}
+		} else if !strings.HasSuffix(host, "."+z.DNSName) {
+			continue
+		}
+		if match == nil || len(z.DNSName) > len(match.DNSName) {
+			match = &z
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no valid zone found for host: %s", host)
+	}
+	return match, nil
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// providerSpecificKeys lists the provider specific endpoint properties each
+// provider implementation understands. Anything else would be silently
+// dropped by the provider, so it is rejected up front instead.
+var providerSpecificKeys = map[string][]string{
+	"aws": {
+		"alias",
+		"aws/evaluate-target-health",
+		"aws/failover",
+		"aws/geolocation-continent-code",
+		"aws/geolocation-country-code",
+		"aws/geolocation-subdivision-code",
+		"aws/health-check-id",
+		"aws/region",
+		"aws/weight",
+	},
+	"google": {
+		"routingpolicy",
+	},
+	"azure": {
+		"routingpolicy",
+	},
+	// the in-memory provider stores endpoints as given, accept the common
+	// generation properties so test setups mirror real providers
+	"inmemory": {
+		"bias",
+		"client-subnet",
+		"geo-code",
+		"geoproximity-region",
+		"latitude",
+		"longitude",
+		"weight",
+	},
+}
+
+// ValidateProviderSpecific checks every provider specific property of the
+// given endpoints is understood by the named provider. The returned error
+// lists the keys the provider supports.
+func ValidateProviderSpecific(providerName string, endpoints []*externaldnsendpoint.Endpoint) error {
+	supported, ok := providerSpecificKeys[providerName]
+	if !ok {
+		return nil
+	}
+	for _, ep := range endpoints {
+		for _, property := range ep.ProviderSpecific {
+			if !contains(supported, property.Name) {
+				keys := append([]string{}, supported...)
+				sort.Strings(keys)
+				return fmt.Errorf("endpoint %q has unsupported provider specific key %q, provider %q supports: %s",
+					ep.DNSName, property.Name, providerName, strings.Join(keys, ", "))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestValidateProviderSpecific(t *testing.T) {
+	endpoint := func(key string) []*externaldnsendpoint.Endpoint {
+		ep := externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1")
+		ep.ProviderSpecific = externaldnsendpoint.ProviderSpecific{{Name: key, Value: "v"}}
+		return []*externaldnsendpoint.Endpoint{ep}
+	}
+
+	tests := []struct {
+		name         string
+		providerName string
+		endpoints    []*externaldnsendpoint.Endpoint
+		wantErr      string
+	}{
+		{
+			name:         "supported aws key",
+			providerName: "aws",
+			endpoints:    endpoint("aws/weight"),
+		},
+		{
+			name:         "unsupported aws key",
+			providerName: "aws",
+			endpoints:    endpoint("routingpolicy"),
+			wantErr:      "unsupported provider specific key \"routingpolicy\"",
+		},
+		{
+			name:         "unknown provider is not checked",
+			providerName: "other",
+			endpoints:    endpoint("anything"),
+		},
+		{
+			name:         "no provider specific properties",
+			providerName: "aws",
+			endpoints:    []*externaldnsendpoint.Endpoint{externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProviderSpecific(tt.providerName, tt.endpoints)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateProviderSpecific() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateProviderSpecific() expected error containing %q, got: %v", tt.wantErr, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "supports:") {
+				t.Errorf("expected supported keys to be listed, got: %v", err)
+			}
+		})
+	}
+}
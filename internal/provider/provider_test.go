@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestFindDNSZoneForHost(t *testing.T) {
+	zones := []DNSZone{
+		{ID: "1", DNSName: "example.com"},
+		{ID: "2", DNSName: "apps.example.com"},
+	}
+
+	tests := []struct {
+		name        string
+		host        string
+		apexAllowed bool
+		wantID      string
+		wantErr     bool
+	}{
+		{
+			name:        "longest suffix wins",
+			host:        "api.apps.example.com",
+			apexAllowed: true,
+			wantID:      "2",
+		},
+		{
+			name:        "apex match honoured when allowed",
+			host:        "apps.example.com",
+			apexAllowed: true,
+			wantID:      "2",
+		},
+		{
+			name:        "apex match skipped when not allowed",
+			host:        "apps.example.com",
+			apexAllowed: false,
+			wantID:      "1",
+		},
+		{
+			name:        "wildcard host matches the zone of its parent",
+			host:        "*.apps.example.com",
+			apexAllowed: true,
+			wantID:      "2",
+		},
+		{
+			name:        "wildcard at the zone apex",
+			host:        "*.example.com",
+			apexAllowed: true,
+			wantID:      "1",
+		},
+		{
+			name:        "no matching zone",
+			host:        "app.other.com",
+			apexAllowed: true,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, err := FindDNSZoneForHost(tt.host, zones, tt.apexAllowed)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FindDNSZoneForHost(%q) expected error, got zone %v", tt.host, zone)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindDNSZoneForHost(%q) unexpected error: %v", tt.host, err)
+			}
+			if zone.ID != tt.wantID {
+				t.Errorf("FindDNSZoneForHost(%q) = zone %q, want %q", tt.host, zone.ID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RecordTypeFilterKey is the provider secret data key restricting the record
+// types managed through that secret (comma separated, e.g. "A,AAAA,CNAME").
+// With a filter set the integration never touches other types, such as TXT
+// or MX records written into the same zone by other tooling.
+const RecordTypeFilterKey = "RECORD_TYPE_FILTER"
+
+// RecordTypeFilterFromSecret returns the record types the provider secret
+// restricts management to, or nil when unrestricted.
+func RecordTypeFilterFromSecret(secret *corev1.Secret) []string {
+	value := strings.TrimSpace(string(secret.Data[RecordTypeFilterKey]))
+	if value == "" {
+		return nil
+	}
+	var types []string
+	for _, recordType := range strings.Split(value, ",") {
+		if recordType = strings.ToUpper(strings.TrimSpace(recordType)); recordType != "" {
+			types = append(types, recordType)
+		}
+	}
+	return types
+}
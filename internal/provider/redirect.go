@@ -0,0 +1,13 @@
+package provider
+
+import "context"
+
+// ApexRedirector is implemented by providers able to create provider-native
+// HTTP redirect constructs at a zone apex (e.g. Cloudflare redirect rules,
+// S3/ALB alias patterns on Route53), where a plain CNAME is not possible.
+// Providers without redirect support simply do not implement it.
+type ApexRedirector interface {
+	// EnsureApexRedirect ensures requests for apexHost are redirected to
+	// targetHost, permanently (301) or temporarily (302).
+	EnsureApexRedirect(ctx context.Context, zone *DNSZone, apexHost, targetHost string, permanent bool) error
+}
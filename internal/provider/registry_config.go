@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// default TXT registry format values, used unless overridden by operator
+// flags or per provider secret data.
+// registry backends
+const (
+	RegistryBackendTXT = "txt"
+	RegistryBackendCRD = "crd"
+)
+
+const (
+	DefaultTXTPrefix              = "kuadrant-"
+	DefaultTXTSuffix              = ""
+	DefaultTXTWildcardReplacement = "wildcard"
+)
+
+// provider secret data keys that override the registry format for all
+// records published through that secret. Useful when a zone already contains
+// TXT records from another tool (e.g. raw external-dns) and the formats
+// would collide.
+const (
+	TXTPrefixKey              = "TXT_PREFIX"
+	TXTTTLKey                 = "TXT_TTL"
+	TXTSuffixKey              = "TXT_SUFFIX"
+	TXTWildcardReplacementKey = "TXT_WILDCARD_REPLACEMENT"
+	TXTEncryptEnabledKey      = "TXT_ENCRYPT_ENABLED"
+	TXTEncryptAESKeyKey       = "TXT_ENCRYPT_AES_KEY"
+	RegistryBackendKey        = "REGISTRY_BACKEND"
+)
+
+// RegistryConfig holds the TXT registry format options used when reading and
+// writing ownership records in provider zones.
+type RegistryConfig struct {
+	// Backend selects the registry implementation used to track ownership,
+	// either "txt" (ownership TXT records in the zone itself) or "crd"
+	// (a DNSOwnership resource in the cluster).
+	Backend string
+
+	TXTPrefix              string
+	TXTSuffix              string
+	TXTWildcardReplacement string
+
+	// TXTTTL is the TTL in seconds written on registry TXT records. Zero
+	// leaves the provider default, which may differ from the data records
+	// and read as drift in some providers.
+	TXTTTL int64
+
+	// TXTEncryptEnabled enables AES encryption of the TXT registry payload
+	// so ownership metadata written into public zones does not leak cluster
+	// or record identifiers. The key must be provided per provider secret.
+	TXTEncryptEnabled bool
+	// TXTEncryptAESKey is the 32 byte AES-256-GCM key used to encrypt and
+	// decrypt registry payloads.
+	TXTEncryptAESKey []byte
+}
+
+// DefaultRegistryConfig returns the registry format used when no flag or
+// secret overrides are set.
+func DefaultRegistryConfig() RegistryConfig {
+	return RegistryConfig{
+		Backend:                RegistryBackendTXT,
+		TXTPrefix:              DefaultTXTPrefix,
+		TXTSuffix:              DefaultTXTSuffix,
+		TXTWildcardReplacement: DefaultTXTWildcardReplacement,
+	}
+}
+
+// WithSecretOverrides returns a copy of the config with any registry format
+// overrides from the given provider secret applied.
+func (c RegistryConfig) WithSecretOverrides(secret *corev1.Secret) RegistryConfig {
+	if v, ok := secret.Data[TXTPrefixKey]; ok {
+		c.TXTPrefix = string(v)
+	}
+	if v, ok := secret.Data[TXTSuffixKey]; ok {
+		c.TXTSuffix = string(v)
+	}
+	if v, ok := secret.Data[TXTWildcardReplacementKey]; ok {
+		c.TXTWildcardReplacement = string(v)
+	}
+	if v, ok := secret.Data[TXTTTLKey]; ok {
+		if ttl, err := strconv.ParseInt(string(v), 10, 64); err == nil && ttl >= 0 {
+			c.TXTTTL = ttl
+		}
+	}
+	if v, ok := secret.Data[TXTEncryptEnabledKey]; ok {
+		if enabled, err := strconv.ParseBool(string(v)); err == nil {
+			c.TXTEncryptEnabled = enabled
+		}
+	}
+	if v, ok := secret.Data[TXTEncryptAESKeyKey]; ok {
+		c.TXTEncryptAESKey = v
+	}
+	if v, ok := secret.Data[RegistryBackendKey]; ok {
+		c.Backend = string(v)
+	}
+	return c
+}
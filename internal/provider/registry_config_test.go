@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"bytes"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRegistryConfig_WithSecretOverrides(t *testing.T) {
+	defaults := DefaultRegistryConfig()
+
+	// a secret with no overrides leaves the defaults untouched
+	cfg := defaults.WithSecretOverrides(&corev1.Secret{})
+	if cfg.TXTPrefix != DefaultTXTPrefix || cfg.TXTSuffix != DefaultTXTSuffix || cfg.TXTWildcardReplacement != DefaultTXTWildcardReplacement {
+		t.Errorf("WithSecretOverrides() changed defaults with no overrides set: %+v", cfg)
+	}
+	if cfg.TXTEncryptEnabled {
+		t.Errorf("WithSecretOverrides() enabled encryption with no overrides set")
+	}
+
+	key := bytes.Repeat([]byte("k"), 32)
+	cfg = defaults.WithSecretOverrides(&corev1.Secret{
+		Data: map[string][]byte{
+			TXTPrefixKey:              []byte("external-dns-"),
+			TXTSuffixKey:              []byte("-reg"),
+			TXTWildcardReplacementKey: []byte("star"),
+			TXTEncryptEnabledKey:      []byte("true"),
+			TXTEncryptAESKeyKey:       key,
+		},
+	})
+	if cfg.TXTPrefix != "external-dns-" {
+		t.Errorf("WithSecretOverrides() TXTPrefix = %q", cfg.TXTPrefix)
+	}
+	if cfg.TXTSuffix != "-reg" {
+		t.Errorf("WithSecretOverrides() TXTSuffix = %q", cfg.TXTSuffix)
+	}
+	if cfg.TXTWildcardReplacement != "star" {
+		t.Errorf("WithSecretOverrides() TXTWildcardReplacement = %q", cfg.TXTWildcardReplacement)
+	}
+	if !cfg.TXTEncryptEnabled {
+		t.Errorf("WithSecretOverrides() TXTEncryptEnabled = false, want true")
+	}
+	if !bytes.Equal(cfg.TXTEncryptAESKey, key) {
+		t.Errorf("WithSecretOverrides() TXTEncryptAESKey not applied")
+	}
+
+	// an invalid bool is ignored rather than failing the reconcile
+	cfg = defaults.WithSecretOverrides(&corev1.Secret{
+		Data: map[string][]byte{TXTEncryptEnabledKey: []byte("not-a-bool")},
+	})
+	if cfg.TXTEncryptEnabled {
+		t.Errorf("WithSecretOverrides() TXTEncryptEnabled = true for invalid value")
+	}
+}
@@ -0,0 +1,14 @@
+package provider
+
+import "context"
+
+// ResourceTagger is implemented by providers able to tag the provider side
+// resources backing a zone (Route53 zones and health checks, Cloudflare
+// record comments/tags). Tags are used for cost attribution and
+// traceability; providers without tagging support simply do not implement
+// it.
+type ResourceTagger interface {
+	// EnsureTags reconciles the given tags onto the provider resources
+	// backing the zone, leaving unrelated tags in place.
+	EnsureTags(ctx context.Context, zone *DNSZone, tags map[string]string) error
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+// provider secret data keys overriding the operator wide provider API
+// timeout and retry defaults for a single provider secret, for provider
+// backends that are slower than the built-in client timeouts assume.
+const (
+	// APITimeoutKey is a Go duration bounding each provider API call,
+	// e.g. "90s". Zero or unset leaves the providers own client timeouts
+	// in effect.
+	APITimeoutKey = "API_TIMEOUT"
+	// APIRetriesKey is the number of times a failed provider API call is
+	// retried with exponential backoff before the error is returned.
+	APIRetriesKey = "API_RETRIES"
+)
+
+// retryBackoff is the delay before the first retry, doubled on each
+// subsequent one.
+const retryBackoff = time.Second
+
+// RetryDefaults is the operator wide provider API timeout and retry
+// configuration, overridable per provider secret.
+type RetryDefaults struct {
+	// Timeout bounds each provider API call. Zero applies no bound.
+	Timeout time.Duration
+	// Retries is the number of times a failed call is retried.
+	Retries int
+}
+
+// retryProvider decorates a Provider with a per call timeout and retries
+// with exponential backoff, for provider backends that are slow or flaky
+// enough to trip the underlying clients own limits.
+type retryProvider struct {
+	Provider
+	timeout time.Duration
+	retries int
+}
+
+// Unwrap returns the decorated provider, so feature interface assertions
+// made through As still find it.
+func (p *retryProvider) Unwrap() Provider {
+	return p.Provider
+}
+
+func (p *retryProvider) Records(ctx context.Context) ([]*externaldnsendpoint.Endpoint, error) {
+	var records []*externaldnsendpoint.Endpoint
+	err := p.do(ctx, func(ctx context.Context) error {
+		var err error
+		records, err = p.Provider.Records(ctx)
+		return err
+	})
+	return records, err
+}
+
+func (p *retryProvider) ApplyChanges(ctx context.Context, changes *externaldnsplan.Changes) error {
+	return p.do(ctx, func(ctx context.Context) error {
+		return p.Provider.ApplyChanges(ctx, changes)
+	})
+}
+
+func (p *retryProvider) DNSZones(ctx context.Context) ([]DNSZone, error) {
+	var zones []DNSZone
+	err := p.do(ctx, func(ctx context.Context) error {
+		var err error
+		zones, err = p.Provider.DNSZones(ctx)
+		return err
+	})
+	return zones, err
+}
+
+func (p *retryProvider) DNSZoneForHost(ctx context.Context, host string) (*DNSZone, error) {
+	var zone *DNSZone
+	err := p.do(ctx, func(ctx context.Context) error {
+		var err error
+		zone, err = p.Provider.DNSZoneForHost(ctx, host)
+		return err
+	})
+	return zone, err
+}
+
+// do runs call with the configured per call timeout, retrying failures with
+// exponential backoff until the retry budget or the callers context is
+// exhausted.
+func (p *retryProvider) do(ctx context.Context, call func(ctx context.Context) error) error {
+	backoff := retryBackoff
+	var err error
+	for attempt := 0; attempt <= p.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		err = p.attempt(ctx, call)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (p *retryProvider) attempt(ctx context.Context, call func(ctx context.Context) error) error {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	return call(ctx)
+}
+
+// As returns the given provider as T if it, or a provider it decorates,
+// implements it. It replaces a plain type assertion for the provider
+// feature interfaces, which a decorator returned by the factory would
+// otherwise hide.
+func As[T any](p Provider) (T, bool) {
+	for {
+		if t, ok := p.(T); ok {
+			return t, true
+		}
+		wrapper, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		p = wrapper.Unwrap()
+	}
+}
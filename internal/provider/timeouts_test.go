@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// flakyProvider fails every call until the configured number of attempts
+// has been made.
+type flakyProvider struct {
+	Provider
+	failures int
+	calls    int
+}
+
+func (p *flakyProvider) Records(_ context.Context) ([]*externaldnsendpoint.Endpoint, error) {
+	p.calls++
+	if p.calls <= p.failures {
+		return nil, fmt.Errorf("attempt %d failed", p.calls)
+	}
+	return nil, nil
+}
+
+func TestRetryProvider_Records(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		retries  int
+		wantCall int
+		wantErr  bool
+	}{
+		{
+			name:     "succeeds without retries",
+			failures: 0,
+			retries:  2,
+			wantCall: 1,
+		},
+		{
+			name:     "retries until success",
+			failures: 2,
+			retries:  2,
+			wantCall: 3,
+		},
+		{
+			name:     "returns the last error when the budget is exhausted",
+			failures: 3,
+			retries:  2,
+			wantCall: 3,
+			wantErr:  true,
+		},
+		{
+			name:     "no retries by default",
+			failures: 1,
+			wantCall: 1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flaky := &flakyProvider{failures: tt.failures}
+			p := &retryProvider{Provider: flaky, retries: tt.retries}
+			_, err := p.Records(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Records() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if flaky.calls != tt.wantCall {
+				t.Errorf("Records() made %d calls, want %d", flaky.calls, tt.wantCall)
+			}
+		})
+	}
+}
+
+func TestRetryProvider_RespectsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	flaky := &flakyProvider{failures: 10}
+	p := &retryProvider{Provider: flaky, retries: 10}
+	if _, err := p.Records(ctx); err == nil {
+		t.Error("Records() expected an error with a cancelled context")
+	}
+	if flaky.calls != 1 {
+		t.Errorf("Records() made %d calls with a cancelled context, want 1", flaky.calls)
+	}
+}
+
+type capableProvider struct {
+	Provider
+}
+
+func (p *capableProvider) Capabilities() Capabilities {
+	return Capabilities{Weighted: true}
+}
+
+func TestAs_Unwraps(t *testing.T) {
+	var p Provider = &retryProvider{Provider: &capableProvider{}}
+	reporter, ok := As[CapabilityReporter](p)
+	if !ok {
+		t.Fatal("As() did not find the CapabilityReporter behind the decorator")
+	}
+	if !reporter.Capabilities().Weighted {
+		t.Error("As() returned the wrong CapabilityReporter")
+	}
+	if _, ok := As[DNSSECManager](p); ok {
+		t.Error("As() found a DNSSECManager neither provider implements")
+	}
+}
+
+func TestRetryProvider_Timeout(t *testing.T) {
+	p := &retryProvider{timeout: 1}
+	err := p.do(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("do() error = %v, want deadline exceeded", err)
+	}
+}
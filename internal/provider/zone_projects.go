@@ -0,0 +1,49 @@
+package provider
+
+import "strings"
+
+// ZoneProjectsKey is the provider secret data key listing the provider side
+// scopes (comma separated) searched during zone assignment, when zones span
+// several: GCP project ids on a gcp secret. It shares its name with the
+// existing single-project GCP credential key.
+const ZoneProjectsKey = "PROJECT_ID"
+
+// AzureSubscriptionsKey and AzureResourceGroupsKey list the subscriptions
+// and resource groups (comma separated) searched during zone assignment of
+// an azure secret, matched against either component of the zones
+// "subscription/resourceGroup" scope.
+const (
+	AzureSubscriptionsKey  = "AZURE_SUBSCRIPTION_IDS"
+	AzureResourceGroupsKey = "AZURE_RESOURCE_GROUPS"
+)
+
+// FilterZonesByProject drops zones living outside the listed provider side
+// scopes, so zone assignment only searches the named projects. An empty
+// list keeps all zones.
+func FilterZonesByProject(zones []DNSZone, projects []string) []DNSZone {
+	if len(projects) == 0 {
+		return zones
+	}
+	var filtered []DNSZone
+	for _, zone := range zones {
+		for _, project := range projects {
+			if zoneProjectMatches(zone.Project, project) {
+				filtered = append(filtered, zone)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// zoneProjectMatches returns true if the listed scope entry covers the zone
+// scope. An entry matches the whole scope, or either component of a
+// composite "subscription/resourceGroup" scope, so Azure subscriptions and
+// resource groups can be listed independently.
+func zoneProjectMatches(project, entry string) bool {
+	if project == entry {
+		return true
+	}
+	subscription, resourceGroup, found := strings.Cut(project, "/")
+	return found && (subscription == entry || resourceGroup == entry)
+}
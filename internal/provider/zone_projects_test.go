@@ -0,0 +1,98 @@
+package provider
+
+import "testing"
+
+func TestFilterZonesByProject(t *testing.T) {
+	zones := []DNSZone{
+		{ID: "a", DNSName: "a.example.com", Project: "proj-a"},
+		{ID: "b", DNSName: "b.example.com", Project: "proj-b"},
+		{ID: "unscoped", DNSName: "c.example.com"},
+	}
+
+	tests := []struct {
+		name     string
+		projects []string
+		wantIDs  []string
+	}{
+		{
+			name:    "an empty list keeps all zones",
+			wantIDs: []string{"a", "b", "unscoped"},
+		},
+		{
+			name:     "a single project selects its zones",
+			projects: []string{"proj-b"},
+			wantIDs:  []string{"b"},
+		},
+		{
+			name:     "several projects are searched together",
+			projects: []string{"proj-a", "proj-b"},
+			wantIDs:  []string{"a", "b"},
+		},
+		{
+			name:     "an unknown project matches nothing",
+			projects: []string{"proj-x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := FilterZonesByProject(zones, tt.projects)
+			var gotIDs []string
+			for _, zone := range filtered {
+				gotIDs = append(gotIDs, zone.ID)
+			}
+			if len(gotIDs) != len(tt.wantIDs) {
+				t.Fatalf("FilterZonesByProject() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tt.wantIDs[i] {
+					t.Errorf("FilterZonesByProject() = %v, want %v", gotIDs, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestZoneProjectMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		project string
+		entry   string
+		want    bool
+	}{
+		{
+			name:    "whole scope",
+			project: "sub-1/rg-payments",
+			entry:   "sub-1/rg-payments",
+			want:    true,
+		},
+		{
+			name:    "subscription component",
+			project: "sub-1/rg-payments",
+			entry:   "sub-1",
+			want:    true,
+		},
+		{
+			name:    "resource group component",
+			project: "sub-1/rg-payments",
+			entry:   "rg-payments",
+			want:    true,
+		},
+		{
+			name:    "unrelated scope",
+			project: "sub-1/rg-payments",
+			entry:   "sub-2",
+		},
+		{
+			name:    "a plain project has no components",
+			project: "proj-a",
+			entry:   "proj",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zoneProjectMatches(tt.project, tt.entry); got != tt.want {
+				t.Errorf("zoneProjectMatches(%q, %q) = %t, want %t", tt.project, tt.entry, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	externaldnsprovider "sigs.k8s.io/external-dns/provider"
+)
+
+// ZoneTagFilterKey is the provider secret data key restricting zone
+// discovery to zones carrying the listed provider tags (comma separated
+// key=value pairs, e.g. "team=payments", a bare key matches any value). It
+// resolves ambiguity when several zones exist for the same domain, such as
+// public/private pairs or blue/green zone migrations.
+const ZoneTagFilterKey = "ZONE_TAG_FILTER"
+
+// FilterZonesByTags drops zones whose tags do not match the filter, so zone
+// assignment only considers the tagged subset. An empty filter keeps all
+// zones.
+func FilterZonesByTags(zones []DNSZone, filter externaldnsprovider.ZoneTagFilter) []DNSZone {
+	if filter.IsEmpty() {
+		return zones
+	}
+	var filtered []DNSZone
+	for _, zone := range zones {
+		if filter.Match(zone.Tags) {
+			filtered = append(filtered, zone)
+		}
+	}
+	return filtered
+}
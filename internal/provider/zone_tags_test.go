@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"testing"
+
+	externaldnsprovider "sigs.k8s.io/external-dns/provider"
+)
+
+func TestFilterZonesByTags(t *testing.T) {
+	zones := []DNSZone{
+		{ID: "public", DNSName: "example.com", Tags: map[string]string{"team": "payments", "visibility": "public"}},
+		{ID: "private", DNSName: "example.com", Tags: map[string]string{"team": "payments", "visibility": "private"}},
+		{ID: "untagged", DNSName: "other.com"},
+	}
+
+	tests := []struct {
+		name    string
+		filter  []string
+		wantIDs []string
+	}{
+		{
+			name:    "an empty filter keeps all zones",
+			filter:  nil,
+			wantIDs: []string{"public", "private", "untagged"},
+		},
+		{
+			name:    "a key=value filter selects matching zones",
+			filter:  []string{"visibility=private"},
+			wantIDs: []string{"private"},
+		},
+		{
+			name:    "all listed tags must match",
+			filter:  []string{"team=payments", "visibility=public"},
+			wantIDs: []string{"public"},
+		},
+		{
+			name:    "a bare key matches any value",
+			filter:  []string{"team"},
+			wantIDs: []string{"public", "private"},
+		},
+		{
+			name:   "no zone carries the tag",
+			filter: []string{"team=platform"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := FilterZonesByTags(zones, externaldnsprovider.NewZoneTagFilter(tt.filter))
+			var gotIDs []string
+			for _, zone := range filtered {
+				gotIDs = append(gotIDs, zone.ID)
+			}
+			if len(gotIDs) != len(tt.wantIDs) {
+				t.Fatalf("FilterZonesByTags() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tt.wantIDs[i] {
+					t.Errorf("FilterZonesByTags() = %v, want %v", gotIDs, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
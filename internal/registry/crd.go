@@ -0,0 +1,137 @@
+// Package registry provides registry backends for tracking ownership of
+// provider zone records, complementing the external-dns TXT registry.
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+	externaldnsregistry "sigs.k8s.io/external-dns/registry"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// CRDRegistry tracks ownership of provider zone records in a DNSOwnership
+// resource instead of TXT records in the zone. Useful for zones where adding
+// a TXT record per owned name is not acceptable or would double record
+// counts against zone limits.
+//
+// One DNSOwnership is maintained per provider secret, in the secrets
+// namespace with the secrets name.
+type CRDRegistry struct {
+	client    client.Client
+	namespace string
+	name      string
+	provider  provider.Provider
+	ownerID   string
+}
+
+var _ externaldnsregistry.Registry = &CRDRegistry{}
+
+// NewCRDRegistry returns a registry tracking ownership in the DNSOwnership
+// with the given namespace and name.
+func NewCRDRegistry(c client.Client, namespace, name string, p provider.Provider, ownerID string) *CRDRegistry {
+	return &CRDRegistry{
+		client:    c,
+		namespace: namespace,
+		name:      name,
+		provider:  p,
+		ownerID:   ownerID,
+	}
+}
+
+// Records returns the records of the zone with registry labels attached from
+// the ownership resource.
+func (r *CRDRegistry) Records(ctx context.Context) ([]*externaldnsendpoint.Endpoint, error) {
+	records, err := r.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ownership, err := r.getOwnership(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.Labels == nil {
+			record.Labels = externaldnsendpoint.NewLabels()
+		}
+		for k, v := range ownership.Spec.Entries[recordKey(record)] {
+			record.Labels[k] = v
+		}
+	}
+	return records, nil
+}
+
+// ApplyChanges applies the given changes to the provider zone and records
+// the resulting ownership in the ownership resource.
+func (r *CRDRegistry) ApplyChanges(ctx context.Context, changes *externaldnsplan.Changes) error {
+	if err := r.provider.ApplyChanges(ctx, changes); err != nil {
+		return err
+	}
+
+	ownership, err := r.getOwnership(ctx)
+	if err != nil {
+		return err
+	}
+	existing := !ownership.CreationTimestamp.IsZero()
+
+	if ownership.Spec.Entries == nil {
+		ownership.Spec.Entries = map[string]v1alpha1.RegistryLabels{}
+	}
+	for _, record := range append(changes.Create, changes.UpdateNew...) {
+		labels := v1alpha1.RegistryLabels{externaldnsendpoint.OwnerLabelKey: r.ownerID}
+		for k, v := range record.Labels {
+			labels[k] = v
+		}
+		ownership.Spec.Entries[recordKey(record)] = labels
+	}
+	for _, record := range changes.Delete {
+		delete(ownership.Spec.Entries, recordKey(record))
+	}
+
+	if existing {
+		return r.client.Update(ctx, ownership)
+	}
+	return r.client.Create(ctx, ownership)
+}
+
+// AdjustEndpoints canonicalizes endpoints with the wrapped provider.
+func (r *CRDRegistry) AdjustEndpoints(endpoints []*externaldnsendpoint.Endpoint) ([]*externaldnsendpoint.Endpoint, error) {
+	return r.provider.AdjustEndpoints(endpoints)
+}
+
+// GetDomainFilter returns the domain filter of the wrapped provider.
+func (r *CRDRegistry) GetDomainFilter() externaldnsendpoint.DomainFilter {
+	return r.provider.GetDomainFilter()
+}
+
+// OwnerID returns the owner id of the registry.
+func (r *CRDRegistry) OwnerID() string {
+	return r.ownerID
+}
+
+func (r *CRDRegistry) getOwnership(ctx context.Context) (*v1alpha1.DNSOwnership, error) {
+	ownership := &v1alpha1.DNSOwnership{
+		ObjectMeta: metav1.ObjectMeta{Namespace: r.namespace, Name: r.name},
+	}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: r.namespace, Name: r.name}, ownership)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	return ownership, nil
+}
+
+// recordKey returns the ownership entry key for an endpoint.
+func recordKey(record *externaldnsendpoint.Endpoint) string {
+	return fmt.Sprintf("%s/%s/%s", record.DNSName, record.SetIdentifier, record.RecordType)
+}
@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"sort"
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+// TargetsLabelKey is the registry label recording which targets of an RRset
+// an owner contributed. It rides along with the owner label in the registry
+// backend (TXT record or DNSOwnership entry).
+const TargetsLabelKey = "targets"
+
+// LabelOwnedTargets records the targets an owner is writing on the outgoing
+// endpoints of the given changes, so a later delete can distinguish its own
+// targets from targets other owners merged into the same RRset.
+func LabelOwnedTargets(changes *externaldnsplan.Changes) {
+	for _, record := range append(changes.Create, changes.UpdateNew...) {
+		if record.Labels == nil {
+			record.Labels = externaldnsendpoint.NewLabels()
+		}
+		record.Labels[TargetsLabelKey] = strings.Join(record.Targets, ",")
+	}
+}
+
+// AdjustDeletesForSharedTargets rewrites deletes of RRsets holding targets
+// from more than one owner. A delete whose current RRset contains targets
+// beyond those the owner recorded becomes an update removing only the
+// owner's targets, so withdrawing one owner never takes down the whole
+// shared dnsName.
+func AdjustDeletesForSharedTargets(changes *externaldnsplan.Changes) *externaldnsplan.Changes {
+	adjusted := &externaldnsplan.Changes{
+		Create:    changes.Create,
+		UpdateOld: changes.UpdateOld,
+		UpdateNew: changes.UpdateNew,
+	}
+	for _, record := range changes.Delete {
+		owned := ownedTargets(record)
+		remaining := subtract(record.Targets, owned)
+		if len(owned) == 0 || len(remaining) == 0 || len(remaining) == len(record.Targets) {
+			adjusted.Delete = append(adjusted.Delete, record)
+			continue
+		}
+		update := record.DeepCopy()
+		update.Targets = remaining
+		adjusted.UpdateOld = append(adjusted.UpdateOld, record)
+		adjusted.UpdateNew = append(adjusted.UpdateNew, update)
+	}
+	return adjusted
+}
+
+// ownedTargets returns the targets the owner of a record recorded for it.
+func ownedTargets(record *externaldnsendpoint.Endpoint) []string {
+	value := record.Labels[TargetsLabelKey]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// subtract returns the sorted elements of s not present in remove.
+func subtract(s, remove []string) []string {
+	var out []string
+	for _, v := range s {
+		found := false
+		for _, r := range remove {
+			if v == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
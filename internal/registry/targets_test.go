@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"reflect"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+	externaldnsplan "sigs.k8s.io/external-dns/plan"
+)
+
+func TestLabelOwnedTargets(t *testing.T) {
+	create := externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1", "2.2.2.2")
+	changes := &externaldnsplan.Changes{Create: []*externaldnsendpoint.Endpoint{create}}
+
+	LabelOwnedTargets(changes)
+
+	if create.Labels[TargetsLabelKey] != "1.1.1.1,2.2.2.2" {
+		t.Errorf("expected targets label, got %q", create.Labels[TargetsLabelKey])
+	}
+}
+
+func TestAdjustDeletesForSharedTargets(t *testing.T) {
+	shared := externaldnsendpoint.NewEndpoint("app.example.com", "A", "1.1.1.1", "2.2.2.2", "3.3.3.3")
+	shared.Labels = externaldnsendpoint.Labels{TargetsLabelKey: "1.1.1.1"}
+
+	exclusive := externaldnsendpoint.NewEndpoint("only.example.com", "A", "4.4.4.4")
+	exclusive.Labels = externaldnsendpoint.Labels{TargetsLabelKey: "4.4.4.4"}
+
+	unlabelled := externaldnsendpoint.NewEndpoint("old.example.com", "A", "5.5.5.5")
+
+	changes := AdjustDeletesForSharedTargets(&externaldnsplan.Changes{
+		Delete: []*externaldnsendpoint.Endpoint{shared, exclusive, unlabelled},
+	})
+
+	// the shared RRset keeps the other owners targets via an update
+	if len(changes.UpdateOld) != 1 || changes.UpdateOld[0] != shared {
+		t.Fatalf("expected shared delete to become an update, got %v", changes.UpdateOld)
+	}
+	if want := []string{"2.2.2.2", "3.3.3.3"}; !reflect.DeepEqual(changes.UpdateNew[0].Targets, externaldnsendpoint.Targets(want)) {
+		t.Errorf("expected remaining targets %v, got %v", want, changes.UpdateNew[0].Targets)
+	}
+
+	// records fully owned, or written before target tracking, still delete
+	if len(changes.Delete) != 2 {
+		t.Fatalf("expected 2 remaining deletes, got %v", changes.Delete)
+	}
+}
@@ -0,0 +1,357 @@
+// Package builder generates DNSRecord endpoints for a target (gateway,
+// ingress, service ...) exposing a hostname. It is the public equivalent of
+// the endpoint generation this operator applies, exported so consumers can
+// build records without depending on internal packages.
+package builder
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/identity"
+)
+
+const (
+	// DefaultTTL is applied to endpoints of simple records.
+	DefaultTTL = externaldnsendpoint.TTL(300)
+	// DefaultLoadBalancedTTL is applied to endpoints of load balanced record
+	// trees, kept low so routing changes propagate quickly.
+	DefaultLoadBalancedTTL = externaldnsendpoint.TTL(60)
+	// DefaultWeight is applied to cluster endpoints that do not set an
+	// explicit weight.
+	DefaultWeight = 120
+
+	// LabelLBAttributePrefix prefixes the load balancer labels inserted
+	// under a hostname, e.g. klb.app.example.com.
+	LabelLBAttributePrefix = "klb."
+
+	// WildcardGeo is the geo code of the catch-all geo endpoint.
+	WildcardGeo = "*"
+
+	// LBAttributeGeoCodeLabel is the object label endpoint generation reads
+	// a geo code from when none is supplied explicitly.
+	LBAttributeGeoCodeLabel = "kuadrant.io/lb-attribute-geo-code"
+
+	// ProviderSpecificGeoCode is the provider specific property carrying the
+	// geo code of an endpoint.
+	ProviderSpecificGeoCode = "geo-code"
+	// ProviderSpecificWeight is the provider specific property carrying the
+	// weight of an endpoint.
+	ProviderSpecificWeight = "weight"
+	// ProviderSpecificBias is the provider specific property carrying a
+	// geoproximity bias, expanding or shrinking the region routed to this
+	// cluster on providers supporting it (e.g. Route53).
+	ProviderSpecificBias = "bias"
+	// ProviderSpecificClientSubnet is the provider specific property
+	// requesting EDNS client subnet based resolution for geo endpoints on
+	// providers supporting it.
+	ProviderSpecificClientSubnet = "client-subnet"
+	// ProviderSpecificGeoproximityRegion is the provider specific property
+	// carrying the provider region of a geoproximity routed endpoint.
+	ProviderSpecificGeoproximityRegion = "geoproximity-region"
+	// ProviderSpecificLatitude and ProviderSpecificLongitude carry the
+	// coordinates of a geoproximity routed endpoint.
+	ProviderSpecificLatitude  = "latitude"
+	ProviderSpecificLongitude = "longitude"
+)
+
+// AddressType distinguishes the kinds of address a target can expose.
+type AddressType string
+
+const (
+	IPAddressType       AddressType = "IPAddress"
+	HostnameAddressType AddressType = "Hostname"
+)
+
+// TargetAddress is a single address of a target.
+type TargetAddress struct {
+	Type  AddressType
+	Value string
+}
+
+// Target is anything exposing addresses a hostname should resolve to.
+// Implementations adapt concrete resources (Gateway, Ingress, Service) to
+// the builder.
+type Target interface {
+	// GetName returns the name of the target resource.
+	GetName() string
+	// GetShortCode returns a short stable identifier of the target, used in
+	// generated dns labels. Typically identity.ShortCode of the cluster id.
+	GetShortCode() string
+	// GetAddresses returns the addresses the hostname should resolve to.
+	GetAddresses() []TargetAddress
+}
+
+// LoadBalancing configures generation of a load balanced endpoint tree
+// instead of a simple record set.
+type LoadBalancing struct {
+	// Geo is the geo code of the publishing cluster, e.g. "GEO-EU".
+	Geo string
+	// AdditionalGeos are further geo codes the cluster serves. Each code
+	// gets its own geo routed endpoint pointing at the same cluster record
+	// set.
+	AdditionalGeos []string
+	// DefaultGeo marks this cluster's geo as the catch-all for clients
+	// matching no other geo.
+	DefaultGeo bool
+	// Weight applied to this cluster's endpoints within its geo.
+	// Defaults to DefaultWeight.
+	Weight int
+	// Bias is an optional geoproximity bias in the range -99 to 99 applied
+	// to this cluster's geo endpoints, passed through as a provider
+	// specific property.
+	Bias int
+	// EnableClientSubnet requests EDNS client subnet based geo resolution,
+	// passed through as a provider specific property.
+	EnableClientSubnet bool
+	// Geoproximity switches the tree to distance based routing. When set,
+	// Geo is ignored and the routing level carries the cluster coordinates
+	// or provider region instead of a geo code.
+	Geoproximity *Geoproximity
+}
+
+// Geoproximity locates a cluster for distance based routing, through either
+// a provider region (e.g. a Route53 AWS region) or explicit coordinates.
+type Geoproximity struct {
+	// Region is the provider region hosting the cluster.
+	Region string
+	// Latitude and Longitude locate the cluster when it runs outside a
+	// provider region. Both must be set together.
+	Latitude  *float64
+	Longitude *float64
+	// Bias expands (positive) or shrinks (negative) the region routed to
+	// this cluster, in the range -99 to 99.
+	Bias int
+}
+
+// EndpointsBuilder builds the endpoints of a DNSRecord for a target exposing
+// a hostname.
+type EndpointsBuilder struct {
+	target        Target
+	hostname      string
+	loadBalancing *LoadBalancing
+	geo           string
+}
+
+// NewEndpointsBuilder returns a builder generating endpoints resolving
+// hostname to the addresses of the given target.
+func NewEndpointsBuilder(target Target, hostname string) *EndpointsBuilder {
+	return &EndpointsBuilder{target: target, hostname: hostname}
+}
+
+// WithLoadBalancing makes the builder generate a load balanced endpoint tree
+// using the given configuration.
+func (b *EndpointsBuilder) WithLoadBalancing(loadBalancing *LoadBalancing) *EndpointsBuilder {
+	b.loadBalancing = loadBalancing
+	return b
+}
+
+// WithGeo overrides the geo code endpoints are generated with, taking
+// precedence over LoadBalancing.Geo. It lets callers supply a geo per
+// target or listener without mutating labels on the target resource.
+func (b *EndpointsBuilder) WithGeo(geo string) *EndpointsBuilder {
+	b.geo = geo
+	return b
+}
+
+// GeoFromLabels returns the geo code declared on an object through the
+// kuadrant.io/lb-attribute-geo-code label, or empty if unset.
+func GeoFromLabels(labels map[string]string) string {
+	return labels[LBAttributeGeoCodeLabel]
+}
+
+// geoCode returns the effective primary geo code of the builder.
+func (b *EndpointsBuilder) geoCode() string {
+	if b.geo != "" {
+		return b.geo
+	}
+	if b.loadBalancing != nil {
+		return b.loadBalancing.Geo
+	}
+	return ""
+}
+
+// geoCodes returns the primary geo code followed by any additional geos,
+// deduplicated in order.
+func (b *EndpointsBuilder) geoCodes() []string {
+	geos := []string{b.geoCode()}
+	if b.loadBalancing != nil {
+		for _, geo := range b.loadBalancing.AdditionalGeos {
+			if geo != "" && !slices.Contains(geos, geo) {
+				geos = append(geos, geo)
+			}
+		}
+	}
+	return geos
+}
+
+// Build generates the endpoints.
+func (b *EndpointsBuilder) Build() ([]*externaldnsendpoint.Endpoint, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	if b.loadBalancing == nil {
+		return b.getSimpleEndpoints(), nil
+	}
+	return b.getLoadBalancedEndpoints(), nil
+}
+
+func (b *EndpointsBuilder) validate() error {
+	if b.target == nil {
+		return fmt.Errorf("target is required")
+	}
+	if b.hostname == "" {
+		return fmt.Errorf("hostname is required")
+	}
+	if len(b.target.GetAddresses()) == 0 {
+		return fmt.Errorf("target %q has no addresses", b.target.GetName())
+	}
+	if b.loadBalancing != nil {
+		if gp := b.loadBalancing.Geoproximity; gp != nil {
+			if gp.Region == "" && (gp.Latitude == nil || gp.Longitude == nil) {
+				return fmt.Errorf("loadBalancing.geoproximity requires a region or both coordinates")
+			}
+			if gp.Bias < -99 || gp.Bias > 99 {
+				return fmt.Errorf("loadBalancing.geoproximity.bias must be between -99 and 99")
+			}
+		} else if b.geoCode() == "" {
+			return fmt.Errorf("loadBalancing.geo is required")
+		}
+		if b.loadBalancing.Bias < -99 || b.loadBalancing.Bias > 99 {
+			return fmt.Errorf("loadBalancing.bias must be between -99 and 99")
+		}
+		if b.target.GetShortCode() == "" {
+			return fmt.Errorf("target %q has no shortcode", b.target.GetName())
+		}
+	}
+	return nil
+}
+
+// getSimpleEndpoints returns endpoints resolving the hostname directly to
+// the target addresses, one endpoint per record type.
+func (b *EndpointsBuilder) getSimpleEndpoints() []*externaldnsendpoint.Endpoint {
+	ips, hostnames := splitAddresses(b.target.GetAddresses())
+
+	var endpoints []*externaldnsendpoint.Endpoint
+	if len(ips) > 0 {
+		endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(
+			b.hostname, externaldnsendpoint.RecordTypeA, DefaultTTL, ips...))
+	}
+	if len(hostnames) > 0 {
+		endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(
+			b.hostname, externaldnsendpoint.RecordTypeCNAME, DefaultTTL, hostnames...))
+	}
+	return endpoints
+}
+
+// getLoadBalancedEndpoints returns the load balancer tree for the hostname:
+//
+//	<hostname>                    CNAME klb.<hostname>
+//	klb.<hostname>                CNAME <geo>.klb.<hostname>   (geo routed)
+//	<geo>.klb.<hostname>          CNAME <shortcode>-<target>.klb.<hostname> (weighted)
+//	<shortcode>-<target>.klb.<hostname>  A/CNAME <addresses>
+//
+// Each cluster publishing the hostname contributes its own cluster and geo
+// endpoints; the shared upper levels merge in the provider zone through the
+// set identifiers.
+func (b *EndpointsBuilder) getLoadBalancedEndpoints() []*externaldnsendpoint.Endpoint {
+	lbName := LabelLBAttributePrefix + stripWildcard(b.hostname)
+	geos := b.geoCodes()
+	clusterName := b.target.GetShortCode() + "-" + identity.ShortCode(b.target.GetName()) + "." + lbName
+	weight := b.loadBalancing.Weight
+	if weight == 0 {
+		weight = DefaultWeight
+	}
+
+	var endpoints []*externaldnsendpoint.Endpoint
+
+	ips, hostnames := splitAddresses(b.target.GetAddresses())
+	if len(ips) > 0 {
+		endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(
+			clusterName, externaldnsendpoint.RecordTypeA, DefaultLoadBalancedTTL, ips...))
+	}
+	if len(hostnames) > 0 {
+		endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(
+			clusterName, externaldnsendpoint.RecordTypeCNAME, DefaultLoadBalancedTTL, hostnames...))
+	}
+
+	if gp := b.loadBalancing.Geoproximity; gp != nil {
+		lbEndpoint := externaldnsendpoint.NewEndpointWithTTL(
+			lbName, externaldnsendpoint.RecordTypeCNAME, DefaultLoadBalancedTTL, clusterName)
+		lbEndpoint.SetIdentifier = clusterName
+		if gp.Region != "" {
+			lbEndpoint = lbEndpoint.WithProviderSpecific(ProviderSpecificGeoproximityRegion, gp.Region)
+		} else {
+			lbEndpoint = lbEndpoint.WithProviderSpecific(ProviderSpecificLatitude, strconv.FormatFloat(*gp.Latitude, 'f', -1, 64))
+			lbEndpoint = lbEndpoint.WithProviderSpecific(ProviderSpecificLongitude, strconv.FormatFloat(*gp.Longitude, 'f', -1, 64))
+		}
+		if gp.Bias != 0 {
+			lbEndpoint = lbEndpoint.WithProviderSpecific(ProviderSpecificBias, fmt.Sprintf("%d", gp.Bias))
+		}
+		endpoints = append(endpoints, lbEndpoint)
+
+		endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(
+			b.hostname, externaldnsendpoint.RecordTypeCNAME, DefaultLoadBalancedTTL, lbName))
+
+		return endpoints
+	}
+
+	for _, geo := range geos {
+		geoName := strings.ToLower(geo) + "." + lbName
+
+		geoEndpoint := externaldnsendpoint.NewEndpointWithTTL(
+			geoName, externaldnsendpoint.RecordTypeCNAME, DefaultLoadBalancedTTL, clusterName)
+		geoEndpoint.SetIdentifier = clusterName
+		geoEndpoint = geoEndpoint.WithProviderSpecific(ProviderSpecificWeight, fmt.Sprintf("%d", weight))
+		endpoints = append(endpoints, geoEndpoint)
+
+		lbEndpoint := externaldnsendpoint.NewEndpointWithTTL(
+			lbName, externaldnsendpoint.RecordTypeCNAME, DefaultLoadBalancedTTL, geoName)
+		lbEndpoint.SetIdentifier = geo
+		lbEndpoint = lbEndpoint.WithProviderSpecific(ProviderSpecificGeoCode, geo)
+		if b.loadBalancing.Bias != 0 {
+			lbEndpoint = lbEndpoint.WithProviderSpecific(ProviderSpecificBias, fmt.Sprintf("%d", b.loadBalancing.Bias))
+		}
+		if b.loadBalancing.EnableClientSubnet {
+			lbEndpoint = lbEndpoint.WithProviderSpecific(ProviderSpecificClientSubnet, "true")
+		}
+		endpoints = append(endpoints, lbEndpoint)
+	}
+
+	if b.loadBalancing.DefaultGeo {
+		geoName := strings.ToLower(geos[0]) + "." + lbName
+		defaultEndpoint := externaldnsendpoint.NewEndpointWithTTL(
+			lbName, externaldnsendpoint.RecordTypeCNAME, DefaultLoadBalancedTTL, geoName)
+		defaultEndpoint.SetIdentifier = "default"
+		defaultEndpoint = defaultEndpoint.WithProviderSpecific(ProviderSpecificGeoCode, WildcardGeo)
+		endpoints = append(endpoints, defaultEndpoint)
+	}
+
+	endpoints = append(endpoints, externaldnsendpoint.NewEndpointWithTTL(
+		b.hostname, externaldnsendpoint.RecordTypeCNAME, DefaultLoadBalancedTTL, lbName))
+
+	return endpoints
+}
+
+// splitAddresses partitions addresses into ip and hostname values.
+func splitAddresses(addresses []TargetAddress) (ips, hostnames []string) {
+	for _, address := range addresses {
+		if address.Type == IPAddressType {
+			ips = append(ips, address.Value)
+		} else {
+			hostnames = append(hostnames, address.Value)
+		}
+	}
+	return ips, hostnames
+}
+
+// stripWildcard removes any wildcard prefix from a hostname.
+func stripWildcard(hostname string) string {
+	hostname, _ = strings.CutPrefix(hostname, v1alpha1.WildcardPrefix)
+	return hostname
+}
@@ -0,0 +1,338 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/pkg/identity"
+)
+
+// testTarget is a minimal Target for builder tests.
+type testTarget struct {
+	name      string
+	shortCode string
+	addresses []TargetAddress
+}
+
+func (t testTarget) GetName() string               { return t.name }
+func (t testTarget) GetShortCode() string          { return t.shortCode }
+func (t testTarget) GetAddresses() []TargetAddress { return t.addresses }
+
+func TestEndpointsBuilder_Simple(t *testing.T) {
+	target := testTarget{
+		name: "test-gateway",
+		addresses: []TargetAddress{
+			{Type: IPAddressType, Value: "172.32.200.1"},
+			{Type: IPAddressType, Value: "172.32.200.2"},
+			{Type: HostnameAddressType, Value: "lb.example.net"},
+		},
+	}
+
+	endpoints, err := NewEndpointsBuilder(target, "app.example.com").Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	if len(endpoints) != 2 {
+		t.Fatalf("Build() expected 2 endpoints, got %d", len(endpoints))
+	}
+
+	a := endpoints[0]
+	if a.DNSName != "app.example.com" || a.RecordType != externaldnsendpoint.RecordTypeA {
+		t.Errorf("expected A endpoint for app.example.com, got %v", a)
+	}
+	if len(a.Targets) != 2 {
+		t.Errorf("expected 2 A targets, got %v", a.Targets)
+	}
+	cname := endpoints[1]
+	if cname.RecordType != externaldnsendpoint.RecordTypeCNAME || cname.Targets[0] != "lb.example.net" {
+		t.Errorf("expected CNAME endpoint to lb.example.net, got %v", cname)
+	}
+}
+
+func TestEndpointsBuilder_LoadBalanced(t *testing.T) {
+	target := testTarget{
+		name:      "test-gateway",
+		shortCode: "2q5hyv",
+		addresses: []TargetAddress{
+			{Type: IPAddressType, Value: "172.32.200.1"},
+		},
+	}
+
+	endpoints, err := NewEndpointsBuilder(target, "app.example.com").
+		WithLoadBalancing(&LoadBalancing{Geo: "GEO-EU", DefaultGeo: true, Weight: 200}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	clusterName := "2q5hyv-" + identity.ShortCode("test-gateway") + ".klb.app.example.com"
+
+	byName := map[string][]*externaldnsendpoint.Endpoint{}
+	for _, ep := range endpoints {
+		byName[ep.DNSName] = append(byName[ep.DNSName], ep)
+	}
+
+	cluster := byName[clusterName]
+	if len(cluster) != 1 || cluster[0].RecordType != externaldnsendpoint.RecordTypeA || cluster[0].Targets[0] != "172.32.200.1" {
+		t.Errorf("expected cluster A endpoint %q -> 172.32.200.1, got %v", clusterName, cluster)
+	}
+
+	geo := byName["geo-eu.klb.app.example.com"]
+	if len(geo) != 1 || geo[0].Targets[0] != clusterName {
+		t.Fatalf("expected geo endpoint -> %q, got %v", clusterName, geo)
+	}
+	if weight, ok := geo[0].GetProviderSpecificProperty(ProviderSpecificWeight); !ok || weight != "200" {
+		t.Errorf("expected geo endpoint weight 200, got %q", weight)
+	}
+
+	lb := byName["klb.app.example.com"]
+	if len(lb) != 2 {
+		t.Fatalf("expected geo routed and default lb endpoints, got %v", lb)
+	}
+	var geos []string
+	for _, ep := range lb {
+		code, _ := ep.GetProviderSpecificProperty(ProviderSpecificGeoCode)
+		geos = append(geos, code)
+		if ep.Targets[0] != "geo-eu.klb.app.example.com" {
+			t.Errorf("expected lb endpoint -> geo-eu.klb.app.example.com, got %v", ep)
+		}
+	}
+	if strings.Join(geos, ",") != "GEO-EU,*" {
+		t.Errorf("expected lb geo codes [GEO-EU *], got %v", geos)
+	}
+
+	root := byName["app.example.com"]
+	if len(root) != 1 || root[0].Targets[0] != "klb.app.example.com" {
+		t.Errorf("expected root CNAME -> klb.app.example.com, got %v", root)
+	}
+}
+
+func TestEndpointsBuilder_Wildcard(t *testing.T) {
+	target := testTarget{
+		name:      "test-gateway",
+		shortCode: "2q5hyv",
+		addresses: []TargetAddress{{Type: IPAddressType, Value: "172.32.200.1"}},
+	}
+
+	endpoints, err := NewEndpointsBuilder(target, "*.example.com").
+		WithLoadBalancing(&LoadBalancing{Geo: "GEO-EU"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	// the wildcard stays on the root endpoint but is stripped from the
+	// generated lb labels
+	sawRoot := false
+	for _, ep := range endpoints {
+		if ep.DNSName == "*.example.com" {
+			sawRoot = true
+		}
+		if strings.Contains(ep.DNSName, "klb.*") {
+			t.Errorf("wildcard leaked into lb label: %q", ep.DNSName)
+		}
+	}
+	if !sawRoot {
+		t.Errorf("expected an endpoint for the wildcard root, got %v", endpoints)
+	}
+}
+
+func TestEndpointsBuilder_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *EndpointsBuilder
+		wantErr string
+	}{
+		{
+			name: "missing hostname",
+			build: func() *EndpointsBuilder {
+				return NewEndpointsBuilder(testTarget{addresses: []TargetAddress{{Type: IPAddressType, Value: "1.1.1.1"}}}, "")
+			},
+			wantErr: "hostname is required",
+		},
+		{
+			name: "no addresses",
+			build: func() *EndpointsBuilder {
+				return NewEndpointsBuilder(testTarget{name: "gw"}, "app.example.com")
+			},
+			wantErr: "has no addresses",
+		},
+		{
+			name: "load balancing without geo",
+			build: func() *EndpointsBuilder {
+				return NewEndpointsBuilder(testTarget{name: "gw", shortCode: "abc123", addresses: []TargetAddress{{Type: IPAddressType, Value: "1.1.1.1"}}}, "app.example.com").
+					WithLoadBalancing(&LoadBalancing{})
+			},
+			wantErr: "loadBalancing.geo is required",
+		},
+		{
+			name: "load balancing without shortcode",
+			build: func() *EndpointsBuilder {
+				return NewEndpointsBuilder(testTarget{name: "gw", addresses: []TargetAddress{{Type: IPAddressType, Value: "1.1.1.1"}}}, "app.example.com").
+					WithLoadBalancing(&LoadBalancing{Geo: "GEO-EU"})
+			},
+			wantErr: "has no shortcode",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build().Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEndpointsBuilder_GeoOverride(t *testing.T) {
+	target := testTarget{
+		name:      "test-gateway",
+		shortCode: "2q5hyv",
+		addresses: []TargetAddress{{Type: IPAddressType, Value: "172.32.200.1"}},
+	}
+
+	endpoints, err := NewEndpointsBuilder(target, "app.example.com").
+		WithLoadBalancing(&LoadBalancing{Geo: "GEO-EU"}).
+		WithGeo("GEO-US").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	sawOverride := false
+	for _, ep := range endpoints {
+		if code, ok := ep.GetProviderSpecificProperty(ProviderSpecificGeoCode); ok {
+			if code != "GEO-US" {
+				t.Errorf("expected geo override GEO-US, got %q", code)
+			}
+			sawOverride = true
+		}
+		if strings.Contains(ep.DNSName, "geo-eu") {
+			t.Errorf("expected geo-us labels, got %q", ep.DNSName)
+		}
+	}
+	if !sawOverride {
+		t.Errorf("expected a geo routed endpoint, got %v", endpoints)
+	}
+
+	if got := GeoFromLabels(map[string]string{LBAttributeGeoCodeLabel: "GEO-EU"}); got != "GEO-EU" {
+		t.Errorf("GeoFromLabels() = %q, want GEO-EU", got)
+	}
+}
+
+func TestEndpointsBuilder_AdditionalGeos(t *testing.T) {
+	target := testTarget{
+		name:      "test-gateway",
+		shortCode: "2q5hyv",
+		addresses: []TargetAddress{{Type: IPAddressType, Value: "172.32.200.1"}},
+	}
+
+	endpoints, err := NewEndpointsBuilder(target, "app.example.com").
+		WithLoadBalancing(&LoadBalancing{Geo: "GEO-US", AdditionalGeos: []string{"GEO-CA", "GEO-US"}}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	geoTargets := map[string]int{}
+	lbGeoCodes := map[string]int{}
+	for _, ep := range endpoints {
+		if strings.HasPrefix(ep.DNSName, "geo-") {
+			geoTargets[ep.DNSName]++
+		}
+		if ep.DNSName == "klb.app.example.com" {
+			if code, ok := ep.GetProviderSpecificProperty(ProviderSpecificGeoCode); ok {
+				lbGeoCodes[code]++
+			}
+		}
+	}
+	for _, geoName := range []string{"geo-us.klb.app.example.com", "geo-ca.klb.app.example.com"} {
+		if geoTargets[geoName] != 1 {
+			t.Errorf("expected one weighted endpoint for %q, got %d", geoName, geoTargets[geoName])
+		}
+	}
+	for _, code := range []string{"GEO-US", "GEO-CA"} {
+		if lbGeoCodes[code] != 1 {
+			t.Errorf("expected one geo routed endpoint for %q, got %d", code, lbGeoCodes[code])
+		}
+	}
+}
+
+func TestEndpointsBuilder_TrafficShaping(t *testing.T) {
+	target := testTarget{
+		name:      "test-gateway",
+		shortCode: "2q5hyv",
+		addresses: []TargetAddress{{Type: IPAddressType, Value: "172.32.200.1"}},
+	}
+
+	if _, err := NewEndpointsBuilder(target, "app.example.com").
+		WithLoadBalancing(&LoadBalancing{Geo: "GEO-US", Bias: 150}).
+		Build(); err == nil {
+		t.Errorf("expected an out of range bias to fail validation")
+	}
+
+	endpoints, err := NewEndpointsBuilder(target, "app.example.com").
+		WithLoadBalancing(&LoadBalancing{Geo: "GEO-US", Bias: -20, EnableClientSubnet: true}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	for _, ep := range endpoints {
+		if _, ok := ep.GetProviderSpecificProperty(ProviderSpecificGeoCode); !ok {
+			continue
+		}
+		if bias, ok := ep.GetProviderSpecificProperty(ProviderSpecificBias); !ok || bias != "-20" {
+			t.Errorf("expected bias -20 on geo endpoint, got %q", bias)
+		}
+		if ecs, ok := ep.GetProviderSpecificProperty(ProviderSpecificClientSubnet); !ok || ecs != "true" {
+			t.Errorf("expected client-subnet true on geo endpoint, got %q", ecs)
+		}
+		return
+	}
+	t.Errorf("expected a geo routed endpoint, got %v", endpoints)
+}
+
+func TestEndpointsBuilder_Geoproximity(t *testing.T) {
+	target := testTarget{
+		name:      "test-gateway",
+		shortCode: "2q5hyv",
+		addresses: []TargetAddress{{Type: IPAddressType, Value: "172.32.200.1"}},
+	}
+	coord := func(v float64) *float64 { return &v }
+
+	if _, err := NewEndpointsBuilder(target, "app.example.com").
+		WithLoadBalancing(&LoadBalancing{Geoproximity: &Geoproximity{Latitude: coord(53.3)}}).
+		Build(); err == nil {
+		t.Errorf("expected a lone latitude to fail validation")
+	}
+
+	endpoints, err := NewEndpointsBuilder(target, "app.example.com").
+		WithLoadBalancing(&LoadBalancing{Geoproximity: &Geoproximity{Latitude: coord(53.3), Longitude: coord(-6.2), Bias: 10}}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	for _, ep := range endpoints {
+		if ep.DNSName != "klb.app.example.com" {
+			continue
+		}
+		if lat, ok := ep.GetProviderSpecificProperty(ProviderSpecificLatitude); !ok || lat != "53.3" {
+			t.Errorf("expected latitude 53.3, got %q", lat)
+		}
+		if long, ok := ep.GetProviderSpecificProperty(ProviderSpecificLongitude); !ok || long != "-6.2" {
+			t.Errorf("expected longitude -6.2, got %q", long)
+		}
+		if bias, ok := ep.GetProviderSpecificProperty(ProviderSpecificBias); !ok || bias != "10" {
+			t.Errorf("expected bias 10, got %q", bias)
+		}
+		if _, ok := ep.GetProviderSpecificProperty(ProviderSpecificGeoCode); ok {
+			t.Errorf("expected no geo code in geoproximity mode")
+		}
+		return
+	}
+	t.Errorf("expected a geoproximity routed endpoint, got %v", endpoints)
+}
@@ -0,0 +1,79 @@
+package builder
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// DNSRecordBuilder constructs a complete DNSRecord in a fluent chain,
+// typically from endpoints generated by an EndpointsBuilder.
+type DNSRecordBuilder struct {
+	record *v1alpha1.DNSRecord
+	ttl    externaldnsendpoint.TTL
+}
+
+// NewDNSRecordBuilder returns a builder for a DNSRecord with the given
+// namespace and name.
+func NewDNSRecordBuilder(namespace, name string) *DNSRecordBuilder {
+	return &DNSRecordBuilder{
+		record: &v1alpha1.DNSRecord{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		},
+	}
+}
+
+// WithRootHost sets the rootHost of the record.
+func (b *DNSRecordBuilder) WithRootHost(rootHost string) *DNSRecordBuilder {
+	b.record.Spec.RootHost = rootHost
+	return b
+}
+
+// WithProviderRef sets the provider secret the record publishes through.
+func (b *DNSRecordBuilder) WithProviderRef(name string) *DNSRecordBuilder {
+	b.record.Spec.ProviderRef = v1alpha1.ProviderRef{Name: name}
+	return b
+}
+
+// WithOwnerID sets an explicit owner id on the record.
+func (b *DNSRecordBuilder) WithOwnerID(ownerID string) *DNSRecordBuilder {
+	b.record.Spec.OwnerID = ownerID
+	return b
+}
+
+// WithEndpoints sets the endpoints of the record.
+func (b *DNSRecordBuilder) WithEndpoints(endpoints []*externaldnsendpoint.Endpoint) *DNSRecordBuilder {
+	b.record.Spec.Endpoints = endpoints
+	return b
+}
+
+// WithHealthCheck configures health checking of the record endpoints. The
+// spec is validated when Build is called.
+func (b *DNSRecordBuilder) WithHealthCheck(healthCheck *v1alpha1.HealthCheckSpec) *DNSRecordBuilder {
+	b.record.Spec.HealthCheck = healthCheck
+	return b
+}
+
+// WithTTL overrides the TTL of every endpoint of the record.
+func (b *DNSRecordBuilder) WithTTL(ttl externaldnsendpoint.TTL) *DNSRecordBuilder {
+	b.ttl = ttl
+	return b
+}
+
+// Build validates and returns the record. The record is returned in the
+// state the mutating webhook would admit it, with any TTL override applied.
+func (b *DNSRecordBuilder) Build() (*v1alpha1.DNSRecord, error) {
+	record := b.record.DeepCopy()
+	if b.ttl > 0 {
+		for _, ep := range record.Spec.Endpoints {
+			ep.RecordTTL = b.ttl
+		}
+	}
+	record.Default()
+	if err := record.Validate(); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,107 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+func TestDNSRecordBuilder_Build(t *testing.T) {
+	endpoints := []*externaldnsendpoint.Endpoint{
+		externaldnsendpoint.NewEndpoint("app.example.com", "A", "172.32.200.1"),
+	}
+
+	record, err := NewDNSRecordBuilder("test-ns", "test-record").
+		WithRootHost("app.example.com").
+		WithProviderRef("dns-credentials").
+		WithEndpoints(endpoints).
+		WithTTL(120).
+		WithHealthCheck(&v1alpha1.HealthCheckSpec{
+			Path:             "/healthz",
+			Port:             443,
+			Protocol:         v1alpha1.HttpsProtocol,
+			FailureThreshold: 3,
+			Interval:         metav1.Duration{Duration: time.Minute},
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	if record.Spec.RootHost != "app.example.com" || record.Spec.ProviderRef.Name != "dns-credentials" {
+		t.Errorf("unexpected record spec: %+v", record.Spec)
+	}
+	if record.Spec.Endpoints[0].RecordTTL != 120 {
+		t.Errorf("expected TTL override 120, got %d", record.Spec.Endpoints[0].RecordTTL)
+	}
+	if record.Spec.HealthCheck == nil || record.Spec.HealthCheck.Port != 443 {
+		t.Errorf("expected health check on record, got %+v", record.Spec.HealthCheck)
+	}
+	// the builder must not mutate its own state on Build
+	if endpoints[0].RecordTTL == 120 {
+		t.Errorf("Build() mutated the input endpoints")
+	}
+}
+
+func TestDNSRecordBuilder_HealthCheckValidation(t *testing.T) {
+	valid := func() *v1alpha1.HealthCheckSpec {
+		return &v1alpha1.HealthCheckSpec{
+			Path:             "/healthz",
+			Port:             443,
+			Protocol:         v1alpha1.HttpsProtocol,
+			FailureThreshold: 3,
+			Interval:         metav1.Duration{Duration: time.Minute},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*v1alpha1.HealthCheckSpec)
+		wantErr string
+	}{
+		{
+			name:    "path without leading slash",
+			mutate:  func(s *v1alpha1.HealthCheckSpec) { s.Path = "healthz" },
+			wantErr: "invalid healthCheck path",
+		},
+		{
+			name:    "port out of range",
+			mutate:  func(s *v1alpha1.HealthCheckSpec) { s.Port = 65536 },
+			wantErr: "invalid healthCheck port",
+		},
+		{
+			name:    "unknown protocol",
+			mutate:  func(s *v1alpha1.HealthCheckSpec) { s.Protocol = "TCP" },
+			wantErr: "invalid healthCheck protocol",
+		},
+		{
+			name:    "zero failure threshold",
+			mutate:  func(s *v1alpha1.HealthCheckSpec) { s.FailureThreshold = 0 },
+			wantErr: "invalid healthCheck failureThreshold",
+		},
+		{
+			name:    "interval too short",
+			mutate:  func(s *v1alpha1.HealthCheckSpec) { s.Interval.Duration = time.Second },
+			wantErr: "invalid healthCheck interval",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthCheck := valid()
+			tt.mutate(healthCheck)
+			_, err := NewDNSRecordBuilder("test-ns", "test-record").
+				WithRootHost("app.example.com").
+				WithProviderRef("dns-credentials").
+				WithHealthCheck(healthCheck).
+				Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+// Provider secret data keys per provider type.
+const (
+	// AWS
+	AWSAccessKeyIDKey     = "AWS_ACCESS_KEY_ID"
+	AWSSecretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+	AWSRegionKey          = "AWS_REGION"
+	// GCP
+	GCPServiceAccountJSONKey = "GOOGLE"
+	// GCPProjectIDKey lists the project ids searched during zone
+	// assignment, comma separated when zones span several projects.
+	GCPProjectIDKey = "PROJECT_ID"
+	// Azure
+	AzureCredentialsJSONKey = "azure.json"
+	// AzureSubscriptionIDsKey and AzureResourceGroupsKey list the
+	// subscriptions and resource groups searched during zone assignment,
+	// comma separated, when zones span more than the ones named in the
+	// credentials file.
+	AzureSubscriptionIDsKey = "AZURE_SUBSCRIPTION_IDS"
+	AzureResourceGroupsKey  = "AZURE_RESOURCE_GROUPS"
+	// In-memory
+	InMemoryZonesKey = "ZONES"
+)
+
+// requiredKeys are the data keys a provider secret of each type must carry.
+var requiredKeys = map[corev1.SecretType][]string{
+	provider.SecretTypeKuadrantAWS:   {AWSAccessKeyIDKey, AWSSecretAccessKeyKey},
+	provider.SecretTypeKuadrantGCP:   {GCPServiceAccountJSONKey, GCPProjectIDKey},
+	provider.SecretTypeKuadrantAzure: {AzureCredentialsJSONKey},
+	provider.SecretTypeKuadrantInMem: nil,
+}
+
+// ProviderBuilder constructs provider credential Secrets of any supported
+// type in a fluent chain, so test suites and downstream operators do not
+// hand-roll them.
+type ProviderBuilder struct {
+	name       string
+	namespace  string
+	secretType corev1.SecretType
+	data       map[string][]byte
+}
+
+// NewProviderBuilder returns a builder for a provider secret with the given
+// namespace and name.
+func NewProviderBuilder(namespace, name string) *ProviderBuilder {
+	return &ProviderBuilder{
+		name:      name,
+		namespace: namespace,
+		data:      map[string][]byte{},
+	}
+}
+
+// WithAWSAccessKey makes the builder produce an aws provider secret with the
+// given static credentials.
+func (b *ProviderBuilder) WithAWSAccessKey(accessKeyID, secretAccessKey string) *ProviderBuilder {
+	b.secretType = provider.SecretTypeKuadrantAWS
+	b.data[AWSAccessKeyIDKey] = []byte(accessKeyID)
+	b.data[AWSSecretAccessKeyKey] = []byte(secretAccessKey)
+	return b
+}
+
+// WithAWSRegion sets the region of an aws provider secret.
+func (b *ProviderBuilder) WithAWSRegion(region string) *ProviderBuilder {
+	b.data[AWSRegionKey] = []byte(region)
+	return b
+}
+
+// WithGCPServiceAccountJSON makes the builder produce a gcp provider secret
+// with the given service account credentials and projects. Zones are
+// discovered across all given projects during zone assignment.
+func (b *ProviderBuilder) WithGCPServiceAccountJSON(serviceAccountJSON []byte, projectIDs ...string) *ProviderBuilder {
+	b.secretType = provider.SecretTypeKuadrantGCP
+	b.data[GCPServiceAccountJSONKey] = serviceAccountJSON
+	b.data[GCPProjectIDKey] = []byte(strings.Join(projectIDs, ","))
+	return b
+}
+
+// WithAzureCredentialsJSON makes the builder produce an azure provider
+// secret with the given credentials file content.
+func (b *ProviderBuilder) WithAzureCredentialsJSON(credentialsJSON []byte) *ProviderBuilder {
+	b.secretType = provider.SecretTypeKuadrantAzure
+	b.data[AzureCredentialsJSONKey] = credentialsJSON
+	return b
+}
+
+// WithAzureSubscriptions sets the subscriptions searched during zone
+// assignment of an azure provider secret.
+func (b *ProviderBuilder) WithAzureSubscriptions(subscriptionIDs ...string) *ProviderBuilder {
+	b.data[AzureSubscriptionIDsKey] = []byte(strings.Join(subscriptionIDs, ","))
+	return b
+}
+
+// WithAzureResourceGroups sets the resource groups searched during zone
+// assignment of an azure provider secret.
+func (b *ProviderBuilder) WithAzureResourceGroups(resourceGroups ...string) *ProviderBuilder {
+	b.data[AzureResourceGroupsKey] = []byte(strings.Join(resourceGroups, ","))
+	return b
+}
+
+// WithInMemoryZones makes the builder produce an inmemory provider secret
+// initialized with the given zones.
+func (b *ProviderBuilder) WithInMemoryZones(zones ...string) *ProviderBuilder {
+	b.secretType = provider.SecretTypeKuadrantInMem
+	if len(zones) > 0 {
+		b.data[InMemoryZonesKey] = []byte(strings.Join(zones, ","))
+	}
+	return b
+}
+
+// WithDataValue sets an arbitrary data key on the secret, e.g. a registry
+// override such as TXT_PREFIX.
+func (b *ProviderBuilder) WithDataValue(key, value string) *ProviderBuilder {
+	b.data[key] = []byte(value)
+	return b
+}
+
+// Build validates and returns the provider secret.
+func (b *ProviderBuilder) Build() (*corev1.Secret, error) {
+	required, ok := requiredKeys[b.secretType]
+	if !ok {
+		return nil, fmt.Errorf("provider type not set, use one of the With<Provider> methods")
+	}
+	for _, key := range required {
+		if len(b.data[key]) == 0 {
+			return nil, fmt.Errorf("provider secret of type %q requires data key %q", b.secretType, key)
+		}
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: b.namespace, Name: b.name},
+		Type:       b.secretType,
+		Data:       b.data,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/provider"
+)
+
+func TestProviderBuilder_Build(t *testing.T) {
+	secret, err := NewProviderBuilder("test-ns", "dns-credentials").
+		WithAWSAccessKey("AKIA123", "secret").
+		WithAWSRegion("eu-west-1").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	if secret.Type != provider.SecretTypeKuadrantAWS {
+		t.Errorf("expected secret type %q, got %q", provider.SecretTypeKuadrantAWS, secret.Type)
+	}
+	if string(secret.Data[AWSAccessKeyIDKey]) != "AKIA123" || string(secret.Data[AWSRegionKey]) != "eu-west-1" {
+		t.Errorf("unexpected secret data: %v", secret.Data)
+	}
+
+	secret, err = NewProviderBuilder("test-ns", "dns-credentials").
+		WithInMemoryZones("example.com", "example.org").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	if string(secret.Data[InMemoryZonesKey]) != "example.com,example.org" {
+		t.Errorf("unexpected zones: %q", secret.Data[InMemoryZonesKey])
+	}
+}
+
+func TestProviderBuilder_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *ProviderBuilder
+		wantErr string
+	}{
+		{
+			name:    "no provider type",
+			build:   func() *ProviderBuilder { return NewProviderBuilder("test-ns", "creds") },
+			wantErr: "provider type not set",
+		},
+		{
+			name: "aws without secret access key",
+			build: func() *ProviderBuilder {
+				return NewProviderBuilder("test-ns", "creds").WithAWSAccessKey("AKIA123", "")
+			},
+			wantErr: "requires data key \"AWS_SECRET_ACCESS_KEY\"",
+		},
+		{
+			name: "gcp without project id",
+			build: func() *ProviderBuilder {
+				return NewProviderBuilder("test-ns", "creds").WithGCPServiceAccountJSON([]byte("{}"), "")
+			},
+			wantErr: "requires data key \"PROJECT_ID\"",
+		},
+		{
+			name: "azure without credentials",
+			build: func() *ProviderBuilder {
+				return NewProviderBuilder("test-ns", "creds").WithAzureCredentialsJSON(nil)
+			},
+			wantErr: "requires data key \"azure.json\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build().Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
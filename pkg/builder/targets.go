@@ -0,0 +1,122 @@
+package builder
+
+import (
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	routev1 "github.com/openshift/api/route/v1"
+)
+
+// IngressTarget adapts a networking.k8s.io Ingress to the builder, exposing
+// the load balancer addresses from its status.
+type IngressTarget struct {
+	Ingress *networkingv1.Ingress
+	// ShortCode identifies the publishing cluster, typically the cluster id
+	// from pkg/identity. Only required for load balanced generation.
+	ShortCode string
+}
+
+var _ Target = IngressTarget{}
+
+func (t IngressTarget) GetName() string {
+	return t.Ingress.GetName()
+}
+
+func (t IngressTarget) GetShortCode() string {
+	return t.ShortCode
+}
+
+func (t IngressTarget) GetAddresses() []TargetAddress {
+	var addresses []TargetAddress
+	for _, ingress := range t.Ingress.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, TargetAddress{Type: IPAddressType, Value: ingress.IP})
+		}
+		if ingress.Hostname != "" {
+			addresses = append(addresses, TargetAddress{Type: HostnameAddressType, Value: ingress.Hostname})
+		}
+	}
+	return addresses
+}
+
+// ServiceTarget adapts a core Service of type LoadBalancer to the builder,
+// exposing the load balancer addresses from its status.
+type ServiceTarget struct {
+	Service *corev1.Service
+	// ShortCode identifies the publishing cluster, typically the cluster id
+	// from pkg/identity. Only required for load balanced generation.
+	ShortCode string
+}
+
+var _ Target = ServiceTarget{}
+
+func (t ServiceTarget) GetName() string {
+	return t.Service.GetName()
+}
+
+func (t ServiceTarget) GetShortCode() string {
+	return t.ShortCode
+}
+
+func (t ServiceTarget) GetAddresses() []TargetAddress {
+	var addresses []TargetAddress
+	for _, ingress := range t.Service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, TargetAddress{Type: IPAddressType, Value: ingress.IP})
+		}
+		if ingress.Hostname != "" {
+			addresses = append(addresses, TargetAddress{Type: HostnameAddressType, Value: ingress.Hostname})
+		}
+	}
+	return addresses
+}
+
+// RouteTarget adapts an OpenShift Route to the builder, exposing the
+// canonical hostname of each router that admitted the route.
+type RouteTarget struct {
+	Route *routev1.Route
+	// ShortCode identifies the publishing cluster, typically the cluster id
+	// from pkg/identity. Only required for load balanced generation.
+	ShortCode string
+}
+
+var _ Target = RouteTarget{}
+
+func (t RouteTarget) GetName() string {
+	return t.Route.GetName()
+}
+
+func (t RouteTarget) GetShortCode() string {
+	return t.ShortCode
+}
+
+func (t RouteTarget) GetAddresses() []TargetAddress {
+	var addresses []TargetAddress
+	for _, ingress := range t.Route.Status.Ingress {
+		if ingress.RouterCanonicalHostname == "" || !routeIngressAdmitted(ingress) {
+			continue
+		}
+		addresses = append(addresses, addressFor(ingress.RouterCanonicalHostname))
+	}
+	return addresses
+}
+
+// routeIngressAdmitted reports whether a router has admitted the route.
+func routeIngressAdmitted(ingress routev1.RouteIngress) bool {
+	for _, condition := range ingress.Conditions {
+		if condition.Type == routev1.RouteAdmitted {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// addressFor classifies a value as an ip or hostname address.
+func addressFor(value string) TargetAddress {
+	if net.ParseIP(value) != nil {
+		return TargetAddress{Type: IPAddressType, Value: value}
+	}
+	return TargetAddress{Type: HostnameAddressType, Value: value}
+}
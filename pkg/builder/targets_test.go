@@ -0,0 +1,89 @@
+package builder
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	routev1 "github.com/openshift/api/route/v1"
+)
+
+func TestIngressTarget_GetAddresses(t *testing.T) {
+	target := IngressTarget{
+		Ingress: &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ingress"},
+			Status: networkingv1.IngressStatus{
+				LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+					Ingress: []networkingv1.IngressLoadBalancerIngress{
+						{IP: "172.32.200.1"},
+						{Hostname: "lb.example.net"},
+					},
+				},
+			},
+		},
+	}
+
+	want := []TargetAddress{
+		{Type: IPAddressType, Value: "172.32.200.1"},
+		{Type: HostnameAddressType, Value: "lb.example.net"},
+	}
+	if got := target.GetAddresses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAddresses() = %v, want %v", got, want)
+	}
+	if target.GetName() != "test-ingress" {
+		t.Errorf("GetName() = %q, want %q", target.GetName(), "test-ingress")
+	}
+}
+
+func TestServiceTarget_GetAddresses(t *testing.T) {
+	target := ServiceTarget{
+		Service: &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-service"},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{
+						{IP: "172.32.200.1", Hostname: "lb.example.net"},
+					},
+				},
+			},
+		},
+	}
+
+	want := []TargetAddress{
+		{Type: IPAddressType, Value: "172.32.200.1"},
+		{Type: HostnameAddressType, Value: "lb.example.net"},
+	}
+	if got := target.GetAddresses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAddresses() = %v, want %v", got, want)
+	}
+}
+
+func TestRouteTarget_GetAddresses(t *testing.T) {
+	admitted := []routev1.RouteIngressCondition{
+		{Type: routev1.RouteAdmitted, Status: corev1.ConditionTrue},
+	}
+	target := RouteTarget{
+		Route: &routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-route"},
+			Status: routev1.RouteStatus{
+				Ingress: []routev1.RouteIngress{
+					{RouterCanonicalHostname: "router-default.apps.example.com", Conditions: admitted},
+					{RouterCanonicalHostname: "172.32.200.1", Conditions: admitted},
+					// not admitted, must be skipped
+					{RouterCanonicalHostname: "router-other.apps.example.com"},
+				},
+			},
+		},
+	}
+
+	want := []TargetAddress{
+		{Type: HostnameAddressType, Value: "router-default.apps.example.com"},
+		{Type: IPAddressType, Value: "172.32.200.1"},
+	}
+	if got := target.GetAddresses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAddresses() = %v, want %v", got, want)
+	}
+}
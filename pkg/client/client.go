@@ -0,0 +1,80 @@
+// Package client provides typed helpers for managing DNSRecords from other
+// operators and test suites, wrapping the controller-runtime client with the
+// create-or-update and wait-for-ready patterns callers otherwise
+// re-implement.
+package client
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// pollInterval is how often waiting helpers re-read the record.
+const pollInterval = 2 * time.Second
+
+// CreateOrUpdateDNSRecord creates the desired record or updates the spec,
+// labels and annotations of the existing one, returning the operation
+// performed.
+func CreateOrUpdateDNSRecord(ctx context.Context, c crclient.Client, desired *v1alpha1.DNSRecord) (controllerutil.OperationResult, error) {
+	current := &v1alpha1.DNSRecord{}
+	current.Namespace = desired.Namespace
+	current.Name = desired.Name
+	return controllerutil.CreateOrUpdate(ctx, c, current, func() error {
+		current.Labels = desired.Labels
+		current.Annotations = desired.Annotations
+		current.Spec = *desired.Spec.DeepCopy()
+		return nil
+	})
+}
+
+// WaitForReady polls the record until its Ready condition is true for the
+// current generation, returning the last observed record. It returns the
+// context error when the timeout elapses first.
+func WaitForReady(ctx context.Context, c crclient.Client, key crclient.ObjectKey, timeout time.Duration) (*v1alpha1.DNSRecord, error) {
+	record := &v1alpha1.DNSRecord{}
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.Get(ctx, key, record); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return IsReady(record), nil
+	})
+	return record, err
+}
+
+// DeleteAndWait deletes the record and polls until provider cleanup has
+// finished and the record is gone.
+func DeleteAndWait(ctx context.Context, c crclient.Client, record *v1alpha1.DNSRecord, timeout time.Duration) error {
+	if err := c.Delete(ctx, record); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	key := crclient.ObjectKeyFromObject(record)
+	return wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.Get(ctx, key, &v1alpha1.DNSRecord{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return false, nil
+	})
+}
+
+// IsReady returns true when the records Ready condition is true for its
+// current generation.
+func IsReady(record *v1alpha1.DNSRecord) bool {
+	condition := meta.FindStatusCondition(record.Status.Conditions, v1alpha1.ConditionTypeReady)
+	return condition != nil &&
+		condition.Status == "True" &&
+		condition.ObservedGeneration == record.Generation
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+func TestIsReady(t *testing.T) {
+	record := func(generation, observed int64, status metav1.ConditionStatus) *v1alpha1.DNSRecord {
+		r := &v1alpha1.DNSRecord{}
+		r.Generation = generation
+		r.Status.Conditions = []metav1.Condition{{
+			Type:               v1alpha1.ConditionTypeReady,
+			Status:             status,
+			ObservedGeneration: observed,
+		}}
+		return r
+	}
+
+	testCases := []struct {
+		name   string
+		record *v1alpha1.DNSRecord
+		want   bool
+	}{
+		{
+			name:   "ready for the current generation",
+			record: record(2, 2, metav1.ConditionTrue),
+			want:   true,
+		},
+		{
+			name:   "ready for a stale generation",
+			record: record(3, 2, metav1.ConditionTrue),
+			want:   false,
+		},
+		{
+			name:   "not ready",
+			record: record(2, 2, metav1.ConditionFalse),
+			want:   false,
+		},
+		{
+			name:   "no conditions",
+			record: &v1alpha1.DNSRecord{},
+			want:   false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := IsReady(testCase.record); got != testCase.want {
+				t.Errorf("IsReady() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package endpoints
+
+import (
+	"net/netip"
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// Normalize canonicalizes endpoints in place so semantically identical specs
+// compare equal against the canonicalized values providers return: dnsNames
+// are lowercased, stripped of trailing dots and take their punycode form
+// when internationalized, hostname targets likewise, IPv6 targets take their
+// canonical compressed form, and duplicate targets within an endpoint are
+// dropped. Without this, case or formatting differences cause perpetual
+// update churn against the provider.
+func Normalize(endpoints []*externaldnsendpoint.Endpoint) {
+	for _, ep := range endpoints {
+		ep.DNSName = normalizeDNSName(ep.DNSName)
+
+		seen := map[string]struct{}{}
+		targets := ep.Targets[:0]
+		for _, target := range ep.Targets {
+			normalized := normalizeTarget(target, ep.RecordType)
+			if _, ok := seen[normalized]; ok {
+				continue
+			}
+			seen[normalized] = struct{}{}
+			targets = append(targets, normalized)
+		}
+		ep.Targets = targets
+	}
+}
+
+// normalizeDNSName lowercases a dnsName, strips a trailing dot and converts
+// internationalized names to their punycode form, the form providers return.
+// A leading wildcard label is preserved as is.
+func normalizeDNSName(dnsName string) string {
+	return v1alpha1.PunycodeHost(strings.TrimSuffix(strings.ToLower(dnsName), "."))
+}
+
+// normalizeTarget canonicalizes one target value. Address targets take the
+// canonical textual form of their address (compressing IPv6), hostname
+// targets of CNAME records are treated like dnsNames, and anything else
+// (e.g. TXT values) is left untouched.
+func normalizeTarget(target, recordType string) string {
+	switch recordType {
+	case externaldnsendpoint.RecordTypeA, externaldnsendpoint.RecordTypeAAAA:
+		if address, err := netip.ParseAddr(target); err == nil {
+			return address.String()
+		}
+		return target
+	case externaldnsendpoint.RecordTypeCNAME, externaldnsendpoint.RecordTypeNS:
+		return normalizeDNSName(target)
+	default:
+		return target
+	}
+}
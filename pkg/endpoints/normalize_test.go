@@ -0,0 +1,54 @@
+package endpoints
+
+import (
+	"reflect"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestNormalize(t *testing.T) {
+	endpoints := []*externaldnsendpoint.Endpoint{
+		{
+			DNSName:    "App.Example.COM.",
+			RecordType: externaldnsendpoint.RecordTypeAAAA,
+			Targets:    []string{"2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1", "2001:db8::2"},
+		},
+		{
+			DNSName:    "www.example.com",
+			RecordType: externaldnsendpoint.RecordTypeCNAME,
+			Targets:    []string{"LB.Provider.Example.ORG."},
+		},
+		{
+			DNSName:    "txt.example.com",
+			RecordType: externaldnsendpoint.RecordTypeTXT,
+			Targets:    []string{"\"CaseSensitive Value.\""},
+		},
+		{
+			DNSName:    "café.example.com",
+			RecordType: externaldnsendpoint.RecordTypeCNAME,
+			Targets:    []string{"münchen.example.org"},
+		},
+	}
+
+	Normalize(endpoints)
+
+	if endpoints[0].DNSName != "app.example.com" {
+		t.Errorf("dnsName not normalized, got %q", endpoints[0].DNSName)
+	}
+	if want := []string{"2001:db8::1", "2001:db8::2"}; !reflect.DeepEqual([]string(endpoints[0].Targets), want) {
+		t.Errorf("AAAA targets = %v, want %v", endpoints[0].Targets, want)
+	}
+	if want := []string{"lb.provider.example.org"}; !reflect.DeepEqual([]string(endpoints[1].Targets), want) {
+		t.Errorf("CNAME targets = %v, want %v", endpoints[1].Targets, want)
+	}
+	if want := []string{"\"CaseSensitive Value.\""}; !reflect.DeepEqual([]string(endpoints[2].Targets), want) {
+		t.Errorf("TXT targets = %v, want %v", endpoints[2].Targets, want)
+	}
+	if endpoints[3].DNSName != "xn--caf-dma.example.com" {
+		t.Errorf("IDN dnsName not converted to punycode, got %q", endpoints[3].DNSName)
+	}
+	if want := []string{"xn--mnchen-3ya.example.org"}; !reflect.DeepEqual([]string(endpoints[3].Targets), want) {
+		t.Errorf("IDN CNAME targets = %v, want %v", endpoints[3].Targets, want)
+	}
+}
@@ -0,0 +1,81 @@
+package endpoints
+
+import (
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+
+	"github.com/mikenairn/kuadrant-dns-operator/api/v1alpha1"
+)
+
+// TargetsFor resolves the given host transitively through the endpoint set
+// and returns the leaf targets it ultimately resolves to: targets that are
+// not themselves dnsNames within the set (IP addresses, external hosts).
+func TargetsFor(host string, endpoints []*externaldnsendpoint.Endpoint) []string {
+	var leaves []string
+	walk(TreeFor(host, endpoints), func(node *Node) {
+		inTree := map[string]struct{}{}
+		for _, child := range node.Children {
+			inTree[child.DNSName] = struct{}{}
+		}
+		for _, ep := range node.Endpoints {
+			for _, target := range ep.Targets {
+				if _, ok := inTree[target]; !ok && !contains(leaves, target) {
+					leaves = append(leaves, target)
+				}
+			}
+		}
+	})
+	return leaves
+}
+
+// EndpointsTraversable reports whether every given host resolves to all of
+// the expected targets by traversing the endpoint set. It is used to verify
+// a generated record tree is complete before publishing, and by consumers to
+// check a hostname will resolve once the record propagates.
+func EndpointsTraversable(endpoints []*externaldnsendpoint.Endpoint, hosts, expectedTargets []string) bool {
+	for _, host := range hosts {
+		leaves := TargetsFor(host, endpoints)
+		for _, target := range expectedTargets {
+			if !contains(leaves, target) {
+				return false
+			}
+		}
+		if len(expectedTargets) > 0 && len(leaves) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterByRootHost returns the endpoints that belong to the given rootHost,
+// those equal to or a subdomain of it. A wildcard prefix on the rootHost is
+// ignored.
+func FilterByRootHost(rootHost string, endpoints []*externaldnsendpoint.Endpoint) []*externaldnsendpoint.Endpoint {
+	rootHost = strings.TrimPrefix(rootHost, v1alpha1.WildcardPrefix)
+	var filtered []*externaldnsendpoint.Endpoint
+	for _, ep := range endpoints {
+		name := strings.TrimPrefix(ep.DNSName, v1alpha1.WildcardPrefix)
+		if name == rootHost || strings.HasSuffix(name, "."+rootHost) {
+			filtered = append(filtered, ep)
+		}
+	}
+	return filtered
+}
+
+// walk visits every node of the tree depth first.
+func walk(node *Node, visit func(*Node)) {
+	visit(node)
+	for _, child := range node.Children {
+		walk(child, visit)
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package endpoints
+
+import (
+	"reflect"
+	"testing"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+func lbTree() []*externaldnsendpoint.Endpoint {
+	return []*externaldnsendpoint.Endpoint{
+		externaldnsendpoint.NewEndpoint("app.example.com", "CNAME", "klb.app.example.com"),
+		externaldnsendpoint.NewEndpoint("klb.app.example.com", "CNAME", "geo-eu.klb.app.example.com"),
+		externaldnsendpoint.NewEndpoint("geo-eu.klb.app.example.com", "CNAME", "abc123-def456.klb.app.example.com"),
+		externaldnsendpoint.NewEndpoint("abc123-def456.klb.app.example.com", "A", "172.32.200.1", "172.32.200.2"),
+	}
+}
+
+func TestTargetsFor(t *testing.T) {
+	got := TargetsFor("app.example.com", lbTree())
+	want := []string{"172.32.200.1", "172.32.200.2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TargetsFor() = %v, want %v", got, want)
+	}
+}
+
+func TestEndpointsTraversable(t *testing.T) {
+	endpoints := lbTree()
+	if !EndpointsTraversable(endpoints, []string{"app.example.com"}, []string{"172.32.200.1", "172.32.200.2"}) {
+		t.Errorf("expected tree to be traversable to all targets")
+	}
+	if EndpointsTraversable(endpoints, []string{"app.example.com"}, []string{"172.32.200.3"}) {
+		t.Errorf("expected traversal to fail for an unknown target")
+	}
+	// break the chain, the geo level no longer resolves to the cluster
+	endpoints[2].Targets = []string{"missing.klb.app.example.com"}
+	if EndpointsTraversable(endpoints, []string{"app.example.com"}, []string{"172.32.200.1"}) {
+		t.Errorf("expected traversal to fail on a broken chain")
+	}
+}
+
+func TestFilterByRootHost(t *testing.T) {
+	endpoints := []*externaldnsendpoint.Endpoint{
+		externaldnsendpoint.NewEndpoint("app.example.com", "A", "172.32.200.1"),
+		externaldnsendpoint.NewEndpoint("klb.app.example.com", "A", "172.32.200.1"),
+		externaldnsendpoint.NewEndpoint("other.example.com", "A", "172.32.200.2"),
+	}
+	got := FilterByRootHost("*.app.example.com", endpoints)
+	if len(got) != 2 || got[0].DNSName != "app.example.com" || got[1].DNSName != "klb.app.example.com" {
+		t.Errorf("FilterByRootHost() = %v", got)
+	}
+}
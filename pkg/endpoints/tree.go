@@ -0,0 +1,88 @@
+// Package endpoints provides utilities for working with sets of external-dns
+// endpoints as published by DNSRecord resources: tree building and
+// rendering, traversal checks and root host filtering.
+package endpoints
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// Node is a node in a tree of endpoints rooted at a records rootHost. Each
+// node is a dnsName and its children are the targets of all endpoints
+// published for that dnsName. Targets that are themselves dnsNames within
+// the endpoint set (e.g. load balancer CNAME chains) become inner nodes,
+// anything else (IP addresses, external hosts) is a leaf.
+type Node struct {
+	DNSName   string
+	Endpoints []*externaldnsendpoint.Endpoint
+	Children  []*Node
+}
+
+// TreeFor builds the endpoint tree for the given rootHost from the given
+// endpoint set.
+func TreeFor(rootHost string, endpoints []*externaldnsendpoint.Endpoint) *Node {
+	byName := map[string][]*externaldnsendpoint.Endpoint{}
+	for _, ep := range endpoints {
+		byName[ep.DNSName] = append(byName[ep.DNSName], ep)
+	}
+	return nodeFor(rootHost, byName, map[string]struct{}{})
+}
+
+func nodeFor(dnsName string, byName map[string][]*externaldnsendpoint.Endpoint, visited map[string]struct{}) *Node {
+	node := &Node{
+		DNSName:   dnsName,
+		Endpoints: byName[dnsName],
+	}
+	if _, ok := visited[dnsName]; ok {
+		// target loops can not resolve, stop here so rendering terminates
+		return node
+	}
+	visited[dnsName] = struct{}{}
+
+	childNames := map[string]struct{}{}
+	for _, ep := range node.Endpoints {
+		for _, target := range ep.Targets {
+			if _, ok := byName[target]; ok {
+				childNames[target] = struct{}{}
+			}
+		}
+	}
+	for childName := range childNames {
+		node.Children = append(node.Children, nodeFor(childName, byName, visited))
+	}
+	sort.Slice(node.Children, func(i, j int) bool {
+		return node.Children[i].DNSName < node.Children[j].DNSName
+	})
+	return node
+}
+
+// RenderTree writes an indented representation of the tree to the given writer.
+func RenderTree(w io.Writer, node *Node) {
+	renderNode(w, node, 0)
+}
+
+func renderNode(w io.Writer, node *Node, depth int) {
+	indent := strings.Repeat("    ", depth)
+	fmt.Fprintf(w, "%s%s\n", indent, node.DNSName)
+	for _, ep := range node.Endpoints {
+		details := []string{ep.RecordType}
+		if ep.SetIdentifier != "" {
+			details = append(details, fmt.Sprintf("setID: %s", ep.SetIdentifier))
+		}
+		for _, ps := range ep.ProviderSpecific {
+			details = append(details, fmt.Sprintf("%s: %s", ps.Name, ps.Value))
+		}
+		if ep.RecordTTL > 0 {
+			details = append(details, fmt.Sprintf("ttl: %d", ep.RecordTTL))
+		}
+		fmt.Fprintf(w, "%s  [%s] -> %s\n", indent, strings.Join(details, ", "), strings.Join(ep.Targets, ", "))
+	}
+	for _, child := range node.Children {
+		renderNode(w, child, depth+1)
+	}
+}
@@ -0,0 +1,65 @@
+// Package identity provides the stable identity of the cluster an operator
+// instance runs in. The cluster id and its derived shortcode are used when
+// generating load balanced endpoint hierarchies, and are exported here so
+// consumers building records against this operator can derive the same
+// values instead of duplicating the hashing.
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mikenairn/kuadrant-dns-operator/internal/common/hash"
+)
+
+const (
+	// ClusterIDLength is the length of generated cluster ids and shortcodes.
+	ClusterIDLength = 6
+
+	// clusterIDNamespace is the namespace whose UID seeds the default
+	// cluster id. kube-system is never deleted and its UID is stable for
+	// the lifetime of a cluster.
+	clusterIDNamespace = "kube-system"
+)
+
+// ClusterIdentity is the set of cluster scoped attributes used when
+// generating endpoints for records published from this cluster.
+type ClusterIdentity struct {
+	// ClusterID is a short stable identifier for the cluster, unique across
+	// the clusters publishing into a shared zone. It owns the cluster's
+	// position in generated load balancer hierarchies.
+	ClusterID string
+
+	// Geo is the geo code endpoints generated by this cluster default to
+	// when a record does not specify one, e.g. "GEO-EU".
+	Geo string
+
+	// CustomWeights maps custom attribute selectors to the weight applied
+	// to endpoints generated for targets matching that attribute.
+	CustomWeights map[string]string
+}
+
+// ShortCode returns the shortcode of an arbitrary name, a short stable hash
+// used to keep generated dns labels within length limits.
+func ShortCode(name string) string {
+	return hash.ToBase36HashLen(name, ClusterIDLength)
+}
+
+// GetClusterIdentity returns the identity of the cluster the given client is
+// connected to. An explicit cluster id takes precedence; otherwise one is
+// derived from the UID of the kube-system namespace, which is stable for the
+// lifetime of the cluster and identical for every operator running in it.
+func GetClusterIdentity(ctx context.Context, c client.Client, clusterID, geo string) (ClusterIdentity, error) {
+	if clusterID == "" {
+		ns := &corev1.Namespace{}
+		if err := c.Get(ctx, types.NamespacedName{Name: clusterIDNamespace}, ns); err != nil {
+			return ClusterIdentity{}, fmt.Errorf("unable to derive cluster id: %w", err)
+		}
+		clusterID = ShortCode(string(ns.GetUID()))
+	}
+	return ClusterIdentity{ClusterID: clusterID, Geo: geo}, nil
+}
@@ -0,0 +1,146 @@
+// Package testdns runs a local authoritative DNS server answering from the
+// records of an external-dns provider, so integration tests can assert
+// actual resolution of generated endpoint trees instead of only inspecting
+// provider state. Typically backed by the in-memory provider.
+package testdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// Source supplies the records the server answers from. The external-dns
+// provider and registry interfaces both satisfy it.
+type Source interface {
+	Records(ctx context.Context) ([]*externaldnsendpoint.Endpoint, error)
+}
+
+// Server is an authoritative resolver for the records of a Source.
+type Server struct {
+	// Addr is the host:port the server listens on.
+	Addr string
+
+	source Source
+	server *dns.Server
+}
+
+// Start listens on an ephemeral loopback port and serves the records of the
+// given source until Stop is called.
+func Start(source Source) (*Server, error) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{Addr: conn.LocalAddr().String(), source: source}
+	s.server = &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(s.handle)}
+
+	go func() {
+		_ = s.server.ActivateAndServe()
+	}()
+	return s, nil
+}
+
+// Stop shuts the server down.
+func (s *Server) Stop() error {
+	return s.server.Shutdown()
+}
+
+// Query resolves a question against this server, returning the answer
+// section.
+func (s *Server) Query(name string, qtype uint16) ([]dns.RR, error) {
+	message := new(dns.Msg)
+	message.SetQuestion(dns.Fqdn(name), qtype)
+	client := &dns.Client{Timeout: 5 * time.Second}
+	response, _, err := client.Exchange(message, s.Addr)
+	if err != nil {
+		return nil, err
+	}
+	return response.Answer, nil
+}
+
+func (s *Server) handle(w dns.ResponseWriter, request *dns.Msg) {
+	response := new(dns.Msg)
+	response.SetReply(request)
+	response.Authoritative = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	records, err := s.source.Records(ctx)
+	if err != nil {
+		response.Rcode = dns.RcodeServerFailure
+		_ = w.WriteMsg(response)
+		return
+	}
+
+	for _, question := range request.Question {
+		answers, found := answersFor(records, question)
+		response.Answer = append(response.Answer, answers...)
+		if !found && len(response.Answer) == 0 {
+			response.Rcode = dns.RcodeNameError
+		}
+	}
+	_ = w.WriteMsg(response)
+}
+
+// answersFor builds the answer section for a question, following CNAMEs
+// within the record set like a resolver would. found reports whether the
+// name exists at all, distinguishing NODATA from NXDOMAIN.
+func answersFor(records []*externaldnsendpoint.Endpoint, question dns.Question) (answers []dns.RR, found bool) {
+	name := question.Name
+	for depth := 0; depth < 10; depth++ {
+		var cname string
+		for _, record := range records {
+			if !strings.EqualFold(dns.Fqdn(record.DNSName), name) {
+				continue
+			}
+			found = true
+			recordType := dns.StringToType[record.RecordType]
+			if recordType != question.Qtype && recordType != dns.TypeCNAME {
+				continue
+			}
+			answers = append(answers, rrsFor(record, name)...)
+			if recordType == dns.TypeCNAME && question.Qtype != dns.TypeCNAME && len(record.Targets) > 0 {
+				cname = dns.Fqdn(record.Targets[0])
+			}
+		}
+		if cname == "" {
+			return answers, found
+		}
+		name = cname
+	}
+	return answers, found
+}
+
+// rrsFor converts an endpoint to resource records for the given fqdn.
+func rrsFor(record *externaldnsendpoint.Endpoint, fqdn string) []dns.RR {
+	ttl := uint32(300)
+	if record.RecordTTL > 0 {
+		ttl = uint32(record.RecordTTL)
+	}
+	var rrs []dns.RR
+	for _, target := range record.Targets {
+		var text string
+		switch record.RecordType {
+		case externaldnsendpoint.RecordTypeTXT:
+			text = fmt.Sprintf("%s %d IN TXT %q", fqdn, ttl, target)
+		case externaldnsendpoint.RecordTypeCNAME:
+			text = fmt.Sprintf("%s %d IN CNAME %s", fqdn, ttl, dns.Fqdn(target))
+		default:
+			text = fmt.Sprintf("%s %d IN %s %s", fqdn, ttl, record.RecordType, target)
+		}
+		rr, err := dns.NewRR(text)
+		if err != nil {
+			continue
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs
+}
@@ -0,0 +1,56 @@
+package testdns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/miekg/dns"
+
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+type staticSource []*externaldnsendpoint.Endpoint
+
+func (s staticSource) Records(_ context.Context) ([]*externaldnsendpoint.Endpoint, error) {
+	return s, nil
+}
+
+func TestServerResolvesEndpointTree(t *testing.T) {
+	source := staticSource{
+		externaldnsendpoint.NewEndpoint("app.example.com", "CNAME", "klb.app.example.com"),
+		externaldnsendpoint.NewEndpoint("klb.app.example.com", "CNAME", "geo-eu.klb.app.example.com"),
+		externaldnsendpoint.NewEndpoint("geo-eu.klb.app.example.com", "A", "172.32.200.1"),
+	}
+
+	server, err := Start(source)
+	if err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	defer func() {
+		if err := server.Stop(); err != nil {
+			t.Errorf("Stop() unexpected error: %v", err)
+		}
+	}()
+
+	answers, err := server.Query("app.example.com", dns.TypeA)
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	var resolved string
+	for _, answer := range answers {
+		if a, ok := answer.(*dns.A); ok {
+			resolved = a.A.String()
+		}
+	}
+	if resolved != "172.32.200.1" {
+		t.Errorf("expected the CNAME chain to resolve to 172.32.200.1, got %q in %v", resolved, answers)
+	}
+
+	answers, err = server.Query("missing.example.com", dns.TypeA)
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	if len(answers) != 0 {
+		t.Errorf("expected no answers for an unknown name, got %v", answers)
+	}
+}